@@ -0,0 +1,225 @@
+package actions
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+
+	"github.com/anchore/chronicle/chronicle/release"
+	"github.com/anchore/chronicle/internal/git"
+	"github.com/anchore/chronicle/internal/log"
+)
+
+// Config controls workflow action detection.
+type Config struct {
+	// Enabled is the master opt-in switch. When false, Detect is a no-op.
+	Enabled bool
+	// Paths are the discovery globs. Empty means DefaultPaths().
+	Paths []string
+}
+
+// DefaultPaths returns the globs for workflow files and the repository's own composite actions
+// (whose steps pull in remote actions just like a workflow does).
+func DefaultPaths() []string {
+	return []string{
+		".github/workflows/*.yml",
+		".github/workflows/*.yaml",
+		".github/actions/**/action.yml",
+		".github/actions/**/action.yaml",
+	}
+}
+
+// fileLister is the slice of git.Interface that detection depends on: reading file content at a
+// ref without a working-tree checkout.
+type fileLister interface {
+	ListFilesAtRef(ref string, match func(path string) bool) ([]git.FileBlob, error)
+}
+
+// Detect reads the workflow files at sinceRef and untilRef and reports each action whose set of
+// references changed, aggregated across all files (moving a step between workflows is not a
+// change). It degrades gracefully like the other source detectors: unparseable files are logged
+// and skipped, listing failures abort detection without an error, and a run that finds nothing
+// returns (nil, nil).
+func Detect(gitter fileLister, cfg Config, sinceRef, untilRef string) (*release.ActionsData, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	if sinceRef == "" {
+		log.Debug("workflow action detection skipped: no since ref to compare against")
+		return nil, nil
+	}
+
+	globs := cfg.Paths
+	if len(globs) == 0 {
+		globs = DefaultPaths()
+	}
+	match := func(p string) bool {
+		for _, g := range globs {
+			if ok, _ := doublestar.Match(g, p); ok {
+				return true
+			}
+		}
+		return false
+	}
+
+	sinceFiles, err := gitter.ListFilesAtRef(sinceRef, match)
+	if err != nil {
+		log.WithFields("error", err, "ref", sinceRef).Warn("workflow action detection: unable to list files at since ref; skipping")
+		return nil, nil
+	}
+	untilFiles, err := gitter.ListFilesAtRef(untilRef, match)
+	if err != nil {
+		log.WithFields("error", err, "ref", untilRef).Warn("workflow action detection: unable to list files at until ref; skipping")
+		return nil, nil
+	}
+
+	return diffUsage(collectUsage(sinceFiles), collectUsage(untilFiles)), nil
+}
+
+// usage is where and at which refs one action is used across a tree.
+type usage struct {
+	refs  map[string][]string // ref -> files using the action at that ref
+	files []string
+}
+
+func (u *usage) sortedRefs() []string {
+	out := make([]string, 0, len(u.refs))
+	for r := range u.refs {
+		out = append(out, r)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func collectUsage(files []git.FileBlob) map[string]*usage {
+	out := make(map[string]*usage)
+	for _, f := range files {
+		refs, err := parseWorkflow(f.Content)
+		if err != nil {
+			log.WithFields("error", err, "file", f.Path).Debug("workflow action detection: unable to parse file; skipping")
+			continue
+		}
+		for _, r := range refs {
+			u, ok := out[r.action]
+			if !ok {
+				u = &usage{refs: make(map[string][]string)}
+				out[r.action] = u
+			}
+			if !slices.Contains(u.refs[r.ref], f.Path) {
+				u.refs[r.ref] = append(u.refs[r.ref], f.Path)
+			}
+			if !slices.Contains(u.files, f.Path) {
+				u.files = append(u.files, f.Path)
+			}
+		}
+	}
+	return out
+}
+
+func diffUsage(since, until map[string]*usage) *release.ActionsData {
+	names := make(map[string]bool)
+	for a := range since {
+		names[a] = true
+	}
+	for a := range until {
+		names[a] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for a := range names {
+		sorted = append(sorted, a)
+	}
+	sort.Strings(sorted)
+
+	data := &release.ActionsData{}
+	for _, action := range sorted {
+		before, after := since[action], until[action]
+		c := release.ActionChange{Action: action, ThirdParty: thirdParty(action)}
+		switch {
+		case before == nil:
+			c.Kind = release.ActionAdded
+			c.To, c.Files = after.sortedRefs(), sortedCopy(after.files)
+		case after == nil:
+			c.Kind = release.ActionRemoved
+			c.From, c.Files = before.sortedRefs(), sortedCopy(before.files)
+		default:
+			c.From, c.To, c.Files = before.sortedRefs(), after.sortedRefs(), sortedCopy(after.files)
+			if slices.Equal(c.From, c.To) {
+				continue
+			}
+			c.Kind = pinningChange(c.From, c.To)
+		}
+		data.Changes = append(data.Changes, c)
+		data.Warnings = append(data.Warnings, newlyUnpinned(action, before, after)...)
+	}
+
+	if len(data.Changes) == 0 {
+		return nil
+	}
+	return data
+}
+
+// pinningChange classifies a change in references by pinning: every reference now a commit SHA
+// where some were not is "pinned", the reverse is "unpinned", and anything else is an update.
+func pinningChange(from, to []string) release.ActionChangeKind {
+	wasPinned, isPinned := allPinned(from), allPinned(to)
+	switch {
+	case !wasPinned && isPinned:
+		return release.ActionPinned
+	case wasPinned && !isPinned:
+		return release.ActionUnpinned
+	}
+	return release.ActionUpdated
+}
+
+func allPinned(refs []string) bool {
+	for _, r := range refs {
+		if !release.IsPinnedActionRef(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// newlyUnpinned warns for each unpinned reference at the until ref when the action was not
+// already used unpinned at the since ref, i.e. the repository just took on a mutable reference.
+// Bumping an already-unpinned action from one tag to another is not new exposure.
+func newlyUnpinned(action string, before, after *usage) []release.ActionWarning {
+	if after == nil {
+		return nil
+	}
+	if before != nil {
+		for r := range before.refs {
+			if !release.IsPinnedActionRef(r) {
+				return nil
+			}
+		}
+	}
+
+	var out []release.ActionWarning
+	for _, r := range after.sortedRefs() {
+		if release.IsPinnedActionRef(r) {
+			continue
+		}
+		files := sortedCopy(after.refs[r])
+		display := action
+		if r != "" {
+			display += "@" + r
+		}
+		out = append(out, release.ActionWarning{
+			Action:  action,
+			Ref:     r,
+			Message: fmt.Sprintf("%s is not pinned to a commit SHA (%s)", display, strings.Join(files, ", ")),
+			Files:   files,
+		})
+	}
+	return out
+}
+
+func sortedCopy(ss []string) []string {
+	out := slices.Clone(ss)
+	sort.Strings(out)
+	return out
+}
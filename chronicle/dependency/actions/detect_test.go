@@ -0,0 +1,137 @@
+package actions
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/chronicle/chronicle/release"
+	"github.com/anchore/chronicle/internal/git"
+)
+
+const (
+	checkoutSHA = "b4ffde65f46336ab88eb53be808477a3936bae11"
+	setupGoSHA  = "0c52d547c9bc32b1aa3301fd7a9cb496313a4491"
+)
+
+// fakeLister serves fixed file sets per ref, filtered through the match predicate like the real
+// tree walk.
+type fakeLister map[string][]git.FileBlob
+
+func (f fakeLister) ListFilesAtRef(ref string, match func(path string) bool) ([]git.FileBlob, error) {
+	var out []git.FileBlob
+	for _, b := range f[ref] {
+		if match(b.Path) {
+			out = append(out, b)
+		}
+	}
+	return out, nil
+}
+
+func workflow(path string, uses ...string) git.FileBlob {
+	content := "jobs:\n  build:\n    steps:\n"
+	for _, u := range uses {
+		content += "      - uses: " + u + "\n"
+	}
+	return git.FileBlob{Path: path, Content: []byte(content)}
+}
+
+func TestDetect(t *testing.T) {
+	cfg := Config{Enabled: true}
+
+	tests := []struct {
+		name   string
+		cfg    Config
+		since  string
+		lister fakeLister
+		want   *release.ActionsData
+	}{
+		{
+			name:  "disabled is a no-op",
+			since: "v1",
+			want:  nil,
+		},
+		{
+			name:  "no since ref skips detection",
+			cfg:   cfg,
+			since: "",
+			want:  nil,
+		},
+		{
+			name:  "pinning, unpinning, updates, additions and removals",
+			cfg:   cfg,
+			since: "v1",
+			lister: fakeLister{
+				"v1": {workflow(".github/workflows/ci.yml",
+					"actions/checkout@v4",
+					"actions/setup-go@"+setupGoSHA,
+					"golangci/golangci-lint-action@v3",
+					"old/action@v1",
+				)},
+				"v2": {workflow(".github/workflows/ci.yml",
+					"actions/checkout@"+checkoutSHA,
+					"actions/setup-go@v5",
+					"golangci/golangci-lint-action@v6",
+					"new/action@main",
+				)},
+			},
+			want: &release.ActionsData{
+				Changes: []release.ActionChange{
+					{Action: "actions/checkout", Kind: release.ActionPinned, From: []string{"v4"}, To: []string{checkoutSHA}, Files: []string{".github/workflows/ci.yml"}},
+					{Action: "actions/setup-go", Kind: release.ActionUnpinned, From: []string{setupGoSHA}, To: []string{"v5"}, Files: []string{".github/workflows/ci.yml"}},
+					{Action: "golangci/golangci-lint-action", Kind: release.ActionUpdated, From: []string{"v3"}, To: []string{"v6"}, Files: []string{".github/workflows/ci.yml"}, ThirdParty: true},
+					{Action: "new/action", Kind: release.ActionAdded, To: []string{"main"}, Files: []string{".github/workflows/ci.yml"}, ThirdParty: true},
+					{Action: "old/action", Kind: release.ActionRemoved, From: []string{"v1"}, Files: []string{".github/workflows/ci.yml"}, ThirdParty: true},
+				},
+				// the lint action was already unpinned, so its tag bump is not new exposure.
+				Warnings: []release.ActionWarning{
+					{Action: "actions/setup-go", Ref: "v5", Message: "actions/setup-go@v5 is not pinned to a commit SHA (.github/workflows/ci.yml)", Files: []string{".github/workflows/ci.yml"}},
+					{Action: "new/action", Ref: "main", Message: "new/action@main is not pinned to a commit SHA (.github/workflows/ci.yml)", Files: []string{".github/workflows/ci.yml"}},
+				},
+			},
+		},
+		{
+			name:  "moving a step between workflows is not a change",
+			cfg:   cfg,
+			since: "v1",
+			lister: fakeLister{
+				"v1": {workflow(".github/workflows/ci.yml", "actions/checkout@v4")},
+				"v2": {workflow(".github/workflows/release.yaml", "actions/checkout@v4")},
+			},
+			want: nil,
+		},
+		{
+			name:  "refs are aggregated across files, and files outside the workflow globs are ignored",
+			cfg:   cfg,
+			since: "v1",
+			lister: fakeLister{
+				"v1": {
+					workflow(".github/workflows/ci.yml", "actions/checkout@v3"),
+					workflow(".github/workflows/release.yml", "actions/checkout@v3"),
+					workflow("docs/example.yml", "some/action@v1"),
+				},
+				"v2": {
+					workflow(".github/workflows/ci.yml", "actions/checkout@v4"),
+					workflow(".github/workflows/release.yml", "actions/checkout@v3"),
+					workflow("docs/example.yml", "some/action@v2"),
+				},
+			},
+			want: &release.ActionsData{
+				Changes: []release.ActionChange{
+					{Action: "actions/checkout", Kind: release.ActionUpdated, From: []string{"v3"}, To: []string{"v3", "v4"}, Files: []string{".github/workflows/ci.yml", ".github/workflows/release.yml"}},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Detect(tt.lister, tt.cfg, tt.since, "v2")
+			require.NoError(t, err)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
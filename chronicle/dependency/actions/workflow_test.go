@@ -0,0 +1,104 @@
+package actions
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWorkflow(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []reference
+		wantErr require.ErrorAssertionFunc
+	}{
+		{
+			name: "steps and reusable workflows",
+			content: `
+name: ci
+on: [push]
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@b4ffde65f46336ab88eb53be808477a3936bae11 # v4.1.1
+      - uses: ./.github/actions/bootstrap
+      - run: make test
+      - uses: github/codeql-action/init@v3
+  release:
+    uses: anchore/workflows/.github/workflows/release.yaml@main
+`,
+			want: []reference{
+				{action: "actions/checkout", ref: "b4ffde65f46336ab88eb53be808477a3936bae11"},
+				{action: "anchore/workflows/.github/workflows/release.yaml", ref: "main"},
+				{action: "github/codeql-action/init", ref: "v3"},
+			},
+		},
+		{
+			name: "composite action",
+			content: `
+name: bootstrap
+runs:
+  using: composite
+  steps:
+    - uses: actions/setup-go@v5
+    - uses: docker://ghcr.io/org/tool:1.2@sha256:0123
+`,
+			want: []reference{
+				{action: "actions/setup-go", ref: "v5"},
+				{action: "docker://ghcr.io/org/tool", ref: "sha256:0123"},
+			},
+		},
+		{
+			name:    "not yaml",
+			content: "jobs: [",
+			wantErr: require.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr == nil {
+				tt.wantErr = require.NoError
+			}
+			got, err := parseWorkflow([]byte(tt.content))
+			tt.wantErr(t, err)
+			if err != nil {
+				return
+			}
+			// jobs are a map, so order is not meaningful.
+			sort.Slice(got, func(i, j int) bool { return got[i].action < got[j].action })
+			if diff := cmp.Diff(tt.want, got, cmp.AllowUnexported(reference{})); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseUses(t *testing.T) {
+	tests := []struct {
+		uses   string
+		want   reference
+		wantOK bool
+	}{
+		{uses: "actions/checkout@v4", want: reference{action: "actions/checkout", ref: "v4"}, wantOK: true},
+		{uses: "owner/repo/path/to/action@abc", want: reference{action: "owner/repo/path/to/action", ref: "abc"}, wantOK: true},
+		{uses: "owner/repo", want: reference{action: "owner/repo"}, wantOK: true},
+		{uses: "docker://alpine:3.20", want: reference{action: "docker://alpine", ref: "3.20"}, wantOK: true},
+		{uses: "docker://localhost:5000/tool", want: reference{action: "docker://localhost:5000/tool"}, wantOK: true},
+		{uses: "./local/action", wantOK: false},
+		{uses: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.uses, func(t *testing.T) {
+			got, ok := parseUses(tt.uses)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package dependency
 import (
 	"sort"
 	"strings"
+	"time"
 )
 
 // PackageVulns associates a package with a set of its vulnerabilities. It backs
@@ -30,12 +31,56 @@ func (d *VulnDelta) HasImpact() bool {
 	return d != nil && (len(d.Remediated) > 0 || len(d.Introduced) > 0)
 }
 
+// VulnerabilityDB identifies the vulnerability database a diff was annotated
+// against. Annotations are only reproducible against the same build, so it is
+// recorded with them.
+type VulnerabilityDB struct {
+	Built         time.Time // when the DB was built; zero when unknown
+	SchemaVersion string    `json:",omitempty"` // the DB schema, e.g. "v6.0.2"; "" when unknown
+}
+
 // annotateConfig controls which vulnerability annotations annotate applies.
 type annotateConfig struct {
 	// MinSeverity is the minimum severity required for a vulnerability to appear
 	// in a change's Vuln delta. "" means no filtering (include all). Valid values
 	// (case-insensitive): negligible, low, medium, high, critical.
 	MinSeverity string
+	// MinEPSS is the minimum EPSS score (0–1) for a vulnerability to appear;
+	// known-exploited vulnerabilities pass regardless, since exploitation is
+	// no longer a prediction. 0 means no filtering.
+	MinEPSS float64
+	// Suppressor, when set, drops the vulnerabilities it suppresses from what
+	// the release still carries (introduced and remaining). Remediated ones are
+	// left alone: the statements describe this release, not the previous one.
+	Suppressor Suppressor
+	// Gate collects the changes introducing a vulnerability it forbids into
+	// the diff's GateViolations, judged before MinSeverity and MinEPSS.
+	Gate VulnGate
+}
+
+// Suppressor reports whether a vulnerability on the package identified by purl
+// is suppressed, typically because a VEX statement declares the product not
+// affected by it. purl may be "" when the scanner had none.
+type Suppressor interface {
+	Suppresses(v Vulnerability, purl string) bool
+}
+
+// vulnFilter is an annotateConfig resolved for filtering. suppressed collects
+// the IDs the suppressor dropped, for the diff's SuppressedCount.
+type vulnFilter struct {
+	minRank    int
+	minEPSS    float64
+	suppressor Suppressor
+	suppressed map[string]struct{}
+}
+
+func (c annotateConfig) filter() vulnFilter {
+	return vulnFilter{
+		minRank:    severityRank(c.MinSeverity),
+		minEPSS:    c.MinEPSS,
+		suppressor: c.Suppressor,
+		suppressed: map[string]struct{}{},
+	}
 }
 
 // annotate returns a copy of d with each change's Vuln delta populated from the
@@ -47,32 +92,44 @@ type annotateConfig struct {
 // (nil). The caller decides when to annotate (ComputeDiff does so only when a
 // ref was actually vuln-matched).
 func annotate(d Diff, cfg annotateConfig) Diff {
-	minRank := severityRank(cfg.MinSeverity)
+	f := cfg.filter()
+	// the gate honors VEX like the listings do, but through its own filter so
+	// vulnerabilities the listings never showed don't count as suppressed.
+	gf := vulnFilter{suppressor: cfg.Suppressor, suppressed: map[string]struct{}{}}
+	var violations []PackageChange
 
 	annotated := make([]PackageChange, len(d.Changes))
 	for i, ch := range d.Changes {
-		key := ch.key()
-
+		// each side is looked up at its own version, in the change's member,
+		// so another member's copy of the package never lends its matches.
 		var sinceVulns, untilVulns []Vulnerability
 		if d.Since.Vulns != nil {
-			sinceVulns = d.Since.Vulns[key]
+			sinceVulns = d.Since.Vulns[ch.fromKey()]
 		}
 		if d.Until.Vulns != nil {
-			untilVulns = d.Until.Vulns[key]
+			untilVulns = d.Until.Vulns[ch.toKey()]
 		}
 
 		var delta VulnDelta
+		var introduced []Vulnerability
 		switch ch.Kind {
 		case Removed:
 			// all of the package's since vulns are remediated; none at until
-			delta.Remediated = filterBySeverity(sinceVulns, minRank)
+			delta.Remediated = f.apply(sinceVulns)
 		case Added:
 			// all of the package's until vulns are introduced; none at since
-			delta.Introduced = filterBySeverity(untilVulns, minRank)
+			introduced = untilVulns
 		default:
 			// Updated / Downgraded: set difference
-			delta.Remediated = filterBySeverity(setDifference(sinceVulns, untilVulns), minRank)
-			delta.Introduced = filterBySeverity(setDifference(untilVulns, sinceVulns), minRank)
+			delta.Remediated = f.apply(setDifference(sinceVulns, untilVulns))
+			introduced = setDifference(untilVulns, sinceVulns)
+		}
+		delta.Introduced = f.suppress(f.apply(introduced), ch.PURL)
+
+		// the gate judges the introduced set before the presentation filters:
+		// a release policy can't be loosened by what the changelog hides.
+		if v, ok := cfg.Gate.offending(ch, gf.suppress(introduced, ch.PURL)); ok {
+			violations = append(violations, v)
 		}
 
 		ch.Vuln = &delta
@@ -87,8 +144,10 @@ func annotate(d Diff, cfg annotateConfig) Diff {
 	out.Until = d.Until
 	// remaining spans the full latest scan (including unchanged packages), so it
 	// is derived from the scans here rather than from the per-change deltas.
-	out.Remaining = remainingVulns(out, minRank)
+	out.Remaining = remainingVulns(out, f)
 	out.RemainingCount = countUniqueIDsIn(out.Remaining)
+	out.SuppressedCount = len(f.suppressed)
+	out.GateViolations = violations
 	return out
 }
 
@@ -97,25 +156,29 @@ func annotate(d Diff, cfg annotateConfig) Diff {
 // package), which are by construction neither remediated (gone at until) nor
 // introduced (absent at since). Unlike the per-change deltas it spans packages
 // whose version never moved, so it is the standing vulnerability burden the
-// release did not clear. Severity-filtered like the per-change deltas, and
-// sorted deterministically for stable output. Empty when until was not matched.
-func remainingVulns(d Diff, minRank int) []PackageVulns {
+// release did not clear. Each member's copy of a package is judged against
+// that member's own since scan: a vulnerability another member still carries
+// was remediated here. Filtered like the per-change deltas, and sorted
+// deterministically for stable output. Empty when until was not matched.
+func remainingVulns(d Diff, f vulnFilter) []PackageVulns {
 	if d.Until.Vulns == nil {
 		return nil
 	}
-	untilPkgs := indexPackages(d.Until.Packages)
+	untilPkgs := indexBy(d.Until.Packages, Package.vulnKey)
+	sinceVersions := indexBy(d.Since.Packages, Package.memberKey)
 
 	out := make([]PackageVulns, 0, len(d.Until.Vulns))
 	for key, untilVulns := range d.Until.Vulns {
 		var sinceVulns []Vulnerability
-		if d.Since.Vulns != nil {
-			sinceVulns = d.Since.Vulns[key]
+		if since, ok := sinceVersions[memberKey{PackageKey: key.PackageKey, Member: key.Member}]; ok && d.Since.Vulns != nil {
+			sinceVulns = d.Since.Vulns[since.vulnKey()]
 		}
-		carried := filterBySeverity(intersectByID(untilVulns, sinceVulns), minRank)
+		pkg := untilPkgs[key]
+		carried := f.suppress(f.apply(intersectByID(untilVulns, sinceVulns)), pkg.PURL)
 		if len(carried) == 0 {
 			continue
 		}
-		out = append(out, PackageVulns{Package: untilPkgs[key], Vulns: carried})
+		out = append(out, PackageVulns{Package: pkg, Vulns: carried})
 	}
 
 	sortPackageVulns(out)
@@ -140,18 +203,22 @@ func intersectByID(a, b []Vulnerability) []Vulnerability {
 	return result
 }
 
-// sortPackageVulns orders the listing deterministically: packages by Type then
-// Name (matching sortChanges), and each package's vulns by ID.
+// sortPackageVulns orders the listing deterministically: packages by Type,
+// Name, then Member (matching sortChanges), and each package's vulns by ID.
 func sortPackageVulns(pvs []PackageVulns) {
 	for i := range pvs {
 		vs := pvs[i].Vulns
 		sort.Slice(vs, func(a, b int) bool { return vs[a].ID < vs[b].ID })
 	}
 	sort.Slice(pvs, func(i, j int) bool {
-		if pvs[i].Package.Type != pvs[j].Package.Type {
-			return pvs[i].Package.Type < pvs[j].Package.Type
+		a, b := pvs[i].Package, pvs[j].Package
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
 		}
-		return pvs[i].Package.Name < pvs[j].Package.Name
+		return a.Member < b.Member
 	})
 }
 
@@ -183,17 +250,40 @@ func setDifference(a, b []Vulnerability) []Vulnerability {
 	return result
 }
 
-// filterBySeverity retains only vulns whose severity is at or above minRank.
-// When minRank is 0 (MinSeverity == ""), all vulns pass through.
-func filterBySeverity(vulns []Vulnerability, minRank int) []Vulnerability {
-	if minRank == 0 {
+// apply retains only vulns whose severity is at or above the minimum rank and
+// whose EPSS score reaches the minimum (or that are known exploited). With
+// neither minimum set (MinSeverity == "", MinEPSS == 0), all vulns pass
+// through.
+func (f vulnFilter) apply(vulns []Vulnerability) []Vulnerability {
+	if f.minRank == 0 && f.minEPSS == 0 {
 		return vulns
 	}
 	var out []Vulnerability
 	for _, v := range vulns {
-		if severityRank(v.Severity) >= minRank {
-			out = append(out, v)
+		if severityRank(v.Severity) < f.minRank {
+			continue
+		}
+		if v.EPSS < f.minEPSS && !v.KnownExploited {
+			continue
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+// suppress drops the vulns the suppressor suppresses on the package purl
+// names, recording their IDs.
+func (f vulnFilter) suppress(vulns []Vulnerability, purl string) []Vulnerability {
+	if f.suppressor == nil {
+		return vulns
+	}
+	var out []Vulnerability
+	for _, v := range vulns {
+		if f.suppressor.Suppresses(v, purl) {
+			f.suppressed[v.ID] = struct{}{}
+			continue
 		}
+		out = append(out, v)
 	}
 	return out
 }
@@ -201,7 +291,7 @@ func filterBySeverity(vulns []Vulnerability, minRank int) []Vulnerability {
 // ValidSeverity reports whether s is a recognized severity name
 // (case-insensitive), or empty (meaning "no filter"). Callers validate a
 // user-supplied MinSeverity with this so a typo fails loudly rather than being
-// silently treated as "no filter" by filterBySeverity.
+// silently treated as "no filter" by vulnFilter.apply.
 func ValidSeverity(s string) bool {
 	return strings.TrimSpace(s) == "" || severityRank(s) > 0
 }
@@ -1,6 +1,7 @@
 package dependency
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -12,17 +13,19 @@ func vuln(id, severity string) Vulnerability {
 }
 
 func vulnMap(entries ...struct {
-	key   PackageKey
+	key   VulnKey
 	vulns []Vulnerability
-}) map[PackageKey][]Vulnerability {
-	m := make(map[PackageKey][]Vulnerability)
+}) map[VulnKey][]Vulnerability {
+	m := make(map[VulnKey][]Vulnerability)
 	for _, e := range entries {
 		m[e.key] = e.vulns
 	}
 	return m
 }
 
-func goKey(name string) PackageKey { return PackageKey{Type: "go-module", Name: name} }
+func goKey(name, version string) VulnKey {
+	return VulnKey{PackageKey: PackageKey{Type: "go-module", Name: name}, Version: version}
+}
 
 func TestAnnotate(t *testing.T) {
 	tests := []struct {
@@ -43,15 +46,15 @@ func TestAnnotate(t *testing.T) {
 			},
 			since: Scan{
 				Vulns: vulnMap(struct {
-					key   PackageKey
+					key   VulnKey
 					vulns []Vulnerability
-				}{goKey("lib"), []Vulnerability{vuln("CVE-001", "high"), vuln("CVE-002", "medium")}}),
+				}{goKey("lib", "1"), []Vulnerability{vuln("CVE-001", "high"), vuln("CVE-002", "medium")}}),
 			},
 			until: Scan{
 				Vulns: vulnMap(struct {
-					key   PackageKey
+					key   VulnKey
 					vulns []Vulnerability
-				}{goKey("lib"), []Vulnerability{vuln("CVE-002", "medium"), vuln("CVE-003", "low")}}),
+				}{goKey("lib", "2"), []Vulnerability{vuln("CVE-002", "medium"), vuln("CVE-003", "low")}}),
 			},
 			cfg: annotateConfig{},
 			wantChanges: []PackageChange{
@@ -74,9 +77,9 @@ func TestAnnotate(t *testing.T) {
 			},
 			since: Scan{
 				Vulns: vulnMap(struct {
-					key   PackageKey
+					key   VulnKey
 					vulns []Vulnerability
-				}{goKey("old"), []Vulnerability{vuln("CVE-A", "critical"), vuln("CVE-B", "low")}}),
+				}{goKey("old", "1"), []Vulnerability{vuln("CVE-A", "critical"), vuln("CVE-B", "low")}}),
 			},
 			until: Scan{Vulns: nil},
 			cfg:   annotateConfig{},
@@ -100,9 +103,9 @@ func TestAnnotate(t *testing.T) {
 			since: Scan{Vulns: nil},
 			until: Scan{
 				Vulns: vulnMap(struct {
-					key   PackageKey
+					key   VulnKey
 					vulns []Vulnerability
-				}{goKey("new"), []Vulnerability{vuln("GHSA-XYZ", "high")}}),
+				}{goKey("new", "3"), []Vulnerability{vuln("GHSA-XYZ", "high")}}),
 			},
 			cfg: annotateConfig{},
 			wantChanges: []PackageChange{
@@ -125,15 +128,15 @@ func TestAnnotate(t *testing.T) {
 			},
 			since: Scan{
 				Vulns: vulnMap(struct {
-					key   PackageKey
+					key   VulnKey
 					vulns []Vulnerability
-				}{goKey("lib"), []Vulnerability{vuln("CVE-OLD", "high")}}),
+				}{goKey("lib", "5"), []Vulnerability{vuln("CVE-OLD", "high")}}),
 			},
 			until: Scan{
 				Vulns: vulnMap(struct {
-					key   PackageKey
+					key   VulnKey
 					vulns []Vulnerability
-				}{goKey("lib"), []Vulnerability{vuln("CVE-OLD", "high"), vuln("CVE-NEW", "critical")}}),
+				}{goKey("lib", "2"), []Vulnerability{vuln("CVE-OLD", "high"), vuln("CVE-NEW", "critical")}}),
 			},
 			cfg: annotateConfig{},
 			wantChanges: []PackageChange{
@@ -155,9 +158,9 @@ func TestAnnotate(t *testing.T) {
 			},
 			since: Scan{
 				Vulns: vulnMap(struct {
-					key   PackageKey
+					key   VulnKey
 					vulns []Vulnerability
-				}{goKey("lib"), []Vulnerability{
+				}{goKey("lib", "1"), []Vulnerability{
 					vuln("CVE-HIGH", "high"),
 					vuln("CVE-LOW", "low"),
 					vuln("CVE-NEG", "negligible"),
@@ -176,6 +179,38 @@ func TestAnnotate(t *testing.T) {
 			wantRemediated: 1,
 			wantIntroduced: 0,
 		},
+		{
+			// MinEPSS filters out unlikely-to-be-exploited vulns, but never a known-exploited one
+			name: "min epss floor keeps known-exploited vulns",
+			changes: []PackageChange{
+				{Name: "lib", Type: "go-module", FromVersion: "1", ToVersion: "2", Kind: Updated},
+			},
+			since: Scan{Vulns: nil},
+			until: Scan{
+				Vulns: vulnMap(struct {
+					key   VulnKey
+					vulns []Vulnerability
+				}{goKey("lib", "2"), []Vulnerability{
+					{ID: "CVE-LIKELY", Severity: "high", EPSS: 0.4},
+					{ID: "CVE-UNLIKELY", Severity: "critical", EPSS: 0.001},
+					{ID: "CVE-KEV", Severity: "medium", KnownExploited: true},
+				}}),
+			},
+			cfg: annotateConfig{MinEPSS: 0.1},
+			wantChanges: []PackageChange{
+				{
+					Name: "lib", Type: "go-module", FromVersion: "1", ToVersion: "2", Kind: Updated,
+					Vuln: &VulnDelta{
+						Introduced: []Vulnerability{
+							{ID: "CVE-LIKELY", Severity: "high", EPSS: 0.4},
+							{ID: "CVE-KEV", Severity: "medium", KnownExploited: true},
+						},
+					},
+				},
+			},
+			wantRemediated: 0,
+			wantIntroduced: 2,
+		},
 		{
 			// unique ID deduplication: same CVE appearing in two packages counts once
 			name: "unique id dedup across packages",
@@ -186,13 +221,13 @@ func TestAnnotate(t *testing.T) {
 			since: Scan{
 				Vulns: vulnMap(
 					struct {
-						key   PackageKey
+						key   VulnKey
 						vulns []Vulnerability
-					}{goKey("pkg-a"), []Vulnerability{vuln("CVE-SHARED", "high"), vuln("CVE-A-ONLY", "medium")}},
+					}{goKey("pkg-a", "1"), []Vulnerability{vuln("CVE-SHARED", "high"), vuln("CVE-A-ONLY", "medium")}},
 					struct {
-						key   PackageKey
+						key   VulnKey
 						vulns []Vulnerability
-					}{goKey("pkg-b"), []Vulnerability{vuln("CVE-SHARED", "high")}},
+					}{goKey("pkg-b", "1"), []Vulnerability{vuln("CVE-SHARED", "high")}},
 				),
 			},
 			until: Scan{Vulns: nil},
@@ -238,9 +273,9 @@ func TestAnnotate(t *testing.T) {
 			},
 			since: Scan{
 				Vulns: vulnMap(struct {
-					key   PackageKey
+					key   VulnKey
 					vulns []Vulnerability
-				}{goKey("lib"), []Vulnerability{
+				}{goKey("lib", "1"), []Vulnerability{
 					vuln("CVE-NEG", "negligible"),
 					vuln("CVE-UNK", ""),
 				}}),
@@ -302,34 +337,34 @@ func TestAnnotate_Remaining(t *testing.T) {
 				Packages: []Package{goPkg("carry", "1"), goPkg("stable", "9"), goPkg("dropped", "1")},
 				Vulns: vulnMap(
 					struct {
-						key   PackageKey
+						key   VulnKey
 						vulns []Vulnerability
-					}{goKey("carry"), []Vulnerability{vuln("CVE-OLD", "high"), vuln("CVE-FIXED", "low")}},
+					}{goKey("carry", "1"), []Vulnerability{vuln("CVE-OLD", "high"), vuln("CVE-FIXED", "low")}},
 					struct {
-						key   PackageKey
+						key   VulnKey
 						vulns []Vulnerability
-					}{goKey("stable"), []Vulnerability{vuln("CVE-STABLE", "medium")}},
+					}{goKey("stable", "9"), []Vulnerability{vuln("CVE-STABLE", "medium")}},
 					struct {
-						key   PackageKey
+						key   VulnKey
 						vulns []Vulnerability
-					}{goKey("dropped"), []Vulnerability{vuln("CVE-GONE", "high")}},
+					}{goKey("dropped", "1"), []Vulnerability{vuln("CVE-GONE", "high")}},
 				),
 			},
 			until: Scan{
 				Packages: []Package{goPkg("carry", "2"), goPkg("stable", "9"), goPkg("fresh", "1")},
 				Vulns: vulnMap(
 					struct {
-						key   PackageKey
+						key   VulnKey
 						vulns []Vulnerability
-					}{goKey("carry"), []Vulnerability{vuln("CVE-OLD", "high"), vuln("CVE-NEW", "critical")}},
+					}{goKey("carry", "2"), []Vulnerability{vuln("CVE-OLD", "high"), vuln("CVE-NEW", "critical")}},
 					struct {
-						key   PackageKey
+						key   VulnKey
 						vulns []Vulnerability
-					}{goKey("stable"), []Vulnerability{vuln("CVE-STABLE", "medium")}},
+					}{goKey("stable", "9"), []Vulnerability{vuln("CVE-STABLE", "medium")}},
 					struct {
-						key   PackageKey
+						key   VulnKey
 						vulns []Vulnerability
-					}{goKey("fresh"), []Vulnerability{vuln("CVE-INTRO", "high")}},
+					}{goKey("fresh", "1"), []Vulnerability{vuln("CVE-INTRO", "high")}},
 				),
 			},
 			wantRemaining: []PackageVulns{
@@ -346,26 +381,26 @@ func TestAnnotate_Remaining(t *testing.T) {
 				Packages: []Package{goPkg("a", "1"), goPkg("b", "1")},
 				Vulns: vulnMap(
 					struct {
-						key   PackageKey
+						key   VulnKey
 						vulns []Vulnerability
-					}{goKey("a"), []Vulnerability{vuln("CVE-SHARED", "high")}},
+					}{goKey("a", "1"), []Vulnerability{vuln("CVE-SHARED", "high")}},
 					struct {
-						key   PackageKey
+						key   VulnKey
 						vulns []Vulnerability
-					}{goKey("b"), []Vulnerability{vuln("CVE-SHARED", "high")}},
+					}{goKey("b", "1"), []Vulnerability{vuln("CVE-SHARED", "high")}},
 				),
 			},
 			until: Scan{
 				Packages: []Package{goPkg("a", "1"), goPkg("b", "1")},
 				Vulns: vulnMap(
 					struct {
-						key   PackageKey
+						key   VulnKey
 						vulns []Vulnerability
-					}{goKey("a"), []Vulnerability{vuln("CVE-SHARED", "high")}},
+					}{goKey("a", "1"), []Vulnerability{vuln("CVE-SHARED", "high")}},
 					struct {
-						key   PackageKey
+						key   VulnKey
 						vulns []Vulnerability
-					}{goKey("b"), []Vulnerability{vuln("CVE-SHARED", "high")}},
+					}{goKey("b", "1"), []Vulnerability{vuln("CVE-SHARED", "high")}},
 				),
 			},
 			wantRemaining: []PackageVulns{
@@ -381,16 +416,16 @@ func TestAnnotate_Remaining(t *testing.T) {
 			since: Scan{
 				Packages: []Package{goPkg("lib", "1")},
 				Vulns: vulnMap(struct {
-					key   PackageKey
+					key   VulnKey
 					vulns []Vulnerability
-				}{goKey("lib"), []Vulnerability{vuln("CVE-HIGH", "high"), vuln("CVE-LOW", "low")}}),
+				}{goKey("lib", "1"), []Vulnerability{vuln("CVE-HIGH", "high"), vuln("CVE-LOW", "low")}}),
 			},
 			until: Scan{
 				Packages: []Package{goPkg("lib", "1")},
 				Vulns: vulnMap(struct {
-					key   PackageKey
+					key   VulnKey
 					vulns []Vulnerability
-				}{goKey("lib"), []Vulnerability{vuln("CVE-HIGH", "high"), vuln("CVE-LOW", "low")}}),
+				}{goKey("lib", "1"), []Vulnerability{vuln("CVE-HIGH", "high"), vuln("CVE-LOW", "low")}}),
 			},
 			cfg: annotateConfig{MinSeverity: "high"},
 			wantRemaining: []PackageVulns{
@@ -424,6 +459,45 @@ func TestAnnotate_Remaining(t *testing.T) {
 	}
 }
 
+func TestAnnotate_WorkspaceMembers(t *testing.T) {
+	// two members depend on lib: api upgrades it past CVE-OLD, web stays on the
+	// vulnerable version. Each member's change and remaining listing must read
+	// only its own matches.
+	lib := func(version, member string) Package {
+		return Package{Name: "lib", Version: version, Type: "go-module", Member: member}
+	}
+	key := func(version, member string) VulnKey {
+		k := goKey("lib", version)
+		k.Member = member
+		return k
+	}
+	old := []Vulnerability{vuln("CVE-OLD", "high")}
+
+	since := Scan{
+		Packages: []Package{lib("1", "api"), lib("1", "web")},
+		Vulns:    map[VulnKey][]Vulnerability{key("1", "api"): old, key("1", "web"): old},
+	}
+	until := Scan{
+		Packages: []Package{lib("2", "api"), lib("1", "web")},
+		Vulns:    map[VulnKey][]Vulnerability{key("2", "api"): nil, key("1", "web"): old},
+	}
+
+	got := annotate(Compare(since, until, intComparer{}), annotateConfig{})
+
+	want := []PackageChange{
+		{
+			Name: "lib", Type: "go-module", FromVersion: "1", ToVersion: "2", Kind: Updated, Member: "api",
+			Vuln: &VulnDelta{Remediated: old},
+		},
+	}
+	if diff := cmp.Diff(want, got.Changes); diff != "" {
+		t.Errorf("annotate() changes mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]PackageVulns{{Package: lib("1", "web"), Vulns: old}}, got.Remaining); diff != "" {
+		t.Errorf("annotate() remaining mismatch (-want +got):\n%s", diff)
+	}
+}
+
 func TestIntersectByID(t *testing.T) {
 	tests := []struct {
 		name string
@@ -550,3 +624,110 @@ func TestSetDifference(t *testing.T) {
 		})
 	}
 }
+
+// idSuppressor suppresses the listed IDs, on the listed purl only when one is
+// given.
+type idSuppressor struct {
+	ids  []string
+	purl string
+}
+
+func (s idSuppressor) Suppresses(v Vulnerability, purl string) bool {
+	if s.purl != "" && s.purl != purl {
+		return false
+	}
+	for _, id := range s.ids {
+		if slices.Contains(v.IDs(), id) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestAnnotate_Suppressed(t *testing.T) {
+	goPkg := func(name, version string) Package {
+		return Package{Name: name, Version: version, Type: "go-module", PURL: "pkg:golang/" + name + "@" + version}
+	}
+	entry := func(name, version string, vulns ...Vulnerability) struct {
+		key   VulnKey
+		vulns []Vulnerability
+	} {
+		return struct {
+			key   VulnKey
+			vulns []Vulnerability
+		}{goKey(name, version), vulns}
+	}
+
+	changes := []PackageChange{
+		{Name: "lib", Type: "go-module", FromVersion: "1", ToVersion: "2", Kind: Updated, PURL: "pkg:golang/lib@2"},
+	}
+	since := Scan{
+		Packages: []Package{goPkg("lib", "1"), goPkg("stable", "1")},
+		Vulns: vulnMap(
+			entry("lib", "1", vuln("CVE-FIXED", "high")),
+			entry("stable", "1", vuln("CVE-CARRIED", "high"), vuln("CVE-KEPT", "low")),
+		),
+	}
+	until := Scan{
+		Packages: []Package{goPkg("lib", "2"), goPkg("stable", "1")},
+		Vulns: vulnMap(
+			entry("lib", "2", vuln("CVE-NEW", "high"), Vulnerability{ID: "GHSA-xxxx", Severity: "medium", Aliases: []string{"CVE-ALIAS"}}),
+			entry("stable", "1", vuln("CVE-CARRIED", "high"), vuln("CVE-KEPT", "low")),
+		),
+	}
+
+	tests := []struct {
+		name           string
+		suppressor     Suppressor
+		wantIntroduced []Vulnerability
+		wantRemaining  []PackageVulns
+		wantSuppressed int
+	}{
+		{
+			name:           "no suppressor",
+			wantIntroduced: []Vulnerability{vuln("CVE-NEW", "high"), {ID: "GHSA-xxxx", Severity: "medium", Aliases: []string{"CVE-ALIAS"}}},
+			wantRemaining: []PackageVulns{
+				{Package: goPkg("stable", "1"), Vulns: []Vulnerability{vuln("CVE-CARRIED", "high"), vuln("CVE-KEPT", "low")}},
+			},
+		},
+		{
+			// introduced and remaining are both suppressed, an alias counts as
+			// the vuln, and a remediated vuln is left alone (it is gone either way)
+			name:           "suppresses introduced and remaining, by id or alias",
+			suppressor:     idSuppressor{ids: []string{"CVE-NEW", "CVE-ALIAS", "CVE-CARRIED", "CVE-FIXED"}},
+			wantIntroduced: nil,
+			wantRemaining: []PackageVulns{
+				{Package: goPkg("stable", "1"), Vulns: []Vulnerability{vuln("CVE-KEPT", "low")}},
+			},
+			wantSuppressed: 3,
+		},
+		{
+			name:           "suppression is scoped to the package purl",
+			suppressor:     idSuppressor{ids: []string{"CVE-NEW", "CVE-CARRIED"}, purl: "pkg:golang/stable@1"},
+			wantIntroduced: []Vulnerability{vuln("CVE-NEW", "high"), {ID: "GHSA-xxxx", Severity: "medium", Aliases: []string{"CVE-ALIAS"}}},
+			wantRemaining: []PackageVulns{
+				{Package: goPkg("stable", "1"), Vulns: []Vulnerability{vuln("CVE-KEPT", "low")}},
+			},
+			wantSuppressed: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewDiff(changes)
+			d.Since = since
+			d.Until = until
+			got := annotate(d, annotateConfig{Suppressor: tt.suppressor})
+
+			require.Len(t, got.Changes, 1)
+			require.Equal(t, []Vulnerability{vuln("CVE-FIXED", "high")}, got.Changes[0].Vuln.Remediated)
+			if diff := cmp.Diff(tt.wantIntroduced, got.Changes[0].Vuln.Introduced); diff != "" {
+				t.Errorf("annotate() introduced mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantRemaining, got.Remaining); diff != "" {
+				t.Errorf("annotate() remaining mismatch (-want +got):\n%s", diff)
+			}
+			require.Equal(t, tt.wantSuppressed, got.SuppressedCount)
+		})
+	}
+}
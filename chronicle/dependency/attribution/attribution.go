@@ -0,0 +1,153 @@
+// Package attribution connects dependency changes to the merged pull requests
+// that made them. The dependency diff only compares the two release endpoints,
+// and the label-driven changelog sections only know about pull requests, so on
+// their own neither can say which PR bumped a package. Attribution walks the
+// release's trunk commits, reads how each PR's merge commit edited the
+// project's manifests and lockfiles, and credits a change to every PR whose
+// edit moved the package to the version it ended up at.
+package attribution
+
+import (
+	"path"
+	"slices"
+
+	"github.com/bmatcuk/doublestar/v4"
+
+	"github.com/anchore/chronicle/chronicle/dependency"
+	"github.com/anchore/chronicle/chronicle/dependency/workspace"
+	"github.com/anchore/chronicle/chronicle/release"
+	"github.com/anchore/chronicle/internal/git"
+	"github.com/anchore/chronicle/internal/log"
+)
+
+// commitDiffer is the slice of git.Interface attribution depends on: the files
+// a single commit changed, read from the object store.
+type commitDiffer interface {
+	FileChangesInCommit(hash string, match func(path string) bool) ([]git.FileChange, error)
+}
+
+// manifestPatterns are the basenames of the manifests and lockfiles package
+// managers record versions in. Only edits to these files count as evidence, so
+// a README or changelog that mentions a version can't claim the change.
+var manifestPatterns = []string{
+	// go
+	"go.mod", "go.sum", "go.work",
+	// javascript
+	"package.json", "package-lock.json", "npm-shrinkwrap.json", "yarn.lock", "pnpm-lock.yaml", "bun.lock",
+	// python
+	"requirements*.txt", "requirements*.in", "constraints*.txt", "Pipfile", "Pipfile.lock", "pyproject.toml", "poetry.lock", "uv.lock", "pdm.lock", "setup.py", "setup.cfg",
+	// ruby
+	"Gemfile", "Gemfile.lock", "gems.rb", "gems.locked", "*.gemspec",
+	// rust
+	"Cargo.toml", "Cargo.lock",
+	// php
+	"composer.json", "composer.lock",
+	// java
+	"pom.xml", "build.gradle", "build.gradle.kts", "gradle.lockfile", "libs.versions.toml",
+	// .net
+	"*.csproj", "*.fsproj", "*.vbproj", "packages.config", "packages.lock.json", "Directory.Packages.props",
+	// swift, cocoapods
+	"Package.swift", "Package.resolved", "Podfile", "Podfile.lock",
+	// dart
+	"pubspec.yaml", "pubspec.lock",
+	// elixir, erlang
+	"mix.exs", "mix.lock", "rebar.config", "rebar.lock",
+	// c/c++
+	"conanfile.txt", "conanfile.py", "conan.lock", "vcpkg.json",
+	// haskell
+	"*.cabal", "cabal.project.freeze", "stack.yaml", "stack.yaml.lock",
+}
+
+// IsManifest reports whether p names a manifest or lockfile attribution reads.
+func IsManifest(p string) bool {
+	base := path.Base(p)
+	for _, pattern := range manifestPatterns {
+		if ok, _ := doublestar.Match(pattern, base); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Attribute credits each change in diff to the merged pull requests in trunk
+// whose merge commit edited a manifest or lockfile to put the package at the
+// version the change ended at (for a removal, to take it out), and returns how
+// many changes were credited. Commits without a pull request are skipped: a
+// merged branch's own commits are covered by its merge commit. Attribution is
+// best-effort, so a commit that can't be read is logged and skipped.
+func Attribute(gitter commitDiffer, trunk *release.TrunkData, diff *dependency.Diff) int {
+	if trunk == nil || diff == nil || len(diff.Changes) == 0 {
+		return 0
+	}
+
+	// trunk commits are newest-first; walk them oldest-first so each change
+	// lists its pull requests in the order they merged. Only each commit's
+	// Hash and PR are read, never the whole TrunkCommit: the dependency
+	// timeline fills in Dependencies on the same commits.
+	for i := len(trunk.Commits) - 1; i >= 0; i-- {
+		hash, pr := trunk.Commits[i].Hash, trunk.Commits[i].PR
+		if pr == nil {
+			continue
+		}
+		files, err := gitter.FileChangesInCommit(hash, IsManifest)
+		if err != nil {
+			log.WithFields("error", err, "commit", hash).Debug("unable to read manifest changes for dependency attribution")
+			continue
+		}
+		if len(files) == 0 {
+			continue
+		}
+		edits := make([]edit, 0, len(files))
+		for _, f := range files {
+			edits = append(edits, newEdit(f))
+		}
+		for i := range diff.Changes {
+			ch := &diff.Changes[i]
+			if hasPR(ch.PullRequests, pr.Number) || !madeBy(*ch, edits) {
+				continue
+			}
+			ch.PullRequests = append(ch.PullRequests, dependency.PullRequest{
+				Number: pr.Number,
+				Title:  pr.Title,
+				URL:    pr.URL,
+				Author: pr.Author,
+			})
+		}
+	}
+
+	var credited int
+	for _, ch := range diff.Changes {
+		if len(ch.PullRequests) > 0 {
+			credited++
+		}
+	}
+	return credited
+}
+
+func hasPR(prs []dependency.PullRequest, number int) bool {
+	return slices.ContainsFunc(prs, func(pr dependency.PullRequest) bool { return pr.Number == number })
+}
+
+// madeBy reports whether any of a commit's manifest edits made the change: it
+// added a line putting the package at its new version, or, for a removal,
+// deleted the line that held it at its old one. In a workspace only the
+// manifests of the change's member count, along with the root's, which hold
+// the lockfile the members share: bumping a package in one member doesn't
+// make the same bump in another.
+func madeBy(ch dependency.PackageChange, edits []edit) bool {
+	for _, e := range edits {
+		if ch.Member != "" && !workspace.Contains([]string{ch.Member}, e.path) {
+			continue
+		}
+		if ch.Kind == dependency.Removed {
+			if evidence(ch.Name, ch.FromVersion, e.before, e.removed) {
+				return true
+			}
+			continue
+		}
+		if evidence(ch.Name, ch.ToVersion, e.after, e.added) {
+			return true
+		}
+	}
+	return false
+}
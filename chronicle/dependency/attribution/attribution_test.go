@@ -0,0 +1,244 @@
+package attribution
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/chronicle/chronicle/dependency"
+	"github.com/anchore/chronicle/chronicle/dependency/timeline"
+	"github.com/anchore/chronicle/chronicle/release"
+	"github.com/anchore/chronicle/internal/git"
+)
+
+func TestIsManifest(t *testing.T) {
+	for _, p := range []string{"go.mod", "sub/go.sum", "web/package-lock.json", "requirements-dev.txt", "src/App/App.csproj", "Cargo.lock"} {
+		assert.True(t, IsManifest(p), p)
+	}
+	for _, p := range []string{"README.md", "CHANGELOG.md", "go.mod.bak", "docs/package.md"} {
+		assert.False(t, IsManifest(p), p)
+	}
+}
+
+func TestEvidence(t *testing.T) {
+	tests := []struct {
+		name    string
+		pkg     string
+		version string
+		before  string
+		after   string
+		want    bool
+	}{
+		{
+			name:    "go.mod bump",
+			pkg:     "golang.org/x/net",
+			version: "v0.2.0",
+			before:  "require (\n\tgolang.org/x/net v0.1.0\n\tgolang.org/x/sys v0.1.0\n)\n",
+			after:   "require (\n\tgolang.org/x/net v0.2.0\n\tgolang.org/x/sys v0.1.0\n)\n",
+			want:    true,
+		},
+		{
+			// the neighbouring module moved to the same version; that is not
+			// evidence for this one
+			name:    "same version on another package's line",
+			pkg:     "golang.org/x/net",
+			version: "v0.2.0",
+			before:  "require (\n\tgolang.org/x/net v0.2.0\n\tgolang.org/x/sys v0.1.0\n)\n",
+			after:   "require (\n\tgolang.org/x/net v0.2.0\n\tgolang.org/x/sys v0.2.0\n)\n",
+			want:    false,
+		},
+		{
+			name:    "name is matched as a whole token",
+			pkg:     "golang.org/x/net",
+			version: "v0.2.0",
+			before:  "require golang.org/x/network v0.1.0\n",
+			after:   "require golang.org/x/network v0.2.0\n",
+			want:    false,
+		},
+		{
+			name:    "version is matched as a whole token",
+			pkg:     "requests",
+			version: "2.31",
+			before:  "requests==2.30.0\n",
+			after:   "requests==2.31.0\n",
+			want:    false,
+		},
+		{
+			name:    "package-lock entry only changes the version line",
+			pkg:     "lodash",
+			version: "4.17.21",
+			before:  "    \"node_modules/lodash\": {\n      \"version\": \"4.17.20\",\n      \"resolved\": \"https://registry.npmjs.org/lodash/-/lodash-4.17.20.tgz\"\n    },\n",
+			after:   "    \"node_modules/lodash\": {\n      \"version\": \"4.17.21\",\n      \"resolved\": \"https://registry.npmjs.org/lodash/-/lodash-4.17.21.tgz\"\n    },\n",
+			want:    true,
+		},
+		{
+			name:    "cargo lock entry",
+			pkg:     "serde",
+			version: "1.0.200",
+			before:  "[[package]]\nname = \"serde\"\nversion = \"1.0.199\"\n",
+			after:   "[[package]]\nname = \"serde\"\nversion = \"1.0.200\"\n",
+			want:    true,
+		},
+		{
+			name:    "version line belongs to a different entry",
+			pkg:     "serde",
+			version: "1.0.200",
+			before:  "[[package]]\nname = \"serde\"\nversion = \"1.0.200\"\n\n[[package]]\nname = \"serde_json\"\nversion = \"1.0.100\"\n",
+			after:   "[[package]]\nname = \"serde\"\nversion = \"1.0.200\"\n\n[[package]]\nname = \"serde_json\"\nversion = \"1.0.200\"\n",
+			want:    false,
+		},
+		{
+			name:    "python names normalize",
+			pkg:     "typing_extensions",
+			version: "4.12.0",
+			before:  "Typing-Extensions==4.11.0\n",
+			after:   "Typing-Extensions==4.12.0\n",
+			want:    true,
+		},
+		{
+			name:    "added file",
+			pkg:     "left-pad",
+			version: "1.3.0",
+			after:   "left-pad@^1.3.0:\n  version \"1.3.0\"\n",
+			want:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEdit(git.FileChange{Path: "f", Before: []byte(tt.before), After: []byte(tt.after)})
+			assert.Equal(t, tt.want, evidence(tt.pkg, tt.version, e.after, e.added))
+		})
+	}
+}
+
+func TestAttribute(t *testing.T) {
+	pr := func(n int) *release.TrunkPR {
+		return &release.TrunkPR{Number: n, Title: "pr", URL: fmt.Sprintf("https://example.com/pull/%d", n), Author: "dependabot"}
+	}
+	dpr := func(n int) dependency.PullRequest {
+		p := pr(n)
+		return dependency.PullRequest{Number: p.Number, Title: p.Title, URL: p.URL, Author: p.Author}
+	}
+	goMod := func(lines ...string) []byte {
+		s := "module example.com/app\n\nrequire (\n"
+		for _, l := range lines {
+			s += "\t" + l + "\n"
+		}
+		return []byte(s + ")\n")
+	}
+
+	gitter := git.MockInterface{MockFileChanges: map[string][]git.FileChange{
+		// #1 bumps x/net to an intermediate version, #3 to the released one
+		"c1": {{Path: "go.mod", Before: goMod("golang.org/x/net v0.1.0", "example.com/old v1.0.0"), After: goMod("golang.org/x/net v0.1.5", "example.com/old v1.0.0")}},
+		"c2": {{Path: "README.md", Before: []byte("x"), After: []byte("golang.org/x/net v0.2.0\n")}},
+		"c3": {
+			{Path: "go.mod", Before: goMod("golang.org/x/net v0.1.5", "example.com/old v1.0.0"), After: goMod("golang.org/x/net v0.2.0")},
+			{Path: "go.sum", Before: nil, After: []byte("golang.org/x/net v0.2.0 h1:abc\n")},
+		},
+		// a direct push with no pull request is never credited
+		"c4": {{Path: "go.mod", Before: goMod("golang.org/x/net v0.2.0"), After: goMod("golang.org/x/net v0.2.0", "example.com/new v1.0.0")}},
+		// #5 adds the package the direct push already added
+		"c5": {{Path: "tools/go.mod", Before: nil, After: goMod("example.com/new v1.0.0")}},
+	}}
+	trunk := &release.TrunkData{Commits: []release.TrunkCommit{
+		// newest first
+		{Hash: "c5", PR: pr(5)},
+		{Hash: "c4"},
+		{Hash: "c3", PR: pr(3)},
+		{Hash: "c2", PR: pr(2)},
+		{Hash: "c1", PR: pr(1)},
+	}}
+	diff := dependency.NewDiff([]dependency.PackageChange{
+		{Name: "golang.org/x/net", Type: "go-module", FromVersion: "v0.1.0", ToVersion: "v0.2.0", Kind: dependency.Updated},
+		{Name: "example.com/old", Type: "go-module", FromVersion: "v1.0.0", Kind: dependency.Removed},
+		{Name: "example.com/new", Type: "go-module", ToVersion: "v1.0.0", Kind: dependency.Added},
+		{Name: "example.com/untouched", Type: "go-module", FromVersion: "v1", ToVersion: "v2", Kind: dependency.Updated},
+	})
+
+	got := Attribute(gitter, trunk, &diff)
+	assert.Equal(t, 3, got)
+
+	want := map[string][]dependency.PullRequest{
+		"golang.org/x/net":      {dpr(3)},
+		"example.com/old":       {dpr(3)},
+		"example.com/new":       {dpr(5)},
+		"example.com/untouched": nil,
+	}
+	for _, c := range diff.Changes {
+		if d := cmp.Diff(want[c.Name], c.PullRequests); d != "" {
+			t.Errorf("%s pull requests mismatch (-want +got):\n%s", c.Name, d)
+		}
+	}
+}
+
+func TestAttribute_WorkspaceMembers(t *testing.T) {
+	pkgJSON := func(version string) []byte {
+		return []byte(`{"dependencies": {"lodash": "` + version + `"}}` + "\n")
+	}
+	gitter := git.MockInterface{MockFileChanges: map[string][]git.FileChange{
+		// #1 bumps lodash in web only, #2 in api only
+		"c1": {{Path: "web/package.json", Before: pkgJSON("4.17.20"), After: pkgJSON("4.17.21")}},
+		"c2": {{Path: "api/package.json", Before: pkgJSON("4.17.20"), After: pkgJSON("4.17.21")}},
+		// #3 edits a nested fixture below api, which is not api's own manifest
+		"c3": {{Path: "api/testdata/package.json", Before: pkgJSON("4.17.20"), After: pkgJSON("4.17.21")}},
+		// #4 moves the root lockfile the members share
+		"c4": {{Path: "package-lock.json", Before: []byte("\"node_modules/lodash\": {\n  \"version\": \"4.17.20\"\n"), After: []byte("\"node_modules/lodash\": {\n  \"version\": \"4.17.21\"\n")}},
+	}}
+	trunk := &release.TrunkData{Commits: []release.TrunkCommit{
+		{Hash: "c4", PR: &release.TrunkPR{Number: 4}},
+		{Hash: "c3", PR: &release.TrunkPR{Number: 3}},
+		{Hash: "c2", PR: &release.TrunkPR{Number: 2}},
+		{Hash: "c1", PR: &release.TrunkPR{Number: 1}},
+	}}
+	diff := dependency.NewDiff([]dependency.PackageChange{
+		{Name: "lodash", Type: "npm", FromVersion: "4.17.20", ToVersion: "4.17.21", Kind: dependency.Updated, Member: "web"},
+		{Name: "lodash", Type: "npm", FromVersion: "4.17.20", ToVersion: "4.17.21", Kind: dependency.Updated, Member: "api"},
+	})
+
+	Attribute(gitter, trunk, &diff)
+
+	got := map[string][]int{}
+	for _, c := range diff.Changes {
+		for _, p := range c.PullRequests {
+			got[c.Member] = append(got[c.Member], p.Number)
+		}
+	}
+	assert.Equal(t, map[string][]int{"web": {1, 4}, "api": {2, 4}}, got)
+}
+
+// the worker runs attribution and the dependency timeline on the same trunk;
+// run under -race, this catches either reading what the other writes.
+func TestAttribute_ConcurrentTimelineAttach(t *testing.T) {
+	gitter := git.MockInterface{MockFileChanges: map[string][]git.FileChange{
+		"c1": {{Path: "go.mod", Before: []byte("require golang.org/x/net v0.1.0\n"), After: []byte("require golang.org/x/net v0.2.0\n")}},
+	}}
+	var commits []release.TrunkCommit
+	tl := timeline.Timeline{}
+	for i := range 50 {
+		hash := fmt.Sprintf("c%d", i)
+		commits = append(commits, release.TrunkCommit{Hash: hash, PR: &release.TrunkPR{Number: i}})
+		tl[hash] = []dependency.PackageChange{{Name: "golang.org/x/net", Type: "go-module", Kind: dependency.Updated}}
+	}
+	trunk := &release.TrunkData{Commits: commits}
+	diff := dependency.NewDiff([]dependency.PackageChange{
+		{Name: "golang.org/x/net", Type: "go-module", FromVersion: "v0.1.0", ToVersion: "v0.2.0", Kind: dependency.Updated},
+	})
+
+	var wg sync.WaitGroup
+	wg.Go(func() { tl.Attach(trunk) })
+	wg.Go(func() { Attribute(gitter, trunk, &diff) })
+	wg.Wait()
+
+	assert.Equal(t, []dependency.PullRequest{{Number: 1}}, diff.Changes[0].PullRequests)
+	assert.Len(t, trunk.Commits[0].Dependencies, 1)
+}
+
+func TestAttribute_NoTrunk(t *testing.T) {
+	diff := dependency.NewDiff([]dependency.PackageChange{{Name: "a", Type: "npm", ToVersion: "1", Kind: dependency.Added}})
+	assert.Equal(t, 0, Attribute(git.MockInterface{}, nil, &diff))
+	assert.Nil(t, diff.Changes[0].PullRequests)
+}
@@ -0,0 +1,154 @@
+package attribution
+
+import (
+	"strings"
+
+	"github.com/go-git/go-git/v5/utils/diff"
+	"github.com/sergi/go-diff/diffmatchpatch"
+
+	"github.com/anchore/chronicle/internal/git"
+)
+
+// blockReach is how many lines above a version-only line ("version": "1.2.3")
+// the package's name may sit. Lockfiles that spread an entry over several lines
+// put the name at most a couple of lines above the version.
+const blockReach = 3
+
+// edit is one manifest's change in a commit, as the lines on each side and
+// which of them the commit removed or added.
+type edit struct {
+	path           string // the manifest, relative to the repository root
+	before, after  []string
+	removed, added []int // line indexes into before and after
+}
+
+func newEdit(f git.FileChange) edit {
+	e := edit{path: f.Path, before: splitLines(f.Before), after: splitLines(f.After)}
+	var b, a int
+	for _, d := range diff.Do(string(f.Before), string(f.After)) {
+		n := len(splitLines([]byte(d.Text)))
+		switch d.Type {
+		case diffmatchpatch.DiffEqual:
+			b += n
+			a += n
+		case diffmatchpatch.DiffDelete:
+			for i := range n {
+				e.removed = append(e.removed, b+i)
+			}
+			b += n
+		case diffmatchpatch.DiffInsert:
+			for i := range n {
+				e.added = append(e.added, a+i)
+			}
+			a += n
+		}
+	}
+	return e
+}
+
+func splitLines(content []byte) []string {
+	if len(content) == 0 {
+		return nil
+	}
+	return strings.SplitAfter(strings.TrimSuffix(string(content), "\n"), "\n")
+}
+
+// evidence reports whether one of the changed lines records the package at
+// version. Most manifests put the name and version on one line (go.mod,
+// requirements.txt, Gemfile.lock); lockfiles that spread an entry over several
+// (package-lock.json, Cargo.lock, pom.xml) change only the version line, so a
+// changed line holding nothing but the version is matched to a name in the
+// few lines above it.
+func evidence(name, version string, lines []string, changed []int) bool {
+	if name == "" || version == "" {
+		return false
+	}
+	for _, i := range changed {
+		if i >= len(lines) || !containsVersion(lines[i], version) {
+			continue
+		}
+		if containsName(lines[i], name) {
+			return true
+		}
+		if !versionOnly(lines[i], version) {
+			continue
+		}
+		for j := i - 1; j >= 0 && j >= i-blockReach; j-- {
+			if containsName(lines[j], name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// containsName reports whether line mentions the package name as a whole
+// token, case-insensitively and treating "-", "_" and "." alike (as Python's
+// name normalization does), so "x/net" doesn't match "x/network".
+func containsName(line, name string) bool {
+	return containsToken(normalizeName(line), normalizeName(name), isNameByte, isNameByte)
+}
+
+// containsVersion reports whether line mentions version as a whole token, so
+// "1.2" doesn't match "1.2.3" or "1.2-rc1". A range operator in front of it
+// ("^1.2.3", "==1.2.3") is fine.
+func containsVersion(line, version string) bool {
+	return containsToken(line, version, isVersionLead, isVersionByte)
+}
+
+// versionOnly reports whether line is just a version field: what is left once
+// the version and the word "version" are taken out is punctuation.
+func versionOnly(line, version string) bool {
+	rest := strings.ToLower(strings.Replace(line, version, "", 1))
+	rest = strings.ReplaceAll(rest, "version", "")
+	for i := 0; i < len(rest); i++ {
+		if isAlnum(rest[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func normalizeName(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '_', '.':
+			return '-'
+		}
+		return r
+	}, strings.ToLower(s))
+}
+
+// containsToken reports whether tok occurs in s with neither neighbor
+// continuing it: the byte before must fail before, the byte after must fail
+// after.
+func containsToken(s, tok string, before, after func(byte) bool) bool {
+	for from := 0; ; {
+		i := strings.Index(s[from:], tok)
+		if i < 0 {
+			return false
+		}
+		i += from
+		end := i + len(tok)
+		if (i == 0 || !before(s[i-1])) && (end == len(s) || !after(s[end])) {
+			return true
+		}
+		from = i + 1
+	}
+}
+
+func isAlnum(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+func isNameByte(c byte) bool {
+	return isAlnum(c) || c == '-' || c == '_' || c == '.'
+}
+
+func isVersionLead(c byte) bool {
+	return isAlnum(c) || c == '.'
+}
+
+func isVersionByte(c byte) bool {
+	return isAlnum(c) || c == '.' || c == '-' || c == '+'
+}
@@ -0,0 +1,150 @@
+package baseimage
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+
+	"github.com/anchore/chronicle/chronicle/release"
+	"github.com/anchore/chronicle/internal/git"
+	"github.com/anchore/chronicle/internal/log"
+)
+
+// Config controls base-image detection.
+type Config struct {
+	// Enabled is the master opt-in switch. When false, Detect is a no-op.
+	Enabled bool
+	// Paths are the Dockerfile discovery globs. Empty means DefaultPaths().
+	Paths []string
+	// Ignore holds path globs excluded from discovery (e.g. "**/testdata/**").
+	Ignore []string
+	// Recursive controls discovery depth. When false, only root-level Dockerfiles are considered.
+	Recursive bool
+}
+
+// DefaultPaths returns the globs that find Dockerfiles and Containerfiles under their usual names,
+// including per-target variants such as "Dockerfile.debug" and "release.Dockerfile".
+func DefaultPaths() []string {
+	return []string{
+		"**/Dockerfile",
+		"**/Dockerfile.*",
+		"**/*.Dockerfile",
+		"**/*.dockerfile",
+		"**/Containerfile",
+		"**/Containerfile.*",
+	}
+}
+
+// fileLister is the slice of git.Interface that detection depends on: reading file content at a
+// ref without a working-tree checkout.
+type fileLister interface {
+	ListFilesAtRef(ref string, match func(path string) bool) ([]git.FileBlob, error)
+}
+
+// Detect reads the Dockerfiles at sinceRef and untilRef and reports every stage whose base image
+// reference (name, tag, or digest) changed. Stages are paired by file and stage name (or position,
+// for unnamed stages); a stage or file that exists at only one ref is not a change. Like toolchain
+// detection it degrades gracefully: unparseable files are logged and skipped, listing failures
+// abort detection without an error, and a run that finds nothing returns (nil, nil).
+func Detect(gitter fileLister, cfg Config, sinceRef, untilRef string) (*release.BaseImageData, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	if sinceRef == "" {
+		log.Debug("base image detection skipped: no since ref to compare against")
+		return nil, nil
+	}
+
+	match := matcher(cfg)
+
+	sinceFiles, err := gitter.ListFilesAtRef(sinceRef, match)
+	if err != nil {
+		log.WithFields("error", err, "ref", sinceRef).Warn("base image detection: unable to list files at since ref; skipping")
+		return nil, nil
+	}
+	untilFiles, err := gitter.ListFilesAtRef(untilRef, match)
+	if err != nil {
+		log.WithFields("error", err, "ref", untilRef).Warn("base image detection: unable to list files at until ref; skipping")
+		return nil, nil
+	}
+
+	return diffStages(stagesByFile(sinceFiles), stagesByFile(untilFiles)), nil
+}
+
+// matcher returns the path predicate handed to the git tree walk.
+func matcher(cfg Config) func(string) bool {
+	globs := cfg.Paths
+	if len(globs) == 0 {
+		globs = DefaultPaths()
+	}
+	return func(p string) bool {
+		p = strings.TrimPrefix(p, "./")
+		if !cfg.Recursive && strings.Contains(p, "/") {
+			return false
+		}
+		if anyMatch(cfg.Ignore, p) {
+			return false
+		}
+		return anyMatch(globs, p)
+	}
+}
+
+func anyMatch(globs []string, p string) bool {
+	for _, g := range globs {
+		if ok, _ := doublestar.Match(strings.TrimPrefix(g, "./"), p); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func stagesByFile(files []git.FileBlob) map[string][]stage {
+	out := make(map[string][]stage)
+	for _, f := range files {
+		stages, err := parseDockerfile(f.Content)
+		if err != nil {
+			// graceful degradation: a single unparseable file must not break detection.
+			log.WithFields("error", err, "file", f.Path).Debug("base image detection: unable to parse file; skipping")
+			continue
+		}
+		out[f.Path] = stages
+	}
+	return out
+}
+
+// diffStages emits an update for every stage present at both refs whose image changed, ordered by
+// file and then by the stage's position at the until ref.
+func diffStages(since, until map[string][]stage) *release.BaseImageData {
+	files := make([]string, 0, len(until))
+	for f := range until {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+
+	var updates []release.BaseImageUpdate
+	for _, file := range files {
+		before := make(map[string]release.ImageReference)
+		for _, s := range since[file] {
+			before[s.key] = s.image
+		}
+		for _, s := range until[file] {
+			from, ok := before[s.key]
+			if !ok || from == s.image {
+				continue
+			}
+			updates = append(updates, release.BaseImageUpdate{
+				File:      file,
+				Stage:     s.key,
+				From:      from,
+				To:        s.image,
+				Direction: direction(from, s.image),
+			})
+		}
+	}
+
+	if len(updates) == 0 {
+		return nil
+	}
+	return &release.BaseImageData{Updates: updates}
+}
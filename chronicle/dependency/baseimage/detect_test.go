@@ -0,0 +1,153 @@
+package baseimage
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/chronicle/chronicle/release"
+	"github.com/anchore/chronicle/internal/git"
+)
+
+// fakeLister serves fixed file sets per ref, filtered through the match predicate like the real
+// tree walk.
+type fakeLister struct {
+	files map[string][]git.FileBlob
+	err   error
+}
+
+func (f fakeLister) ListFilesAtRef(ref string, match func(path string) bool) ([]git.FileBlob, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	var out []git.FileBlob
+	for _, b := range f.files[ref] {
+		if match(b.Path) {
+			out = append(out, b)
+		}
+	}
+	return out, nil
+}
+
+func dockerfile(path, content string) git.FileBlob {
+	return git.FileBlob{Path: path, Content: []byte(content)}
+}
+
+func TestDetect(t *testing.T) {
+	baseCfg := Config{Enabled: true, Recursive: true, Ignore: []string{"**/testdata/**"}}
+
+	tests := []struct {
+		name   string
+		cfg    Config
+		since  string
+		lister fakeLister
+		want   *release.BaseImageData
+	}{
+		{
+			name:  "disabled is a no-op",
+			cfg:   Config{},
+			since: "v1",
+			want:  nil,
+		},
+		{
+			name:  "no since ref skips detection",
+			cfg:   baseCfg,
+			since: "",
+			want:  nil,
+		},
+		{
+			name:   "listing failure degrades to nothing",
+			cfg:    baseCfg,
+			since:  "v1",
+			lister: fakeLister{err: errors.New("boom")},
+			want:   nil,
+		},
+		{
+			name:  "tag bump and digest refresh across stages",
+			cfg:   baseCfg,
+			since: "v1",
+			lister: fakeLister{files: map[string][]git.FileBlob{
+				"v1": {dockerfile("Dockerfile", "ARG GO=1.22\nFROM golang:${GO}-alpine AS build\nFROM alpine:3.20@sha256:aaa\n")},
+				"v2": {dockerfile("Dockerfile", "ARG GO=1.23\nFROM golang:${GO}-alpine AS build\nFROM alpine:3.20@sha256:bbb\n")},
+			}},
+			want: &release.BaseImageData{Updates: []release.BaseImageUpdate{
+				{
+					File: "Dockerfile", Stage: "build",
+					From:      release.ImageReference{Name: "golang", Tag: "1.22-alpine"},
+					To:        release.ImageReference{Name: "golang", Tag: "1.23-alpine"},
+					Direction: release.BaseImageUpgrade,
+				},
+				{
+					File: "Dockerfile", Stage: "#1",
+					From: release.ImageReference{Name: "alpine", Tag: "3.20", Digest: "sha256:aaa"},
+					To:   release.ImageReference{Name: "alpine", Tag: "3.20", Digest: "sha256:bbb"},
+				},
+			}},
+		},
+		{
+			name:  "unchanged images, new files and new stages are not changes",
+			cfg:   baseCfg,
+			since: "v1",
+			lister: fakeLister{files: map[string][]git.FileBlob{
+				"v1": {dockerfile("Dockerfile", "FROM alpine:3.20\n")},
+				"v2": {
+					dockerfile("Dockerfile", "FROM alpine:3.20\nFROM debian:12 AS extra\n"),
+					dockerfile("Containerfile", "FROM fedora:40\n"),
+				},
+			}},
+			want: nil,
+		},
+		{
+			name:  "non-recursive discovery and ignore globs",
+			cfg:   Config{Enabled: true, Ignore: []string{"**/testdata/**"}},
+			since: "v1",
+			lister: fakeLister{files: map[string][]git.FileBlob{
+				"v1": {
+					dockerfile("build/Dockerfile", "FROM alpine:3.19\n"),
+					dockerfile("release.Dockerfile", "FROM node:20\n"),
+				},
+				"v2": {
+					dockerfile("build/Dockerfile", "FROM alpine:3.20\n"),
+					dockerfile("release.Dockerfile", "FROM node:18\n"),
+				},
+			}},
+			want: &release.BaseImageData{Updates: []release.BaseImageUpdate{
+				{
+					File: "release.Dockerfile", Stage: "#0",
+					From:      release.ImageReference{Name: "node", Tag: "20"},
+					To:        release.ImageReference{Name: "node", Tag: "18"},
+					Direction: release.BaseImageDowngrade,
+				},
+			}},
+		},
+		{
+			name:  "path override",
+			cfg:   Config{Enabled: true, Paths: []string{"docker/*.df"}, Recursive: true},
+			since: "v1",
+			lister: fakeLister{files: map[string][]git.FileBlob{
+				"v1": {dockerfile("docker/app.df", "FROM alpine:3.19\n"), dockerfile("Dockerfile", "FROM alpine:3.19\n")},
+				"v2": {dockerfile("docker/app.df", "FROM alpine:3.20\n"), dockerfile("Dockerfile", "FROM alpine:3.20\n")},
+			}},
+			want: &release.BaseImageData{Updates: []release.BaseImageUpdate{
+				{
+					File: "docker/app.df", Stage: "#0",
+					From:      release.ImageReference{Name: "alpine", Tag: "3.19"},
+					To:        release.ImageReference{Name: "alpine", Tag: "3.20"},
+					Direction: release.BaseImageUpgrade,
+				},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Detect(tt.lister, tt.cfg, tt.since, "v2")
+			require.NoError(t, err)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package baseimage
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/anchore/chronicle/chronicle/release"
+	"github.com/anchore/chronicle/internal/log"
+)
+
+// stage is one FROM line whose base image could be resolved from source alone.
+type stage struct {
+	key   string // the stage name, or "#N" (docker's 0-based stage index) when unnamed
+	image release.ImageReference
+}
+
+var escapeDirectivePattern = regexp.MustCompile(`(?i)^#\s*escape\s*=\s*(\S)\s*$`)
+
+// parseDockerfile returns the base image of every stage in a Dockerfile. Global ARGs (those
+// declared before the first FROM) are substituted into the image the way the builder would with
+// no --build-arg overrides. Stages built FROM an earlier stage or FROM scratch have no base image
+// and are left out, as is any FROM whose image still references an ARG with no default.
+func parseDockerfile(content []byte) ([]stage, error) {
+	args := map[string]string{}
+	stageNames := map[string]bool{}
+	var out []stage
+	index := 0
+
+	for _, line := range instructions(content) {
+		keyword, rest, _ := strings.Cut(line, " ")
+		rest = strings.TrimSpace(rest)
+
+		switch strings.ToUpper(keyword) {
+		case "ARG":
+			// only ARGs before the first FROM are in scope for FROM lines.
+			if index == 0 {
+				parseArgs(rest, args)
+			}
+		case "FROM":
+			key, ref, ok := parseFrom(rest, index, args, stageNames)
+			index++
+			if !ok {
+				continue
+			}
+			image, err := parseReference(ref)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, stage{key: key, image: image})
+		}
+	}
+	return out, nil
+}
+
+// instructions splits a Dockerfile into logical instructions: continuation lines are joined,
+// comments and blank lines dropped, and leading whitespace trimmed. The escape character honors the
+// `# escape=` parser directive.
+func instructions(content []byte) []string {
+	escape := "\\"
+	var out []string
+	var current strings.Builder
+	directives := true
+
+	sc := bufio.NewScanner(bytes.NewReader(content))
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+
+		if directives {
+			if m := escapeDirectivePattern.FindStringSubmatch(line); m != nil {
+				escape = m[1]
+				continue
+			}
+			// parser directives are only recognized before any other content.
+			directives = strings.HasPrefix(line, "#") && strings.Contains(line, "=")
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			// comment lines inside a continuation are dropped without ending the instruction.
+			continue
+		}
+
+		if strings.HasSuffix(line, escape) {
+			current.WriteString(strings.TrimSuffix(line, escape))
+			current.WriteString(" ")
+			continue
+		}
+		current.WriteString(line)
+		out = append(out, strings.TrimSpace(current.String()))
+		current.Reset()
+	}
+	if current.Len() > 0 {
+		out = append(out, strings.TrimSpace(current.String()))
+	}
+	return out
+}
+
+// parseArgs records each NAME[=default] declared by an ARG instruction. A default may itself refer
+// to an earlier ARG.
+func parseArgs(rest string, args map[string]string) {
+	for _, field := range strings.Fields(rest) {
+		name, value, hasDefault := strings.Cut(field, "=")
+		if !hasDefault {
+			// declared without a default: only a --build-arg could set it.
+			if _, ok := args[name]; !ok {
+				args[name] = ""
+			}
+			continue
+		}
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		} else {
+			value = strings.Trim(value, `'`)
+		}
+		args[name] = expand(value, args)
+	}
+}
+
+// parseFrom reads `FROM [--platform=...] image [AS name]`, returning the stage key and the
+// expanded image reference. ok is false for stages with no external base image.
+func parseFrom(rest string, index int, args map[string]string, stageNames map[string]bool) (string, string, bool) {
+	var fields []string
+	for _, f := range strings.Fields(rest) {
+		if strings.HasPrefix(f, "--") {
+			continue
+		}
+		fields = append(fields, f)
+	}
+	if len(fields) == 0 {
+		return "", "", false
+	}
+
+	key := fmt.Sprintf("#%d", index)
+	if len(fields) >= 3 && strings.EqualFold(fields[1], "AS") {
+		key = strings.ToLower(fields[2])
+		// register the name even when this stage is skipped below, so a later FROM naming it is
+		// still recognized as a stage rather than an image.
+		defer func() { stageNames[key] = true }()
+	}
+
+	ref := expand(fields[0], args)
+	switch {
+	case strings.Contains(ref, "$") || strings.HasSuffix(ref, ":") || strings.HasSuffix(ref, "@") || ref == "":
+		log.WithFields("image", fields[0]).Debug("base image detection: image references an ARG without a default; skipping")
+		return "", "", false
+	case strings.EqualFold(ref, "scratch"), stageNames[strings.ToLower(ref)]:
+		return "", "", false
+	}
+	return key, ref, true
+}
+
+var argPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(?:(:[-+])([^}]*))?\}|\$([A-Za-z_][A-Za-z0-9_]*)`)
+
+// expand substitutes $NAME, ${NAME}, ${NAME:-default} and ${NAME:+alternate} from args. A name
+// with no value is left as written (so callers can tell it was unresolved), unless a :- default
+// supplies one.
+func expand(s string, args map[string]string) string {
+	return argPattern.ReplaceAllStringFunc(s, func(m string) string {
+		sub := argPattern.FindStringSubmatch(m)
+		name, op, word := sub[1], sub[2], sub[3]
+		if name == "" {
+			name = sub[4]
+		}
+		value := args[name]
+		switch op {
+		case ":-":
+			if value == "" {
+				return word
+			}
+		case ":+":
+			if value == "" {
+				return ""
+			}
+			return word
+		}
+		if value == "" {
+			return m
+		}
+		return value
+	})
+}
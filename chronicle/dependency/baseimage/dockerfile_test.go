@@ -0,0 +1,97 @@
+package baseimage
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/chronicle/chronicle/release"
+)
+
+func TestParseDockerfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []stage
+	}{
+		{
+			name:    "single stage",
+			content: "FROM alpine:3.19\nRUN apk add --no-cache ca-certificates\n",
+			want:    []stage{{key: "#0", image: release.ImageReference{Name: "alpine", Tag: "3.19"}}},
+		},
+		{
+			name: "multi-stage build skips stages built from earlier stages and scratch",
+			content: `FROM golang:1.22-alpine AS builder
+RUN go build -o /app .
+
+FROM builder AS test
+RUN go test ./...
+
+FROM scratch AS minimal
+COPY --from=builder /app /app
+
+FROM gcr.io/distroless/static-debian12:nonroot
+COPY --from=builder /app /app
+`,
+			want: []stage{
+				{key: "builder", image: release.ImageReference{Name: "golang", Tag: "1.22-alpine"}},
+				{key: "#3", image: release.ImageReference{Name: "gcr.io/distroless/static-debian12", Tag: "nonroot"}},
+			},
+		},
+		{
+			name: "global ARGs are substituted, including defaults and references to earlier ARGs",
+			content: `ARG GO_VERSION=1.23
+ARG VARIANT="alpine3.20"
+ARG IMAGE=golang:${GO_VERSION}-${VARIANT}
+FROM ${IMAGE} AS build
+FROM alpine:${ALPINE_VERSION:-3.20}
+ARG GO_VERSION=9.99
+FROM golang:$GO_VERSION
+`,
+			want: []stage{
+				{key: "build", image: release.ImageReference{Name: "golang", Tag: "1.23-alpine3.20"}},
+				{key: "#1", image: release.ImageReference{Name: "alpine", Tag: "3.20"}},
+				{key: "#2", image: release.ImageReference{Name: "golang", Tag: "1.23"}},
+			},
+		},
+		{
+			name:    "ARG without a default cannot be resolved",
+			content: "ARG BASE\nFROM ${BASE}\nFROM alpine:3.19\n",
+			want:    []stage{{key: "#1", image: release.ImageReference{Name: "alpine", Tag: "3.19"}}},
+		},
+		{
+			name:    "digests, platform flags, registry ports and case-insensitive keywords",
+			content: "from --platform=$BUILDPLATFORM localhost:5000/base@sha256:abc as Base\nFROM docker.io/library/node:20-slim@sha256:def\n",
+			want: []stage{
+				{key: "base", image: release.ImageReference{Name: "localhost:5000/base", Digest: "sha256:abc"}},
+				{key: "#1", image: release.ImageReference{Name: "docker.io/library/node", Tag: "20-slim", Digest: "sha256:def"}},
+			},
+		},
+		{
+			name:    "continuation lines and comments",
+			content: "# syntax=docker/dockerfile:1\nFROM \\\n  # the pinned builder\n  golang:1.22 \\\n  AS build\n",
+			want:    []stage{{key: "build", image: release.ImageReference{Name: "golang", Tag: "1.22"}}},
+		},
+		{
+			name:    "escape directive",
+			content: "# escape=`\nFROM mcr.microsoft.com/windows/servercore:ltsc2022 `\n  AS base\n",
+			want:    []stage{{key: "base", image: release.ImageReference{Name: "mcr.microsoft.com/windows/servercore", Tag: "ltsc2022"}}},
+		},
+		{
+			name:    "no FROM lines",
+			content: "# just a comment\n",
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDockerfile([]byte(tt.content))
+			require.NoError(t, err)
+			if diff := cmp.Diff(tt.want, got, cmp.AllowUnexported(stage{})); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package baseimage
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/anchore/chronicle/chronicle/release"
+	"github.com/anchore/chronicle/internal"
+)
+
+// parseReference splits an image reference into name, tag, and digest. The tag separator is the
+// last ':' after the final '/', so a registry port ("localhost:5000/app") is not mistaken for a
+// tag. The name is kept as written; "golang" and "docker.io/library/golang" are not unified, since
+// switching between them is itself a change worth seeing.
+func parseReference(s string) (release.ImageReference, error) {
+	var ref release.ImageReference
+	name, digest, _ := strings.Cut(s, "@")
+	ref.Digest = digest
+
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, ref.Tag = name[:i], name[i+1:]
+	}
+	ref.Name = name
+
+	if ref.Name == "" {
+		return release.ImageReference{}, fmt.Errorf("unable to parse image reference %q", s)
+	}
+	return ref, nil
+}
+
+// tagVersionPattern reads a semver-ish tag: a dotted numeric version with an optional leading "v",
+// followed by any variant suffix ("1.23-alpine", "v2.1.0", "22.04", "3.12-slim-bookworm").
+var tagVersionPattern = regexp.MustCompile(`^v?(\d+(?:\.\d+)*)`)
+
+// direction reports whether the image tag moved up or down. Tags are only comparable when both
+// refer to the same image and both start with a numeric version; the variant suffix is not
+// compared, so "1.22-alpine" → "1.23-bookworm" is still an upgrade. Equal versions (e.g. a
+// digest-only change) have no direction.
+func direction(from, to release.ImageReference) release.BaseImageDirection {
+	if from.Name != to.Name {
+		return release.BaseImageDirectionUnknown
+	}
+	fv, ok := tagVersion(from.Tag)
+	if !ok {
+		return release.BaseImageDirectionUnknown
+	}
+	tv, ok := tagVersion(to.Tag)
+	if !ok {
+		return release.BaseImageDirectionUnknown
+	}
+	switch c := internal.CompareVersionSegments(tv, fv); {
+	case c > 0:
+		return release.BaseImageUpgrade
+	case c < 0:
+		return release.BaseImageDowngrade
+	}
+	return release.BaseImageDirectionUnknown
+}
+
+func tagVersion(tag string) ([]int, bool) {
+	m := tagVersionPattern.FindStringSubmatch(tag)
+	if m == nil {
+		return nil, false
+	}
+	var out []int
+	for _, seg := range strings.Split(m[1], ".") {
+		n, err := strconv.Atoi(seg)
+		if err != nil {
+			return nil, false
+		}
+		out = append(out, n)
+	}
+	return out, true
+}
@@ -0,0 +1,66 @@
+package baseimage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/chronicle/chronicle/release"
+)
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		ref  string
+		want release.ImageReference
+	}{
+		{ref: "alpine", want: release.ImageReference{Name: "alpine"}},
+		{ref: "alpine:3.19", want: release.ImageReference{Name: "alpine", Tag: "3.19"}},
+		{ref: "alpine@sha256:abc", want: release.ImageReference{Name: "alpine", Digest: "sha256:abc"}},
+		{ref: "ghcr.io/org/app:v1.2.3@sha256:abc", want: release.ImageReference{Name: "ghcr.io/org/app", Tag: "v1.2.3", Digest: "sha256:abc"}},
+		{ref: "localhost:5000/app", want: release.ImageReference{Name: "localhost:5000/app"}},
+		{ref: "localhost:5000/app:1.0", want: release.ImageReference{Name: "localhost:5000/app", Tag: "1.0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			got, err := parseReference(tt.ref)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.ref, got.String())
+		})
+	}
+}
+
+func TestDirection(t *testing.T) {
+	ref := func(name, tag string) release.ImageReference {
+		return release.ImageReference{Name: name, Tag: tag}
+	}
+
+	tests := []struct {
+		name string
+		from release.ImageReference
+		to   release.ImageReference
+		want release.BaseImageDirection
+	}{
+		{name: "minor upgrade", from: ref("golang", "1.22-alpine"), to: ref("golang", "1.23-alpine"), want: release.BaseImageUpgrade},
+		{name: "downgrade", from: ref("node", "22"), to: ref("node", "20.11.1"), want: release.BaseImageDowngrade},
+		{name: "v-prefixed", from: ref("app", "v1.9.0"), to: ref("app", "v1.10.0"), want: release.BaseImageUpgrade},
+		{name: "variant change still ordered by version", from: ref("python", "3.11-slim"), to: ref("python", "3.12-bookworm"), want: release.BaseImageUpgrade},
+		{name: "same version, different variant", from: ref("python", "3.12-slim"), to: ref("python", "3.12-alpine"), want: release.BaseImageDirectionUnknown},
+		{name: "non-version tag", from: ref("alpine", "latest"), to: ref("alpine", "3.20"), want: release.BaseImageDirectionUnknown},
+		{name: "different image", from: ref("golang", "1.22"), to: ref("cgr.dev/chainguard/go", "1.23"), want: release.BaseImageDirectionUnknown},
+		{
+			name: "digest only",
+			from: release.ImageReference{Name: "alpine", Tag: "3.20", Digest: "sha256:aaa"},
+			to:   release.ImageReference{Name: "alpine", Tag: "3.20", Digest: "sha256:bbb"},
+			want: release.BaseImageDirectionUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, direction(tt.from, tt.to))
+		})
+	}
+}
@@ -8,9 +8,11 @@ package dependency
 // layer in release/render — not here.
 //
 // This file holds only the atomic vocabulary shared across the whole package:
-// a Package, its identity (PackageKey), and a Vulnerability. The diff result
-// model lives in diff.go, the Scanner contract and its Scan output in
-// scanner.go, and vulnerability annotation in annotate.go.
+// a Package, its identity (PackageKey), how it is depended on (Relationship,
+// Scope), and a Vulnerability. The diff result model lives in diff.go, the
+// license policy in license.go, the
+// Scanner contract and its Scan output in scanner.go, and vulnerability
+// annotation in annotate.go.
 
 // Package is a single resolved dependency at a point in time. Identity is the
 // (Type, Name) pair; Version distinguishes points in time. Type is the raw syft
@@ -20,19 +22,85 @@ type Package struct {
 	Name    string // identity (with Type)
 	Version string
 	Type    string // syft package type string, e.g. "go-module", "npm"
+	PURL    string // package URL as cataloged, e.g. "pkg:golang/golang.org/x/net@v0.2.0"; "" when unknown. Not part of identity.
+
+	// Relationship and Scope describe how the project depends on the package,
+	// as far as the scanner could tell; both are "" when unknown. Neither is
+	// part of identity: a package that moves from indirect to direct is still
+	// the same package.
+	Relationship Relationship
+	Scope        Scope
+
+	// Licenses are the package's declared licenses, preferring each one's SPDX
+	// expression over the raw text it was cataloged from; sorted and
+	// deduplicated, nil when none were found.
+	Licenses []string
+
+	// Member is the workspace member that depends on the package: its
+	// directory relative to the repository root, or "." for the root itself.
+	// "" when the repository declares no workspace. A package two members
+	// depend on is cataloged once for each, so each member's changes stand on
+	// their own.
+	Member string
 }
 
+// Relationship is whether the project asks for a package itself (direct) or
+// only gets it through another dependency (transitive).
+type Relationship string
+
+const (
+	Direct     Relationship = "direct"
+	Transitive Relationship = "transitive"
+)
+
+// Scope is when a dependency is needed: at runtime, or only for development
+// (tests, builds, tooling).
+type Scope string
+
+const (
+	ScopeRuntime Scope = "runtime"
+	ScopeDev     Scope = "dev"
+)
+
 // key returns the PackageKey identity for a package.
 func (p Package) key() PackageKey {
 	return PackageKey{Type: p.Type, Name: p.Name}
 }
 
-// PackageKey is the identity used to index packages and vulnerabilities across
-// the two scans. It is deliberately version-less: identity is (Type, Name),
-// and Version is the axis the diff moves along — so the same package at two refs
-// shares a key and is matched as an update rather than a remove+add.
+// memberKey scopes a package's identity to the workspace member it was
+// cataloged in, so Compare diffs each member against itself. It collapses to
+// the plain key when no workspace is in play.
+type memberKey struct {
+	PackageKey
+	Member string
+}
+
+func (p Package) memberKey() memberKey {
+	return memberKey{PackageKey: p.key(), Member: p.Member}
+}
+
+// vulnKey returns the VulnKey a scan files the package's vulnerabilities under.
+func (p Package) vulnKey() VulnKey {
+	return VulnKey{PackageKey: p.key(), Version: p.Version, Member: p.Member}
+}
+
+// PackageKey is the identity used to index packages across the two scans. It
+// is deliberately version-less: identity is (Type, Name), and Version is the
+// axis the diff moves along — so the same package at two refs shares a key and
+// is matched as an update rather than a remove+add.
 type PackageKey struct{ Type, Name string }
 
+// VulnKey is what a Scan's vulnerabilities are indexed by: the package's
+// identity plus the version it was cataloged at and the workspace member it
+// was cataloged in. Unlike PackageKey it is not version-less — a package two
+// members pin at different versions carries different vulnerabilities in
+// each, so one scan can hold several entries for the same package.
+type VulnKey struct {
+	PackageKey
+	Version string
+	Member  string
+}
+
 // Vulnerability is a single match against a package at a point in time. It is
 // always reached through the package it affects (the Scan.Vulns map key, or
 // a PackageChange), so it carries no back-reference to that package.
@@ -41,4 +109,25 @@ type Vulnerability struct {
 	Severity   string
 	FixState   string // fixed / not-fixed / unknown
 	DataSource string // grype's primary reference URL for the ID; "" if none. Encoders use it to make the ID clickable.
+
+	// the prioritization data grype's DB carries, where it has it: whether CISA
+	// lists the vulnerability as known exploited (KEV), and its EPSS score (the
+	// probability of exploitation in the next 30 days) and that score's
+	// percentile, both 0–1. Zero when the DB has no entry.
+	KnownExploited bool
+	EPSS           float64
+	EPSSPercentile float64
+
+	// FixedIn lists the versions of the package that fix the vulnerability;
+	// empty when no fix is known.
+	FixedIn []string
+
+	// Aliases are other IDs the same vulnerability is tracked under (e.g. the
+	// CVE behind a GHSA), so statements made about any of them still apply.
+	Aliases []string
+}
+
+// IDs returns the vulnerability's ID followed by its aliases.
+func (v Vulnerability) IDs() []string {
+	return append([]string{v.ID}, v.Aliases...)
 }
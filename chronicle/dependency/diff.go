@@ -34,6 +34,26 @@ type DiffConfig struct {
 	// MinSeverity filters which vulnerabilities annotation attributes to a change;
 	// "" includes all. Ignored when neither ref was vuln-matched.
 	MinSeverity string
+
+	// MinEPSS filters further by EPSS score (0–1): a vulnerability scored below
+	// it is not attributed unless it is known exploited. 0 includes all.
+	MinEPSS float64
+
+	// Suppressor drops vulnerabilities the project has declared it is not
+	// affected by (e.g. through VEX statements) from the introduced and
+	// remaining listings; nil suppresses nothing.
+	Suppressor Suppressor
+
+	// LicensePolicy names the licenses the project does not accept; changes
+	// that bring one in are reported on the Diff as LicenseViolations. The
+	// zero value checks nothing.
+	LicensePolicy LicensePolicy
+
+	// Gate forbids introducing some vulnerabilities; changes that do are
+	// reported on the Diff as GateViolations. It sees every introduced
+	// vulnerability, whatever MinSeverity and MinEPSS hide from the listings,
+	// but not those the Suppressor drops. The zero value forbids nothing.
+	Gate VulnGate
 }
 
 // VersionComparer classifies the direction of a version change for a given
@@ -63,6 +83,11 @@ type Diff struct {
 	// spans unchanged packages too), so annotate sets it from the scans rather
 	// than NewDiff deriving it. Zero on an unannotated diff.
 	RemainingCount int `json:"-"`
+	// SuppressedCount is the unique vuln IDs the Suppressor kept out of the
+	// introduced and remaining listings, so the rollups can be reconciled with
+	// a scanner that applies the same VEX statements. Zero on an unannotated
+	// diff.
+	SuppressedCount int `json:"-"`
 
 	// Since and Until are the scans that were compared, retained so callers
 	// can derive per-ref scan figures (package and vulnerability counts, match
@@ -79,6 +104,24 @@ type Diff struct {
 	// catalogs, not part of the serialized delta; RemainingCount carries the
 	// rollup figure instead.
 	Remaining []PackageVulns `json:"-"`
+
+	// LicenseViolations are the changes that introduced a license the
+	// configured LicensePolicy denies. Set by ComputeDiff; nil when no policy
+	// was configured or nothing violated it.
+	LicenseViolations []LicenseViolation `json:",omitempty"`
+
+	// GateViolations are the changes that introduce a vulnerability the
+	// configured VulnGate forbids, each with its Vuln narrowed to the
+	// forbidden ones. Set by annotate, from the introduced vulnerabilities
+	// before the presentation filters; nil when no gate was configured or
+	// nothing tripped it. Excluded from JSON: it is a verdict on this run's
+	// policy, not part of the changelog.
+	GateViolations []PackageChange `json:"-"`
+
+	// VulnerabilityDB is the database the vulnerability annotations were
+	// matched against. Set by the caller that loaded it (ComputeDiff never sees
+	// the DB itself); nil on an unannotated diff.
+	VulnerabilityDB *VulnerabilityDB `json:",omitempty"`
 }
 
 // ChangeTotals is a per-kind count of package changes.
@@ -98,7 +141,35 @@ type PackageChange struct {
 	FromVersion string `json:",omitempty"` // "" for Added
 	ToVersion   string `json:",omitempty"` // "" for Removed
 	Kind        ChangeKind
-	Vuln        *VulnDelta `json:",omitempty"` // nil unless annotated
+	// PURL is the package URL of the package as it stands after the change —
+	// the until side, or the since side for Removed — so external documents
+	// (VEX, SBOM) can name the exact component. "" when the scanner had none.
+	PURL string `json:",omitempty"`
+	// Relationship and Scope are how the project depends on the package, taken
+	// from the same side as PURL; "" when the scanner could not tell.
+	Relationship Relationship `json:",omitempty"`
+	Scope        Scope        `json:",omitempty"`
+	// Member is the workspace member the change was made in (see
+	// Package.Member); "" when the repository declares no workspace.
+	Member string `json:",omitempty"`
+	// FromLicenses and ToLicenses are the package's licenses at each side of
+	// the change; empty on the side the package is absent from, or where none
+	// were cataloged. See LicenseChanged.
+	FromLicenses []string   `json:",omitempty"`
+	ToLicenses   []string   `json:",omitempty"`
+	Vuln         *VulnDelta `json:",omitempty"` // nil unless annotated
+	// PullRequests are the merged pull requests whose manifest or lockfile
+	// edits made the change, oldest first; nil unless attributed (see the
+	// attribution package), or when no pull request could be found.
+	PullRequests []PullRequest `json:",omitempty"`
+}
+
+// PullRequest identifies a merged pull request a change is attributed to.
+type PullRequest struct {
+	Number int
+	Title  string
+	URL    string
+	Author string
 }
 
 // ComputeDiff diffs the dependency graph between cfg.SinceRef and cfg.UntilRef.
@@ -128,28 +199,33 @@ func ComputeDiff(ctx context.Context, scanner Scanner, cfg DiffConfig) (*Diff, e
 		return nil, err
 	}
 
-	diff := compare(since, until, cfg.Comparer)
+	diff := Compare(since, until, cfg.Comparer)
 
 	// attribute vulnerabilities when either ref was actually vuln-matched. A
 	// matched ref always carries a non-nil Vulns map (empty if it found none), so
 	// the map's presence is the annotate signal — no separate flag is needed, and
 	// it can't disagree with how the scanner was built.
 	if diff.Since.Vulns != nil || diff.Until.Vulns != nil {
-		diff = annotate(diff, annotateConfig{MinSeverity: cfg.MinSeverity})
+		diff = annotate(diff, annotateConfig{MinSeverity: cfg.MinSeverity, MinEPSS: cfg.MinEPSS, Suppressor: cfg.Suppressor, Gate: cfg.Gate})
 	}
+	diff.LicenseViolations = cfg.LicensePolicy.Violations(diff.Changes)
 
 	return &diff, nil
 }
 
-// compare builds a Diff from two scans. Packages present only in since are
+// Compare builds a Diff from two scans. Packages present only in since are
 // Removed; only in until are Added; present in both with a differing version are
 // Updated or Downgraded (determined by cmp). Equal versions are omitted. The
-// Changes slice is sorted deterministically (by Type then Name) so output is
-// stable for tests and rendering. The compared scans are retained on the
-// returned diff so callers can read the raw per-ref data behind it.
-func compare(since, until Scan, cmp VersionComparer) Diff {
-	sinceIdx := indexPackages(since.Packages)
-	untilIdx := indexPackages(until.Packages)
+// Changes slice is sorted deterministically (by Type, Name, then workspace
+// member) so output is stable for tests and rendering. In a workspace each
+// member is compared against itself, so a package two members depend on
+// yields a change per member that moved it. The compared scans are retained on the
+// returned diff so callers can read the raw per-ref data behind it. Scans
+// need not come from a Scanner: the dependency timeline compares the packages
+// parsed from one commit's manifests the same way.
+func Compare(since, until Scan, cmp VersionComparer) Diff {
+	sinceIdx := indexBy(since.Packages, Package.memberKey)
+	untilIdx := indexBy(until.Packages, Package.memberKey)
 
 	var changes []PackageChange
 
@@ -158,11 +234,16 @@ func compare(since, until Scan, cmp VersionComparer) Diff {
 		untilPkg, inUntil := untilIdx[key]
 		if !inUntil {
 			changes = append(changes, PackageChange{
-				Name:        sincePkg.Name,
-				Type:        sincePkg.Type,
-				FromVersion: sincePkg.Version,
-				ToVersion:   "",
-				Kind:        Removed,
+				Name:         sincePkg.Name,
+				Type:         sincePkg.Type,
+				FromVersion:  sincePkg.Version,
+				ToVersion:    "",
+				Kind:         Removed,
+				PURL:         sincePkg.PURL,
+				Relationship: sincePkg.Relationship,
+				Scope:        sincePkg.Scope,
+				Member:       sincePkg.Member,
+				FromLicenses: sincePkg.Licenses,
 			})
 			continue
 		}
@@ -174,11 +255,17 @@ func compare(since, until Scan, cmp VersionComparer) Diff {
 
 		kind := classifyVersionChange(sincePkg.Type, sincePkg.Version, untilPkg.Version, cmp)
 		changes = append(changes, PackageChange{
-			Name:        sincePkg.Name,
-			Type:        sincePkg.Type,
-			FromVersion: sincePkg.Version,
-			ToVersion:   untilPkg.Version,
-			Kind:        kind,
+			Name:         sincePkg.Name,
+			Type:         sincePkg.Type,
+			FromVersion:  sincePkg.Version,
+			ToVersion:    untilPkg.Version,
+			Kind:         kind,
+			PURL:         untilPkg.PURL,
+			Relationship: untilPkg.Relationship,
+			Scope:        untilPkg.Scope,
+			Member:       untilPkg.Member,
+			FromLicenses: sincePkg.Licenses,
+			ToLicenses:   untilPkg.Licenses,
 		})
 	}
 
@@ -186,11 +273,16 @@ func compare(since, until Scan, cmp VersionComparer) Diff {
 	for key, untilPkg := range untilIdx {
 		if _, inSince := sinceIdx[key]; !inSince {
 			changes = append(changes, PackageChange{
-				Name:        untilPkg.Name,
-				Type:        untilPkg.Type,
-				FromVersion: "",
-				ToVersion:   untilPkg.Version,
-				Kind:        Added,
+				Name:         untilPkg.Name,
+				Type:         untilPkg.Type,
+				FromVersion:  "",
+				ToVersion:    untilPkg.Version,
+				Kind:         Added,
+				PURL:         untilPkg.PURL,
+				Relationship: untilPkg.Relationship,
+				Scope:        untilPkg.Scope,
+				Member:       untilPkg.Member,
+				ToLicenses:   untilPkg.Licenses,
 			})
 		}
 	}
@@ -202,22 +294,41 @@ func compare(since, until Scan, cmp VersionComparer) Diff {
 	return d
 }
 
-// sortChanges orders changes deterministically by Type then Name so output is
-// stable for tests and rendering. NewDiff calls it for every diff it builds.
+// sortChanges orders changes deterministically by Type, Name, then Member so
+// output is stable for tests and rendering. NewDiff calls it for every diff it
+// builds.
 func sortChanges(changes []PackageChange) {
 	sort.Slice(changes, func(i, j int) bool {
 		if changes[i].Type != changes[j].Type {
 			return changes[i].Type < changes[j].Type
 		}
-		return changes[i].Name < changes[j].Name
+		if changes[i].Name != changes[j].Name {
+			return changes[i].Name < changes[j].Name
+		}
+		return changes[i].Member < changes[j].Member
 	})
 }
 
-// indexPackages returns a map from PackageKey to Package for fast lookup.
-func indexPackages(pkgs []Package) map[PackageKey]Package {
-	idx := make(map[PackageKey]Package, len(pkgs))
+// indexBy returns a map from each package's key to the package for fast
+// lookup. A package cataloged more than once under the same key (e.g. required
+// by two go.mod files) is direct if any of its entries is, and runtime if any
+// of its entries is, so a direct requirement in one module is never hidden
+// behind an indirect one in another. Its licenses are the union of every
+// entry's.
+func indexBy[K comparable](pkgs []Package, key func(Package) K) map[K]Package {
+	idx := make(map[K]Package, len(pkgs))
 	for _, p := range pkgs {
-		idx[p.key()] = p
+		k := key(p)
+		if prev, ok := idx[k]; ok {
+			if prev.Relationship == Direct {
+				p.Relationship = Direct
+			}
+			if prev.Scope == ScopeRuntime {
+				p.Scope = ScopeRuntime
+			}
+			p.Licenses = mergeLicenses(prev.Licenses, p.Licenses)
+		}
+		idx[k] = p
 	}
 	return idx
 }
@@ -239,14 +350,19 @@ func classifyVersionChange(pkgType, from, to string, cmp VersionComparer) Change
 	return Updated
 }
 
-// key returns the PackageKey identity for a change, mirroring Package.key so
-// annotation can index a change straight into a Scan's vuln map.
-func (c PackageChange) key() PackageKey {
-	return PackageKey{Type: c.Type, Name: c.Name}
+// fromKey and toKey return the VulnKeys the changed package was filed under
+// at since and until, mirroring Package.vulnKey so annotation can index a
+// change straight into each Scan's vuln map.
+func (c PackageChange) fromKey() VulnKey {
+	return VulnKey{PackageKey: PackageKey{Type: c.Type, Name: c.Name}, Version: c.FromVersion, Member: c.Member}
+}
+
+func (c PackageChange) toKey() VulnKey {
+	return VulnKey{PackageKey: PackageKey{Type: c.Type, Name: c.Name}, Version: c.ToVersion, Member: c.Member}
 }
 
 // NewDiff freezes a set of changes into a Diff: it sorts them deterministically
-// (by Type then Name) and derives the per-kind totals and unique-vuln-ID counts
+// (by Type, Name, then Member) and derives the per-kind totals and unique-vuln-ID counts
 // from the changes themselves. annotate routes through NewDiff too, so an
 // annotated diff's counts reflect the per-change Vuln deltas.
 func NewDiff(changes []PackageChange) Diff {
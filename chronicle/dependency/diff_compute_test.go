@@ -42,7 +42,7 @@ require github.com/google/uuid v1.6.0
 	sinceSha, _ := buildGoModRepo(t, repoDir, goModBase, goModBumped)
 
 	result, err := dependency.ComputeDiff(context.Background(),
-		scan.NewScanner(source.NewGitTarget(repoDir), "", nil, nil, true, nil),
+		scan.NewScanner(source.NewGitTarget(repoDir), scan.Options{Recursive: true}),
 		dependency.DiffConfig{
 			Comparer: scan.NewVersionComparer(),
 			SinceRef: sinceSha,
@@ -67,6 +67,7 @@ require github.com/google/uuid v1.6.0
 	require.Equal(t, dependency.Updated, got.Kind)
 	require.Equal(t, "v1.3.0", got.FromVersion)
 	require.Equal(t, "v1.6.0", got.ToVersion)
+	require.Equal(t, dependency.Direct, got.Relationship, "a require without // indirect is a direct dependency")
 }
 
 // buildGoModRepo initializes a git repo at dir with two commits: the first writes
@@ -164,17 +164,88 @@ func TestCompare(t *testing.T) {
 				{Name: "shared-name", Type: "npm", FromVersion: "1", ToVersion: "2", Kind: Updated},
 			},
 		},
+		{
+			name: "purl is taken from the until side, or the since side when removed",
+			since: scan(
+				Package{Name: "lib", Version: "1", Type: "npm", PURL: "pkg:npm/lib@1"},
+				Package{Name: "gone", Version: "1", Type: "npm", PURL: "pkg:npm/gone@1"},
+			),
+			until: scan(
+				Package{Name: "lib", Version: "2", Type: "npm", PURL: "pkg:npm/lib@2"},
+				Package{Name: "new", Version: "1", Type: "npm", PURL: "pkg:npm/new@1"},
+			),
+			cmp: intComparer{},
+			want: []PackageChange{
+				{Name: "gone", Type: "npm", FromVersion: "1", Kind: Removed, PURL: "pkg:npm/gone@1"},
+				{Name: "lib", Type: "npm", FromVersion: "1", ToVersion: "2", Kind: Updated, PURL: "pkg:npm/lib@2"},
+				{Name: "new", Type: "npm", ToVersion: "1", Kind: Added, PURL: "pkg:npm/new@1"},
+			},
+		},
+		{
+			name: "relationship follows the until side, and a duplicate entry that is direct wins",
+			since: scan(
+				Package{Name: "lib", Version: "1", Type: "go-module", Relationship: Transitive},
+				Package{Name: "gone", Version: "1", Type: "npm", Relationship: Direct, Scope: ScopeDev},
+			),
+			until: scan(
+				// required directly by one module and indirectly by another.
+				Package{Name: "lib", Version: "2", Type: "go-module", Relationship: Direct, Scope: ScopeRuntime},
+				Package{Name: "lib", Version: "2", Type: "go-module", Relationship: Transitive},
+			),
+			cmp: intComparer{},
+			want: []PackageChange{
+				{Name: "lib", Type: "go-module", FromVersion: "1", ToVersion: "2", Kind: Updated, Relationship: Direct, Scope: ScopeRuntime},
+				{Name: "gone", Type: "npm", FromVersion: "1", Kind: Removed, Relationship: Direct, Scope: ScopeDev},
+			},
+		},
+		{
+			name: "licenses carry from each side, and a duplicate entry's licenses are merged",
+			since: scan(
+				Package{Name: "lib", Version: "1", Type: "npm", Licenses: []string{"MIT"}},
+				Package{Name: "gone", Version: "1", Type: "npm", Licenses: []string{"ISC"}},
+			),
+			until: scan(
+				Package{Name: "lib", Version: "2", Type: "npm", Licenses: []string{"BUSL-1.1"}},
+				Package{Name: "new", Version: "1", Type: "npm", Licenses: []string{"MIT"}},
+				Package{Name: "new", Version: "1", Type: "npm", Licenses: []string{"Apache-2.0"}},
+			),
+			cmp: intComparer{},
+			want: []PackageChange{
+				{Name: "gone", Type: "npm", FromVersion: "1", Kind: Removed, FromLicenses: []string{"ISC"}},
+				{Name: "lib", Type: "npm", FromVersion: "1", ToVersion: "2", Kind: Updated, FromLicenses: []string{"MIT"}, ToLicenses: []string{"BUSL-1.1"}},
+				{Name: "new", Type: "npm", ToVersion: "1", Kind: Added, ToLicenses: []string{"Apache-2.0", "MIT"}},
+			},
+		},
+		{
+			name: "workspace members are compared against themselves",
+			since: scan(
+				Package{Name: "lib", Version: "1", Type: "go-module", Member: "."},
+				Package{Name: "lib", Version: "1", Type: "go-module", Member: "api"},
+				Package{Name: "old", Version: "1", Type: "go-module", Member: "api"},
+			),
+			until: scan(
+				Package{Name: "lib", Version: "1", Type: "go-module", Member: "."},
+				Package{Name: "lib", Version: "2", Type: "go-module", Member: "api"},
+				Package{Name: "old", Version: "1", Type: "go-module", Member: "."},
+			),
+			cmp: intComparer{},
+			want: []PackageChange{
+				{Name: "lib", Type: "go-module", FromVersion: "1", ToVersion: "2", Kind: Updated, Member: "api"},
+				{Name: "old", Type: "go-module", ToVersion: "1", Kind: Added, Member: "."},
+				{Name: "old", Type: "go-module", FromVersion: "1", Kind: Removed, Member: "api"},
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := compare(tt.since, tt.until, tt.cmp)
+			got := Compare(tt.since, tt.until, tt.cmp)
 
 			// Compare establishes the structural diff only — no annotation, so
 			// every change's Vuln is nil. EquateEmpty treats the empty diff's
 			// changes (non-nil empty) as equal to the nil want.
 			if d := cmp.Diff(tt.want, got.Changes, cmpopts.EquateEmpty()); d != "" {
-				t.Errorf("compare() changes mismatch (-want +got):\n%s", d)
+				t.Errorf("Compare() changes mismatch (-want +got):\n%s", d)
 			}
 		})
 	}
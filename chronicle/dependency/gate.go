@@ -0,0 +1,52 @@
+package dependency
+
+// VulnGate is a release policy over the vulnerabilities a diff introduces: a
+// release that introduces one it forbids should not ship. The zero value
+// forbids nothing.
+//
+// Display settings don't weaken the policy: annotation applies the gate to
+// every introduced vulnerability, including those below the min-severity or
+// min-epss the changelog lists (see Diff.GateViolations). Only those the
+// project declared itself not affected by through VEX statements can't trip
+// it, as with a VEX-aware scanner.
+type VulnGate struct {
+	// IntroducedSeverity forbids introducing a vulnerability of this severity
+	// or higher (case-insensitive: negligible, low, medium, high, critical).
+	// "" disables the severity check.
+	IntroducedSeverity string
+	// KnownExploited forbids introducing a vulnerability CISA lists as known
+	// exploited, whatever its severity.
+	KnownExploited bool
+}
+
+// Enabled reports whether the gate forbids anything.
+func (g VulnGate) Enabled() bool {
+	return severityRank(g.IntroducedSeverity) > 0 || g.KnownExploited
+}
+
+// offending narrows c to the vulnerabilities among introduced that the gate
+// forbids; ok is false when there are none.
+func (g VulnGate) offending(c PackageChange, introduced []Vulnerability) (PackageChange, bool) {
+	if !g.Enabled() {
+		return PackageChange{}, false
+	}
+	var forbidden []Vulnerability
+	for _, v := range introduced {
+		if g.forbids(v) {
+			forbidden = append(forbidden, v)
+		}
+	}
+	if len(forbidden) == 0 {
+		return PackageChange{}, false
+	}
+	c.Vuln = &VulnDelta{Introduced: forbidden}
+	return c, true
+}
+
+func (g VulnGate) forbids(v Vulnerability) bool {
+	if g.KnownExploited && v.KnownExploited {
+		return true
+	}
+	minRank := severityRank(g.IntroducedSeverity)
+	return minRank > 0 && severityRank(v.Severity) >= minRank
+}
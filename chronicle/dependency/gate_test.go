@@ -0,0 +1,141 @@
+package dependency
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVulnGate_offending(t *testing.T) {
+	kev := Vulnerability{ID: "CVE-KEV", Severity: "low", KnownExploited: true}
+	lib := PackageChange{Name: "lib", Type: "go-module", FromVersion: "1", ToVersion: "2", Kind: Updated}
+	introduced := []Vulnerability{vuln("CVE-CRIT", "Critical"), vuln("CVE-MED", "medium"), kev, vuln("CVE-UNK", "")}
+
+	narrowed := func(vulns ...Vulnerability) PackageChange {
+		c := lib
+		c.Vuln = &VulnDelta{Introduced: vulns}
+		return c
+	}
+
+	tests := []struct {
+		name   string
+		gate   VulnGate
+		want   PackageChange
+		wantOK bool
+	}{
+		{
+			name: "zero value forbids nothing",
+			gate: VulnGate{},
+		},
+		{
+			name:   "introduced severity at or above the threshold",
+			gate:   VulnGate{IntroducedSeverity: "critical"},
+			want:   narrowed(vuln("CVE-CRIT", "Critical")),
+			wantOK: true,
+		},
+		{
+			name:   "known exploited regardless of severity",
+			gate:   VulnGate{KnownExploited: true},
+			want:   narrowed(kev),
+			wantOK: true,
+		},
+		{
+			name:   "either check trips the gate",
+			gate:   VulnGate{IntroducedSeverity: "critical", KnownExploited: true},
+			want:   narrowed(vuln("CVE-CRIT", "Critical"), kev),
+			wantOK: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.gate.offending(lib, introduced)
+			assert.Equal(t, tt.wantOK, ok)
+			if d := cmp.Diff(tt.want, got); d != "" {
+				t.Errorf("offending() mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestAnnotate_GateViolations(t *testing.T) {
+	entry := func(name, version string, vulns ...Vulnerability) struct {
+		key   VulnKey
+		vulns []Vulnerability
+	} {
+		return struct {
+			key   VulnKey
+			vulns []Vulnerability
+		}{goKey(name, version), vulns}
+	}
+	// a critical the EPSS filter hides, and a medium KEV the severity filter hides
+	unlikely := Vulnerability{ID: "CVE-CRIT", Severity: "critical", EPSS: 0.01}
+	kev := Vulnerability{ID: "CVE-KEV", Severity: "medium", EPSS: 0.5, KnownExploited: true}
+
+	changes := []PackageChange{
+		{Name: "lib", Type: "go-module", FromVersion: "1", ToVersion: "2", Kind: Updated, PURL: "pkg:golang/lib@2"},
+		{Name: "new", Type: "go-module", ToVersion: "1", Kind: Added, PURL: "pkg:golang/new@1"},
+		{Name: "old", Type: "go-module", FromVersion: "1", Kind: Removed, PURL: "pkg:golang/old@1"},
+	}
+	since := Scan{Vulns: vulnMap(
+		entry("lib", "1", vuln("CVE-FIXED", "critical")),
+		entry("old", "1", vuln("CVE-GONE", "critical")),
+	)}
+	until := Scan{Vulns: vulnMap(
+		entry("lib", "2", unlikely),
+		entry("new", "1", kev),
+	)}
+
+	tests := []struct {
+		name           string
+		cfg            annotateConfig
+		want           []PackageChange
+		wantSuppressed int
+	}{
+		{
+			name: "no gate",
+			cfg:  annotateConfig{MinSeverity: "high", MinEPSS: 0.1},
+		},
+		{
+			name: "presentation filters don't hide vulnerabilities from the gate",
+			cfg:  annotateConfig{MinSeverity: "high", MinEPSS: 0.1, Gate: VulnGate{IntroducedSeverity: "critical", KnownExploited: true}},
+			want: []PackageChange{
+				{Name: "lib", Type: "go-module", FromVersion: "1", ToVersion: "2", Kind: Updated, PURL: "pkg:golang/lib@2", Vuln: &VulnDelta{Introduced: []Vulnerability{unlikely}}},
+				{Name: "new", Type: "go-module", ToVersion: "1", Kind: Added, PURL: "pkg:golang/new@1", Vuln: &VulnDelta{Introduced: []Vulnerability{kev}}},
+			},
+		},
+		{
+			// neither was ever listed, so neither counts as suppressed
+			name: "vex suppression still applies",
+			cfg: annotateConfig{
+				MinSeverity: "high", MinEPSS: 0.1,
+				Suppressor: idSuppressor{ids: []string{"CVE-KEV", "CVE-CRIT"}},
+				Gate:       VulnGate{IntroducedSeverity: "critical", KnownExploited: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewDiff(changes)
+			d.Since = since
+			d.Until = until
+			got := annotate(d, tt.cfg)
+
+			if diff := cmp.Diff(tt.want, got.GateViolations); diff != "" {
+				t.Errorf("annotate() gate violations mismatch (-want +got):\n%s", diff)
+			}
+			assert.Equal(t, tt.wantSuppressed, got.SuppressedCount)
+			// the listings are still filtered for presentation
+			assert.Empty(t, got.Changes[0].Vuln.Introduced)
+		})
+	}
+}
+
+func TestVulnGate_Enabled(t *testing.T) {
+	assert.False(t, VulnGate{}.Enabled())
+	assert.False(t, VulnGate{IntroducedSeverity: "bogus"}.Enabled())
+	assert.True(t, VulnGate{IntroducedSeverity: "high"}.Enabled())
+	assert.True(t, VulnGate{KnownExploited: true}.Enabled())
+}
@@ -0,0 +1,133 @@
+package dependency
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+// LicensePolicy is the set of licenses a project does not accept in its
+// dependencies. The zero value denies nothing.
+type LicensePolicy struct {
+	// Deny lists SPDX license IDs, matched case-insensitively. An entry also
+	// matches the versions and variants of its family, so "AGPL" covers
+	// AGPL-3.0-only and AGPL-3.0-or-later, and "GPL-2.0" covers GPL-2.0-only —
+	// but "GPL" does not cover LGPL-2.1, which is a different ID.
+	Deny []string
+}
+
+// LicenseViolation is one change that brought a denied license into the
+// project: an added package, or an update whose license moved onto a denied
+// one. A package that was already under a denied license at the since ref is
+// not a new violation.
+type LicenseViolation struct {
+	Name    string
+	Type    string
+	Version string
+	License string // the denied license ID, as the package declares it
+}
+
+// LicenseChanged reports whether an update or downgrade moved the package to a
+// different set of licenses. Both sides must be known: a package whose license
+// was not cataloged at one of the refs has nothing to compare.
+func (c PackageChange) LicenseChanged() bool {
+	if c.Kind != Updated && c.Kind != Downgraded {
+		return false
+	}
+	return len(c.FromLicenses) > 0 && len(c.ToLicenses) > 0 && !slices.Equal(c.FromLicenses, c.ToLicenses)
+}
+
+// Enabled reports whether the policy denies anything.
+func (p LicensePolicy) Enabled() bool {
+	return len(p.Deny) > 0
+}
+
+// Violations returns the changes that introduced a denied license, sorted like
+// the changes themselves. Each denied license ID a change introduces is its own
+// violation.
+func (p LicensePolicy) Violations(changes []PackageChange) []LicenseViolation {
+	if !p.Enabled() {
+		return nil
+	}
+	var out []LicenseViolation
+	for _, c := range changes {
+		if c.Kind == Removed {
+			continue
+		}
+		before := p.denied(c.FromLicenses)
+		for _, id := range p.denied(c.ToLicenses) {
+			if slices.Contains(before, id) {
+				continue
+			}
+			out = append(out, LicenseViolation{Name: c.Name, Type: c.Type, Version: c.ToVersion, License: id})
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Type != out[j].Type {
+			return out[i].Type < out[j].Type
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+// denied returns the license IDs in licenses that the policy denies. Each
+// license may be an SPDX expression; every ID in it is checked, so a dual
+// license offering a denied option ("MIT OR AGPL-3.0-only") is flagged for
+// review rather than assumed to be taken under the permissive choice.
+func (p LicensePolicy) denied(licenses []string) []string {
+	var out []string
+	for _, l := range licenses {
+		for _, id := range licenseIDs(l) {
+			if p.denies(id) && !slices.Contains(out, id) {
+				out = append(out, id)
+			}
+		}
+	}
+	return out
+}
+
+func (p LicensePolicy) denies(id string) bool {
+	id = strings.ToLower(id)
+	for _, d := range p.Deny {
+		d = strings.ToLower(strings.TrimSpace(d))
+		if d != "" && (id == d || strings.HasPrefix(id, d+"-")) {
+			return true
+		}
+	}
+	return false
+}
+
+// licenseIDs splits an SPDX expression into its license IDs, dropping the
+// operators, parentheses, any "+" suffix, and the exception named after a
+// WITH (an exception grants permissions; it is not a license of its own).
+func licenseIDs(expression string) []string {
+	fields := strings.FieldsFunc(expression, func(r rune) bool {
+		return r == ' ' || r == '(' || r == ')'
+	})
+	var out []string
+	for i := 0; i < len(fields); i++ {
+		switch strings.ToUpper(fields[i]) {
+		case "AND", "OR":
+			continue
+		case "WITH":
+			i++
+			continue
+		}
+		out = append(out, strings.TrimSuffix(fields[i], "+"))
+	}
+	return out
+}
+
+// mergeLicenses returns the sorted union of two license lists.
+func mergeLicenses(a, b []string) []string {
+	if len(a) == 0 {
+		return b
+	}
+	if len(b) == 0 {
+		return a
+	}
+	out := slices.Concat(a, b)
+	slices.Sort(out)
+	return slices.Compact(out)
+}
@@ -0,0 +1,65 @@
+package dependency
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLicensePolicy_Violations(t *testing.T) {
+	policy := LicensePolicy{Deny: []string{"agpl", "GPL-3.0", "SSPL-1.0"}}
+
+	tests := []struct {
+		name   string
+		change PackageChange
+		want   []LicenseViolation
+	}{
+		{
+			name:   "added package under a denied family",
+			change: PackageChange{Name: "a", Type: "npm", ToVersion: "1", Kind: Added, ToLicenses: []string{"AGPL-3.0-only"}},
+			want:   []LicenseViolation{{Name: "a", Type: "npm", Version: "1", License: "AGPL-3.0-only"}},
+		},
+		{
+			name:   "a family entry does not match a different ID that shares a suffix",
+			change: PackageChange{Name: "a", Type: "npm", ToVersion: "1", Kind: Added, ToLicenses: []string{"LGPL-3.0-only"}},
+		},
+		{
+			name:   "update onto a denied license",
+			change: PackageChange{Name: "a", Type: "npm", FromVersion: "1", ToVersion: "2", Kind: Updated, FromLicenses: []string{"Apache-2.0"}, ToLicenses: []string{"SSPL-1.0"}},
+			want:   []LicenseViolation{{Name: "a", Type: "npm", Version: "2", License: "SSPL-1.0"}},
+		},
+		{
+			name:   "a package already under a denied license is not new exposure",
+			change: PackageChange{Name: "a", Type: "npm", FromVersion: "1", ToVersion: "2", Kind: Updated, FromLicenses: []string{"GPL-3.0-only"}, ToLicenses: []string{"GPL-3.0-only"}},
+		},
+		{
+			name:   "every ID of an expression is checked, but not a WITH exception",
+			change: PackageChange{Name: "a", Type: "npm", ToVersion: "1", Kind: Added, ToLicenses: []string{"(MIT OR GPL-3.0+) AND GPL-2.0 WITH AGPL-exception"}},
+			want:   []LicenseViolation{{Name: "a", Type: "npm", Version: "1", License: "GPL-3.0"}},
+		},
+		{
+			name:   "removed packages are never violations",
+			change: PackageChange{Name: "a", Type: "npm", FromVersion: "1", Kind: Removed, FromLicenses: []string{"AGPL-3.0-only"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := policy.Violations([]PackageChange{tt.change})
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	require.Nil(t, LicensePolicy{}.Violations([]PackageChange{{Name: "a", Kind: Added, ToLicenses: []string{"AGPL-3.0-only"}}}))
+}
+
+func TestPackageChange_LicenseChanged(t *testing.T) {
+	require.True(t, PackageChange{Kind: Updated, FromLicenses: []string{"MIT"}, ToLicenses: []string{"BUSL-1.1"}}.LicenseChanged())
+	require.False(t, PackageChange{Kind: Updated, FromLicenses: []string{"MIT"}, ToLicenses: []string{"MIT"}}.LicenseChanged())
+	// a side that was not cataloged has nothing to compare.
+	require.False(t, PackageChange{Kind: Updated, ToLicenses: []string{"MIT"}}.LicenseChanged())
+	require.False(t, PackageChange{Kind: Added, ToLicenses: []string{"MIT"}}.LicenseChanged())
+}
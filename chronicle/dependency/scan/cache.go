@@ -0,0 +1,223 @@
+package scan
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anchore/chronicle/chronicle/dependency"
+	"github.com/anchore/chronicle/internal/log"
+	"github.com/anchore/syft/syft/format/syftjson"
+)
+
+// cacheVersion is part of every cache key; bump it when the shape of a cached
+// entry (or what goes into one) changes, so old entries are simply never hit.
+const cacheVersion = "5"
+
+// Cache is an on-disk, content-addressed store of scan results, so a ref whose
+// tree has not changed (typically the previous release) is not materialized and
+// cataloged again on every run. Catalogs are keyed by everything that decides
+// what syft finds — the commit's tree hash and the scan's scoping — and
+// vulnerability matches separately by the catalog plus the grype DB's build
+// time, so a DB update re-matches without re-cataloging. An entry that can't be
+// read or written is treated as a miss: the cache only ever saves time.
+type Cache struct {
+	dir string
+}
+
+// NewCache returns a Cache rooted at dir, created on first write.
+func NewCache(dir string) *Cache {
+	return &Cache{dir: dir}
+}
+
+// DefaultCacheDir is where scan results are cached when no directory is
+// configured: chronicle's directory under the user cache dir.
+func DefaultCacheDir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to find user cache dir: %w", err)
+	}
+	return filepath.Join(base, "chronicle", "dependency-scans"), nil
+}
+
+// catalogEntry is a cached catalog: the mapped packages (classification is
+// done against the materialized tree, so it is cached rather than redone) and
+// the SBOM as syft JSON, which matching and SBOM exports still need.
+type catalogEntry struct {
+	Packages []dependency.Package `json:"packages"`
+	SBOM     json.RawMessage      `json:"sbom"`
+}
+
+// vulnEntry is one package's cached vulnerability matches.
+type vulnEntry struct {
+	Type    string                     `json:"type"`
+	Name    string                     `json:"name"`
+	Version string                     `json:"version"`
+	Member  string                     `json:"member,omitempty"`
+	Vulns   []dependency.Vulnerability `json:"vulns"`
+}
+
+// catalogKey derives the cache key for a catalog of the tree treeHash, scanned
+// with s's scoping. The ref is included because syft names the SBOM's source
+// after it, and that name ends up in exported documents. syft's own version is
+// included so an upgrade (new catalogers, fixed parsers) never reuses old
+// results.
+func (s *scanner) catalogKey(treeHash, ref string) string {
+	// the members follow from the tree, but whether they were used doesn't.
+	members := "-"
+	if m, ok := s.workspaces[ref]; ok {
+		members = "[" + strings.Join(m, ",") + "]"
+	}
+	return cacheKey("catalog", cacheVersion, moduleVersion("github.com/anchore/syft"),
+		treeHash, ref, s.sourceName,
+		strings.Join(s.ecosystems, ","), strings.Join(s.excludePaths, ","), strconv.FormatBool(s.recursive), members)
+}
+
+// vulnKey derives the cache key for the matches of a cached catalog against the
+// DB built at built.
+func vulnKey(catalogKey string, built time.Time) string {
+	return cacheKey("vulns", cacheVersion, moduleVersion("github.com/anchore/grype"), catalogKey, built.UTC().Format(time.RFC3339Nano))
+}
+
+func cacheKey(parts ...string) string {
+	h := sha256.New()
+	for _, p := range parts {
+		// length-prefix each part so no two part lists hash alike.
+		fmt.Fprintf(h, "%d:%s;", len(p), p)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// moduleVersion reports the version of a module linked into the binary, or ""
+// when build info is unavailable.
+func moduleVersion(path string) string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == path {
+			if dep.Replace != nil {
+				return dep.Replace.Version
+			}
+			return dep.Version
+		}
+	}
+	return ""
+}
+
+// loadCatalog returns the cached catalog for key. A nil cache always misses.
+func (c *Cache) loadCatalog(key string) (*catalog, bool) {
+	var entry catalogEntry
+	if !c.load("catalog", key, &entry) {
+		return nil, false
+	}
+	sb, _, _, err := syftjson.NewFormatDecoder().Decode(bytes.NewReader(entry.SBOM))
+	if err != nil || sb == nil {
+		log.WithFields("error", err, "key", key).Debug("unable to decode cached sbom; cataloging again")
+		return nil, false
+	}
+	return &catalog{packages: entry.Packages, sb: sb}, true
+}
+
+// storeCatalog caches cat under key. A nil cache stores nothing.
+func (c *Cache) storeCatalog(key string, cat *catalog) {
+	if c == nil {
+		return
+	}
+	var buf bytes.Buffer
+	if err := syftjson.NewFormatEncoder().Encode(&buf, *cat.sb); err != nil {
+		log.WithFields("error", err).Debug("unable to encode sbom for the scan cache")
+		return
+	}
+	c.store("catalog", key, catalogEntry{Packages: cat.packages, SBOM: buf.Bytes()})
+}
+
+// loadVulns returns the cached matches for key. A nil cache always misses.
+func (c *Cache) loadVulns(key string) (map[dependency.VulnKey][]dependency.Vulnerability, bool) {
+	var entries []vulnEntry
+	if !c.load("vulns", key, &entries) {
+		return nil, false
+	}
+	out := make(map[dependency.VulnKey][]dependency.Vulnerability, len(entries))
+	for _, e := range entries {
+		out[dependency.VulnKey{PackageKey: dependency.PackageKey{Type: e.Type, Name: e.Name}, Version: e.Version, Member: e.Member}] = e.Vulns
+	}
+	return out, true
+}
+
+// storeVulns caches matches under key. A nil cache stores nothing.
+func (c *Cache) storeVulns(key string, vulns map[dependency.VulnKey][]dependency.Vulnerability) {
+	if c == nil {
+		return
+	}
+	entries := make([]vulnEntry, 0, len(vulns))
+	for k, vs := range vulns {
+		entries = append(entries, vulnEntry{Type: k.Type, Name: k.Name, Version: k.Version, Member: k.Member, Vulns: vs})
+	}
+	c.store("vulns", key, entries)
+}
+
+func (c *Cache) load(kind, key string, v any) bool {
+	if c == nil || key == "" {
+		return false
+	}
+	raw, err := os.ReadFile(c.path(kind, key))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.WithFields("error", err, "key", key).Debug("unable to read scan cache entry")
+		}
+		return false
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		log.WithFields("error", err, "key", key).Debug("unable to parse scan cache entry")
+		return false
+	}
+	return true
+}
+
+// store writes an entry through a temp file and a rename, so a concurrent run
+// (or a crash mid-write) never leaves a truncated entry behind.
+func (c *Cache) store(kind, key string, v any) {
+	if key == "" {
+		return
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		log.WithFields("error", err).Debug("unable to encode scan cache entry")
+		return
+	}
+	path := c.path(kind, key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		log.WithFields("error", err).Debug("unable to create scan cache dir")
+		return
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), "."+key+".*")
+	if err != nil {
+		log.WithFields("error", err).Debug("unable to write scan cache entry")
+		return
+	}
+	defer os.Remove(f.Name()) // no-op once renamed
+	_, err = f.Write(raw)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		log.WithFields("error", err).Debug("unable to write scan cache entry")
+	}
+}
+
+func (c *Cache) path(kind, key string) string {
+	return filepath.Join(c.dir, kind, key+".json")
+}
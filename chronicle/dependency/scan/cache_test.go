@@ -0,0 +1,132 @@
+package scan
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/chronicle/chronicle/dependency"
+)
+
+// countingTarget materializes a fixed directory for every ref, counting the
+// calls, and names every ref's content by a fixed tree hash.
+type countingTarget struct {
+	dir          string
+	tree         string
+	materialized int
+}
+
+func (c *countingTarget) Materialize(_ context.Context, _ string) (string, func() error, error) {
+	c.materialized++
+	return c.dir, func() error { return nil }, nil
+}
+
+func (c *countingTarget) TreeHash(_ string) (string, error) {
+	return c.tree, nil
+}
+
+func TestScanner_Scan_Cache(t *testing.T) {
+	root := t.TempDir()
+	writeManifest(t, filepath.Join(root, "requirements.txt"), "rootdep==1.0.0")
+	cacheDir := t.TempDir()
+
+	target := &countingTarget{dir: root, tree: "tree-1"}
+	exportPath := filepath.Join(t.TempDir(), "sbom.spdx.json")
+	export, err := ParseSBOMExport("spdx-json=" + exportPath)
+	require.NoError(t, err)
+	s := NewScanner(target, Options{SourceName: "test", Ecosystems: []string{"python"}, Exports: map[string][]SBOMExport{"v1": {export}}, Cache: NewCache(cacheDir)})
+
+	first, err := s.Scan(context.Background(), "v1")
+	require.NoError(t, err)
+	require.Equal(t, 1, target.materialized)
+	require.NoError(t, os.Remove(exportPath))
+
+	// the second scan of the same tree is served from the cache, and still
+	// writes the requested SBOM from the cached catalog.
+	second, err := s.Scan(context.Background(), "v1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, target.materialized)
+	assert.Equal(t, packageNames(first), packageNames(second))
+	assert.Equal(t, []string{"rootdep"}, packageNames(second))
+	assert.FileExists(t, exportPath)
+
+	// new content is a new key.
+	target.tree = "tree-2"
+	_, err = s.Scan(context.Background(), "v1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, target.materialized)
+}
+
+func TestScanner_Scan_NoCache(t *testing.T) {
+	root := t.TempDir()
+	writeManifest(t, filepath.Join(root, "requirements.txt"), "rootdep==1.0.0")
+
+	target := &countingTarget{dir: root, tree: "tree-1"}
+	s := NewScanner(target, Options{SourceName: "test", Ecosystems: []string{"python"}})
+	for range 2 {
+		_, err := s.Scan(context.Background(), "v1")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, target.materialized)
+}
+
+// TestScanner_catalogKey checks every input that changes what syft finds also
+// changes the key.
+func TestScanner_catalogKey(t *testing.T) {
+	base := &scanner{sourceName: "org/repo", ecosystems: []string{"go"}, excludePaths: []string{"./vendor"}}
+	key := base.catalogKey("tree", "v1")
+	assert.Equal(t, key, base.catalogKey("tree", "v1"))
+
+	variants := map[string]string{
+		"tree":       base.catalogKey("other-tree", "v1"),
+		"ref":        base.catalogKey("tree", "v2"),
+		"source":     (&scanner{sourceName: "org/fork", ecosystems: []string{"go"}, excludePaths: []string{"./vendor"}}).catalogKey("tree", "v1"),
+		"ecosystems": (&scanner{sourceName: "org/repo", ecosystems: []string{"go", "python"}, excludePaths: []string{"./vendor"}}).catalogKey("tree", "v1"),
+		"excludes":   (&scanner{sourceName: "org/repo", ecosystems: []string{"go"}}).catalogKey("tree", "v1"),
+		"recursive":  (&scanner{sourceName: "org/repo", ecosystems: []string{"go"}, excludePaths: []string{"./vendor"}, recursive: true}).catalogKey("tree", "v1"),
+	}
+	for name, k := range variants {
+		assert.NotEqual(t, key, k, name)
+	}
+
+	built := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	assert.NotEqual(t, vulnKey(key, built), vulnKey(key, built.Add(time.Hour)))
+}
+
+func TestCache_Vulns(t *testing.T) {
+	c := NewCache(t.TempDir())
+	net := dependency.PackageKey{Type: "go-module", Name: "golang.org/x/net"}
+	vulns := map[dependency.VulnKey][]dependency.Vulnerability{
+		{PackageKey: net, Version: "v0.1.0"}:                  {{ID: "GHSA-xxxx", Severity: "High"}},
+		{PackageKey: net, Version: "v0.2.0", Member: "tools"}: {{ID: "GHSA-yyyy", Severity: "Low"}},
+	}
+
+	_, ok := c.loadVulns("key")
+	require.False(t, ok)
+
+	c.storeVulns("key", vulns)
+	got, ok := c.loadVulns("key")
+	require.True(t, ok)
+	assert.Equal(t, vulns, got)
+
+	// a nil cache (caching disabled) never hits and never fails.
+	var disabled *Cache
+	disabled.storeVulns("key", vulns)
+	_, ok = disabled.loadVulns("key")
+	assert.False(t, ok)
+}
+
+func packageNames(snap dependency.Scan) []string {
+	var out []string
+	for _, p := range snap.Packages {
+		out = append(out, p.Name)
+	}
+	sort.Strings(out)
+	return out
+}
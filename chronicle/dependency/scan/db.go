@@ -2,8 +2,11 @@ package scan
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"time"
 
+	"github.com/anchore/chronicle/chronicle/dependency"
 	"github.com/anchore/clio"
 	"github.com/anchore/grype/grype"
 	v6 "github.com/anchore/grype/grype/db/v6"
@@ -22,39 +25,125 @@ var grypeID = clio.Identification{Name: "grype"}
 // dependency core and the command layer never touch grype types).
 type DB struct {
 	provider vulnerability.Provider
+	built    time.Time // when the DB was built; zero when unknown, which disables caching matches
+	schema   string    // the DB's schema version, e.g. "v6.0.2"; "" when unknown
 }
 
-// DBStatus reports the on-disk grype DB state without any network access.
-// present is false when no DB is installed or its status is unreadable; age is
-// how long ago it was built (meaningful only when present). The caller uses this
-// to decide whether to update (when enabled) and whether to warn about staleness.
-func DBStatus() (present bool, age time.Duration) {
+// Info describes the loaded DB's build, for recording alongside the
+// annotations it produced.
+func (db *DB) Info() dependency.VulnerabilityDB {
+	return dependency.VulnerabilityDB{Built: db.built, SchemaVersion: db.schema}
+}
+
+// DBConfig selects the vulnerability DB LoadDB loads.
+type DBConfig struct {
+	// Update downloads the latest DB into grype's shared cache before loading
+	// it. Ignored when Import is set.
+	Update bool
+	// Import is a DB archive, raw .db file or URL to import and load in place
+	// of grype's shared cache, for builds without network access to grype's
+	// listing. It is imported into chronicle's own DB directory (see
+	// ImportedDBDir), so the shared cache is left as it was.
+	Import string
+	// Pin, when set, is the build time the loaded DB must have; any other
+	// build fails the load, so runs that must agree can't drift apart on a
+	// DB update.
+	Pin time.Time
+}
+
+// DBStatus reports the state of grype's shared, on-disk DB without any network
+// access. present is false when no DB is installed or its status is unreadable.
+// The caller uses the build time to decide whether to update (when enabled)
+// and whether to warn about staleness.
+func DBStatus() (info dependency.VulnerabilityDB, present bool) {
 	desc, err := v6.ReadDescription(installation.DefaultConfig(grypeID).DBFilePath())
 	if err != nil || desc == nil {
-		return false, 0
+		return dependency.VulnerabilityDB{}, false
+	}
+	return dependency.VulnerabilityDB{Built: desc.Built.Time, SchemaVersion: desc.SchemaVersion.String()}, true
+}
+
+// ImportedDBDir is where DBConfig.Import imports a DB to: chronicle's
+// directory under the user cache dir. Each import replaces the last.
+func ImportedDBDir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to find user cache dir: %w", err)
 	}
-	return true, time.Since(desc.Built.Time)
+	return filepath.Join(base, "chronicle", "vulnerability-db"), nil
 }
 
 // LoadDB loads the grype vulnerability DB into a provider the scanner matches
-// against. When update is set it first downloads the latest DB (the slow, network
-// step); otherwise it opens whatever DB is on disk directly. The on-disk DB is
+// against: the imported one when cfg.Import is set, otherwise grype's shared
+// DB, first updated (the slow, network step) when cfg.Update is set. The DB is
 // loaded regardless of its age (see loadProvider) — staleness is the caller's
-// concern to warn about, not an error here.
-func LoadDB(update bool) (*DB, error) {
-	provider, err := loadProvider(update)
+// concern to warn about, not an error here — but one that doesn't match
+// cfg.Pin is.
+func LoadDB(cfg DBConfig) (*DB, error) {
+	installCfg := installation.DefaultConfig(grypeID)
+	update := cfg.Update
+	if cfg.Import != "" {
+		dir, err := ImportedDBDir()
+		if err != nil {
+			return nil, fmt.Errorf("unable to import vulnerability DB: %w", err)
+		}
+		installCfg.DBRootDir = dir
+		if err := importDB(installCfg, cfg.Import); err != nil {
+			return nil, fmt.Errorf("unable to import vulnerability DB %q: %w", cfg.Import, err)
+		}
+		update = false
+	}
+
+	provider, status, err := loadProvider(installCfg, update)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load vulnerability DB: %w", err)
 	}
-	return &DB{provider: provider}, nil
+	db := &DB{provider: provider}
+	if status != nil {
+		db.built = status.Built
+		db.schema = status.SchemaVersion
+	}
+	if err := checkPin(db.built, cfg.Pin); err != nil {
+		return nil, err
+	}
+	return db, nil
 }
 
-func loadProvider(update bool) (vulnerability.Provider, error) {
-	installCfg := installation.DefaultConfig(grypeID)
+// checkPin fails a DB built at any time other than pin (when pinned). Both are
+// compared to the second, which is as precise as grype records a build.
+func checkPin(built, pin time.Time) error {
+	if pin.IsZero() {
+		return nil
+	}
+	if !built.Truncate(time.Second).Equal(pin.Truncate(time.Second)) {
+		return fmt.Errorf("vulnerability DB was built at %s, not at the pinned %s", formatBuilt(built), pin.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
+func formatBuilt(built time.Time) string {
+	if built.IsZero() {
+		return "an unknown time"
+	}
+	return built.UTC().Format(time.RFC3339)
+}
+
+func importDB(installCfg installation.Config, reference string) error {
+	client, err := distribution.NewClient(distribution.DefaultConfig())
+	if err != nil {
+		return fmt.Errorf("unable to create distribution client: %w", err)
+	}
+	c, err := installation.NewCurator(installCfg, client)
+	if err != nil {
+		return fmt.Errorf("unable to create curator: %w", err)
+	}
+	return c.Import(reference)
+}
+
+func loadProvider(installCfg installation.Config, update bool) (vulnerability.Provider, *vulnerability.ProviderStatus, error) {
 	// don't let an over-age on-disk DB turn into a load error: chronicle decides
 	// whether to update (when enabled) and warns on staleness itself. Checksum
 	// validation stays on, so a genuinely corrupt DB still errors (→ degrade).
 	installCfg.ValidateAge = false
-	provider, _, err := grype.LoadVulnerabilityDB(distribution.DefaultConfig(), installCfg, update)
-	return provider, err
+	return grype.LoadVulnerabilityDB(distribution.DefaultConfig(), installCfg, update)
 }
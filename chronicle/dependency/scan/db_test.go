@@ -0,0 +1,47 @@
+package scan
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckPin(t *testing.T) {
+	built := time.Date(2025, 1, 15, 1, 31, 22, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		built   time.Time
+		pin     time.Time
+		wantErr require.ErrorAssertionFunc
+	}{
+		{
+			name:    "unpinned accepts any build",
+			built:   built,
+			wantErr: require.NoError,
+		},
+		{
+			name:    "pinned build matches",
+			built:   built.Add(400 * time.Millisecond), // grype records builds to the second
+			pin:     built.In(time.FixedZone("EST", -5*60*60)),
+			wantErr: require.NoError,
+		},
+		{
+			name:    "a different build is refused",
+			built:   built.Add(24 * time.Hour),
+			pin:     built,
+			wantErr: require.Error,
+		},
+		{
+			name:    "an unknown build never satisfies a pin",
+			pin:     built,
+			wantErr: require.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.wantErr(t, checkPin(tt.built, tt.pin))
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package scan
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/anchore/chronicle/chronicle/dependency"
+	"github.com/anchore/chronicle/internal/bus"
+	"github.com/anchore/chronicle/internal/log"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/syft/syft"
+	syftSource "github.com/anchore/syft/syft/source"
+)
+
+// ImageInput names the container image to catalog for each ref. An image is
+// anything syft accepts as an image source: a registry reference
+// ("ghcr.io/org/app:1.2.0"), an OCI layout directory, or a docker/OCI archive on
+// disk, optionally with a syft scheme prefix ("oci-dir:build/image"). A ref's
+// explicit image wins; otherwise the template is expanded for it.
+type ImageInput struct {
+	// Images maps a ref to its image.
+	Images map[string]string
+	// Template is an image with "{ref}" and "{version}" placeholders, e.g.
+	// "ghcr.io/org/app:{version}".
+	Template string
+	// Versions maps a ref to the release version it was tagged as, without a
+	// leading "v" (image tags rarely carry one); it fills the "{version}"
+	// placeholder. A ref with no version (e.g. the first commit, when there is
+	// no previous release) can't expand a template that uses it.
+	Versions map[string]string
+}
+
+// Image resolves the image for ref, or returns an error when neither an
+// explicit image nor the template covers it.
+func (in ImageInput) Image(ref string) (string, error) {
+	if img := in.Images[ref]; img != "" {
+		return img, nil
+	}
+	if in.Template == "" {
+		return "", fmt.Errorf("no image configured for ref %q", ref)
+	}
+	img := strings.ReplaceAll(in.Template, "{ref}", ref)
+	if strings.Contains(img, "{version}") {
+		version := in.Versions[ref]
+		if version == "" {
+			return "", fmt.Errorf("unable to expand image template %q: ref %q has no release version", in.Template, ref)
+		}
+		img = strings.ReplaceAll(img, "{version}", version)
+	}
+	return img, nil
+}
+
+// imageScanner is the dependency.Scanner for released container images: it
+// catalogs the image each ref shipped as, so the diff covers the OS packages
+// and everything else baked into it, not just what the source tree declares.
+type imageScanner struct {
+	input      ImageInput
+	sourceName string
+	provider   vulnerability.Provider // nil means packages-only
+	exports    map[string][]SBOMExport
+}
+
+// NewImageScanner builds a Scanner that catalogs the image input resolves for
+// each ref with syft's default image catalogers. sourceName, db and exports
+// behave as Options.SourceName, DB and Exports do for NewScanner.
+func NewImageScanner(input ImageInput, sourceName string, db *DB, exports map[string][]SBOMExport) dependency.Scanner {
+	s := &imageScanner{input: input, sourceName: sourceName, exports: exports}
+	if db != nil {
+		s.provider = db.provider
+	}
+	return s
+}
+
+// Scan pulls (or opens) ref's image, catalogs it, and, when a DB was supplied,
+// matches it. An image that can't be resolved or cataloged is fatal for the
+// ref; a match failure degrades to packages-only.
+func (s *imageScanner) Scan(ctx context.Context, ref string) (dependency.Scan, error) {
+	img, err := s.input.Image(ref)
+	if err != nil {
+		return dependency.Scan{}, err
+	}
+
+	srcCfg := syft.DefaultGetSourceConfig().WithAlias(syftSource.Alias{Name: s.sourceName, Version: ref})
+	getSourceMu.Lock()
+	src, err := syft.GetSource(ctx, img, srcCfg)
+	getSourceMu.Unlock()
+	if err != nil {
+		return dependency.Scan{}, fmt.Errorf("unable to get image %q for ref %q: %w", img, ref, err)
+	}
+	bus.LinkSBOMSource(ref, string(src.ID()))
+	defer func() {
+		if cerr := src.Close(); cerr != nil {
+			log.WithFields("error", cerr).Trace("unable to close syft image source")
+		}
+	}()
+
+	sb, err := syft.CreateSBOM(ctx, src, syft.DefaultCreateSBOMConfig())
+	if err != nil {
+		return dependency.Scan{}, fmt.Errorf("unable to catalog image %q: %w", img, err)
+	}
+	log.WithFields("ref", ref, "image", img, "packages", sb.Artifacts.Packages.PackageCount()).Debug("cataloged image")
+
+	// an image has no manifests to re-read, so classification comes from the
+	// dependency graph syft recorded while cataloging it.
+	return scanSBOM(ctx, sb, ref, s.provider, s.exports[ref])
+}
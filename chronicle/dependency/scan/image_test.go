@@ -0,0 +1,74 @@
+package scan
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImageInput_Image(t *testing.T) {
+	in := ImageInput{
+		Images:   map[string]string{"HEAD": "oci-dir:build/image"},
+		Template: "ghcr.io/org/app:{version}",
+		Versions: map[string]string{"v1.2.0": "1.2.0"},
+	}
+
+	got, err := in.Image("HEAD")
+	require.NoError(t, err)
+	require.Equal(t, "oci-dir:build/image", got, "an explicit image wins over the template")
+
+	got, err = in.Image("v1.2.0")
+	require.NoError(t, err)
+	require.Equal(t, "ghcr.io/org/app:1.2.0", got)
+
+	_, err = in.Image("0123abc")
+	require.ErrorContains(t, err, `ref "0123abc" has no release version`)
+
+	got, err = ImageInput{Template: "images/{ref}.tar"}.Image("v1.2.0")
+	require.NoError(t, err)
+	require.Equal(t, "images/v1.2.0.tar", got)
+
+	_, err = ImageInput{}.Image("v1.2.0")
+	require.ErrorContains(t, err, `no image configured for ref "v1.2.0"`)
+}
+
+// apkImage writes a single-layer alpine-like image with one installed apk package to a
+// docker archive, so the image scan can run offline.
+func apkImage(t *testing.T, version string) string {
+	t.Helper()
+	img, err := crane.Image(map[string][]byte{
+		"etc/os-release":       []byte("ID=alpine\nVERSION_ID=3.20.0\n"),
+		"lib/apk/db/installed": []byte("P:musl\nV:" + version + "\nA:x86_64\nL:MIT\n\n"),
+	})
+	require.NoError(t, err)
+	tag, err := name.NewTag("example.com/app:test")
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "image.tar")
+	require.NoError(t, tarball.WriteToFile(path, tag, img))
+	return path
+}
+
+func TestImageScanner_Scan(t *testing.T) {
+	s := NewImageScanner(ImageInput{Images: map[string]string{
+		"v1": apkImage(t, "1.2.4-r1"),
+		"v2": apkImage(t, "1.2.5-r0"),
+	}}, "test", nil, nil)
+
+	for ref, want := range map[string]string{"v1": "1.2.4-r1", "v2": "1.2.5-r0"} {
+		snap, err := s.Scan(context.Background(), ref)
+		require.NoError(t, err)
+		require.Len(t, snap.Packages, 1)
+		require.Equal(t, "musl", snap.Packages[0].Name)
+		require.Equal(t, "apk", snap.Packages[0].Type)
+		require.Equal(t, want, snap.Packages[0].Version)
+		require.Equal(t, []string{"MIT"}, snap.Packages[0].Licenses)
+	}
+
+	_, err := s.Scan(context.Background(), "v3")
+	require.ErrorContains(t, err, "no image configured")
+}
@@ -0,0 +1,214 @@
+package scan
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+
+	"github.com/anchore/chronicle/chronicle/dependency"
+	"github.com/anchore/chronicle/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+)
+
+// classification is how the project depends on one cataloged package.
+type classification struct {
+	relationship dependency.Relationship
+	scope        dependency.Scope
+}
+
+// classifier works out whether each cataloged package is a direct or transitive dependency, and
+// whether it is needed at runtime or only for development. syft's package model keeps neither
+// directly, so it is pieced together from what the catalog does carry:
+//
+//   - the manifest each package was cataloged from, re-read from the scanned tree: a go.mod
+//     require without `// indirect` is direct, and a lockfile entry named in the neighbouring
+//     package.json (dependencies vs devDependencies) is direct with that scope. Anything else
+//     those manifests resolved is transitive.
+//   - syft's dependency-of relationships, for ecosystems whose catalogers record the project
+//     itself (e.g. a pom.xml's own artifact): a package the project depends on is direct, and
+//     one only reachable through another dependency is transitive.
+//   - package metadata that records a scope (a Maven dependency's <scope>test</scope>).
+//
+// Packages none of these speak to are left unclassified, which renders like a direct change.
+type classifier struct {
+	dir       string                                 // the scanned tree, which location paths are relative to
+	goMods    map[string]*goModRequires              // go.mod path -> its requirements
+	npmScopes map[string]map[string]dependency.Scope // package.json path -> direct dependency name -> scope
+}
+
+// classify returns the classification of every package in sb that could be classified, keyed by
+// package ID. dir is the tree sb was cataloged from; "" when there is none (an SBOM read from a
+// file), which leaves only the dependency graph and package metadata to go on.
+func classify(dir string, sb *sbom.SBOM) map[artifact.ID]classification {
+	c := &classifier{
+		dir:       dir,
+		goMods:    make(map[string]*goModRequires),
+		npmScopes: make(map[string]map[string]dependency.Scope),
+	}
+	graph := dependencyGraph(sb)
+
+	out := make(map[artifact.ID]classification)
+	for p := range sb.Artifacts.Packages.Enumerate() {
+		cl, ok := c.fromManifest(p)
+		if !ok {
+			cl.relationship = graph[p.ID()]
+		}
+		if cl.scope == "" {
+			cl.scope = metadataScope(p)
+		}
+		if cl.relationship != "" || cl.scope != "" {
+			out[p.ID()] = cl
+		}
+	}
+	return out
+}
+
+// fromManifest classifies a package by the manifest it was cataloged from, reporting false when
+// the package did not come from a manifest this knows how to read.
+func (c *classifier) fromManifest(p pkg.Package) (classification, bool) {
+	if c.dir == "" {
+		return classification{}, false
+	}
+	for _, loc := range p.Locations.ToSlice() {
+		switch base := path.Base(loc.RealPath); {
+		case p.Type == pkg.GoModulePkg && base == "go.mod":
+			mod := c.goMod(loc.RealPath)
+			if mod == nil || p.Name == mod.module {
+				// the main module is the project itself, not a dependency of it.
+				continue
+			}
+			if indirect, ok := mod.indirect[p.Name]; ok && !indirect {
+				return classification{relationship: dependency.Direct}, true
+			}
+			return classification{relationship: dependency.Transitive}, true
+		case p.Type == pkg.NpmPkg && (base == "package-lock.json" || base == "yarn.lock" || base == "pnpm-lock.yaml"):
+			declared := c.packageJSON(path.Join(path.Dir(loc.RealPath), "package.json"))
+			if declared == nil {
+				continue
+			}
+			if scope, ok := declared[p.Name]; ok {
+				return classification{relationship: dependency.Direct, scope: scope}, true
+			}
+			return classification{relationship: dependency.Transitive}, true
+		}
+	}
+	return classification{}, false
+}
+
+// goModRequires is a go.mod's module path and its requirements, mapped to whether each is marked
+// `// indirect`.
+type goModRequires struct {
+	module   string
+	indirect map[string]bool
+}
+
+// goMod returns the requirements of a go.mod. Parsed once per file; nil when the file can't be
+// read or parsed.
+func (c *classifier) goMod(p string) *goModRequires {
+	if requires, ok := c.goMods[p]; ok {
+		return requires
+	}
+	var requires *goModRequires
+	if content, err := os.ReadFile(filepath.Join(c.dir, filepath.FromSlash(p))); err != nil {
+		log.WithFields("error", err, "file", p).Debug("unable to read go.mod for dependency classification")
+	} else if f, err := modfile.ParseLax(p, content, nil); err != nil {
+		log.WithFields("error", err, "file", p).Debug("unable to parse go.mod for dependency classification")
+	} else {
+		requires = &goModRequires{indirect: make(map[string]bool, len(f.Require))}
+		if f.Module != nil {
+			requires.module = f.Module.Mod.Path
+		}
+		for _, r := range f.Require {
+			requires.indirect[r.Mod.Path] = r.Indirect
+		}
+	}
+	c.goMods[p] = requires
+	return requires
+}
+
+// packageJSON returns the dependencies a package.json declares, mapped to their scope. Parsed
+// once per file; nil when the file is missing (a lockfile with no manifest beside it) or invalid.
+func (c *classifier) packageJSON(p string) map[string]dependency.Scope {
+	if declared, ok := c.npmScopes[p]; ok {
+		return declared
+	}
+	var declared map[string]dependency.Scope
+	var manifest struct {
+		Dependencies         map[string]string `json:"dependencies"`
+		OptionalDependencies map[string]string `json:"optionalDependencies"`
+		PeerDependencies     map[string]string `json:"peerDependencies"`
+		DevDependencies      map[string]string `json:"devDependencies"`
+	}
+	if content, err := os.ReadFile(filepath.Join(c.dir, filepath.FromSlash(p))); err != nil {
+		log.WithFields("error", err, "file", p).Debug("unable to read package.json for dependency classification")
+	} else if err := json.Unmarshal(content, &manifest); err != nil {
+		log.WithFields("error", err, "file", p).Debug("unable to parse package.json for dependency classification")
+	} else {
+		declared = make(map[string]dependency.Scope)
+		// a package listed both as a dev and a runtime dependency is needed at runtime.
+		for name := range manifest.DevDependencies {
+			declared[name] = dependency.ScopeDev
+		}
+		for _, deps := range []map[string]string{manifest.Dependencies, manifest.OptionalDependencies, manifest.PeerDependencies} {
+			for name := range deps {
+				declared[name] = dependency.ScopeRuntime
+			}
+		}
+	}
+	c.npmScopes[p] = declared
+	return declared
+}
+
+// dependencyGraph classifies packages by syft's dependency-of relationships. The roots are the
+// packages others depend on that depend on nothing themselves — the project's own entries, such
+// as a pom.xml's artifact. A package with an edge to a root is direct; any other package in the
+// graph is transitive. Packages with no dependency-of edges are absent.
+func dependencyGraph(sb *sbom.SBOM) map[artifact.ID]dependency.Relationship {
+	dependsOn := make(map[artifact.ID][]artifact.ID) // dependency -> the packages that depend on it
+	for _, r := range sb.Relationships {
+		if r.Type != artifact.DependencyOfRelationship {
+			continue
+		}
+		from, fromOK := r.From.(pkg.Package)
+		to, toOK := r.To.(pkg.Package)
+		if !fromOK || !toOK || from.ID() == to.ID() {
+			continue
+		}
+		dependsOn[from.ID()] = append(dependsOn[from.ID()], to.ID())
+	}
+
+	out := make(map[artifact.ID]dependency.Relationship)
+	for dep, parents := range dependsOn {
+		out[dep] = dependency.Transitive
+		for _, parent := range parents {
+			if _, isDependency := dependsOn[parent]; !isDependency {
+				out[dep] = dependency.Direct
+				break
+			}
+		}
+	}
+	return out
+}
+
+// metadataScope reads a scope the cataloger recorded on the package itself. Only Maven records
+// one; a test-scoped dependency is development-only and every other scope is needed to build or
+// run the project.
+func metadataScope(p pkg.Package) dependency.Scope {
+	if m, ok := p.Metadata.(pkg.JavaArchive); ok && m.PomProperties != nil {
+		switch strings.ToLower(strings.TrimSpace(m.PomProperties.Scope)) {
+		case "":
+			return ""
+		case "test":
+			return dependency.ScopeDev
+		default:
+			return dependency.ScopeRuntime
+		}
+	}
+	return ""
+}
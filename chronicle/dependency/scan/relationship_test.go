@@ -0,0 +1,147 @@
+package scan
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/chronicle/chronicle/dependency"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/file"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+)
+
+func catalogedPackage(name string, typ pkg.Type, location string, metadata any) pkg.Package {
+	p := pkg.Package{
+		Name:      name,
+		Version:   "1.0.0",
+		Type:      typ,
+		Locations: file.NewLocationSet(file.NewLocation(location)),
+		Metadata:  metadata,
+	}
+	p.SetID()
+	return p
+}
+
+// classifiedByName flattens classify's result for comparison.
+func classifiedByName(sb *sbom.SBOM, classes map[artifact.ID]classification) map[string]classification {
+	out := make(map[string]classification)
+	for p := range sb.Artifacts.Packages.Enumerate() {
+		if cl, ok := classes[p.ID()]; ok {
+			out[p.Name] = cl
+		}
+	}
+	return out
+}
+
+func TestClassify_Manifests(t *testing.T) {
+	root := t.TempDir()
+	writeManifest(t, filepath.Join(root, "go.mod"), `module example.com/app
+
+go 1.22
+
+require (
+	github.com/spf13/cobra v1.8.0
+	golang.org/x/sys v0.20.0 // indirect
+)`)
+	writeManifest(t, filepath.Join(root, "web", "package.json"), `{
+  "dependencies": {"react": "^18.0.0"},
+  "devDependencies": {"jest": "^29.0.0", "react": "^18.0.0"},
+  "peerDependencies": {"react-dom": "^18.0.0"}
+}`)
+
+	sb := &sbom.SBOM{Artifacts: sbom.Artifacts{Packages: pkg.NewCollection(
+		catalogedPackage("example.com/app", pkg.GoModulePkg, "/go.mod", nil),
+		catalogedPackage("github.com/spf13/cobra", pkg.GoModulePkg, "/go.mod", nil),
+		catalogedPackage("golang.org/x/sys", pkg.GoModulePkg, "/go.mod", nil),
+		catalogedPackage("github.com/inconshreveable/mousetrap", pkg.GoModulePkg, "/go.mod", nil),
+		catalogedPackage("react", pkg.NpmPkg, "/web/package-lock.json", nil),
+		catalogedPackage("react-dom", pkg.NpmPkg, "/web/package-lock.json", nil),
+		catalogedPackage("jest", pkg.NpmPkg, "/web/package-lock.json", nil),
+		catalogedPackage("loose-envify", pkg.NpmPkg, "/web/package-lock.json", nil),
+		// a lockfile with no package.json beside it can't be classified.
+		catalogedPackage("left-pad", pkg.NpmPkg, "/vendor/yarn.lock", nil),
+	)}}
+
+	want := map[string]classification{
+		"github.com/spf13/cobra":               {relationship: dependency.Direct},
+		"golang.org/x/sys":                     {relationship: dependency.Transitive},
+		"github.com/inconshreveable/mousetrap": {relationship: dependency.Transitive},
+		"react":                                {relationship: dependency.Direct, scope: dependency.ScopeRuntime},
+		"react-dom":                            {relationship: dependency.Direct, scope: dependency.ScopeRuntime},
+		"jest":                                 {relationship: dependency.Direct, scope: dependency.ScopeDev},
+		"loose-envify":                         {relationship: dependency.Transitive},
+	}
+	got := classifiedByName(sb, classify(root, sb))
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(classification{})); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestClassify_DependencyGraph(t *testing.T) {
+	scoped := func(scope string) pkg.JavaArchive {
+		return pkg.JavaArchive{PomProperties: &pkg.JavaPomProperties{Scope: scope}}
+	}
+	app := catalogedPackage("app", pkg.JavaPkg, "/pom.xml", pkg.JavaArchive{})
+	guava := catalogedPackage("guava", pkg.JavaPkg, "/pom.xml", scoped("compile"))
+	failureaccess := catalogedPackage("failureaccess", pkg.JavaPkg, "/pom.xml", scoped(""))
+	junit := catalogedPackage("junit", pkg.JavaPkg, "/pom.xml", scoped("test"))
+	unrelated := catalogedPackage("unrelated", pkg.JavaPkg, "/lib/unrelated.jar", nil)
+
+	dependencyOf := func(from, to pkg.Package) artifact.Relationship {
+		return artifact.Relationship{From: from, To: to, Type: artifact.DependencyOfRelationship}
+	}
+	sb := &sbom.SBOM{
+		Artifacts: sbom.Artifacts{Packages: pkg.NewCollection(app, guava, failureaccess, junit, unrelated)},
+		Relationships: []artifact.Relationship{
+			dependencyOf(guava, app),
+			dependencyOf(junit, app),
+			dependencyOf(failureaccess, guava),
+			{From: unrelated, To: app, Type: artifact.ContainsRelationship},
+		},
+	}
+
+	want := map[string]classification{
+		"guava":         {relationship: dependency.Direct, scope: dependency.ScopeRuntime},
+		"junit":         {relationship: dependency.Direct, scope: dependency.ScopeDev},
+		"failureaccess": {relationship: dependency.Transitive},
+	}
+	got := classifiedByName(sb, classify(t.TempDir(), sb))
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(classification{})); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// TestScanner_Scan_Relationships runs the npm lockfile cataloger for real, so the manifest lookup
+// is checked against the location paths syft actually reports.
+func TestScanner_Scan_Relationships(t *testing.T) {
+	root := t.TempDir()
+	writeManifest(t, filepath.Join(root, "package.json"), `{"name": "app", "version": "1.0.0", "dependencies": {"react": "^18.3.1"}}`)
+	writeManifest(t, filepath.Join(root, "package-lock.json"), `{
+  "name": "app",
+  "version": "1.0.0",
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"name": "app", "version": "1.0.0", "dependencies": {"react": "^18.3.1"}},
+    "node_modules/js-tokens": {"version": "4.0.0", "resolved": "https://registry.npmjs.org/js-tokens/-/js-tokens-4.0.0.tgz"},
+    "node_modules/loose-envify": {"version": "1.4.0", "resolved": "https://registry.npmjs.org/loose-envify/-/loose-envify-1.4.0.tgz", "dependencies": {"js-tokens": "^3.0.0 || ^4.0.0"}},
+    "node_modules/react": {"version": "18.3.1", "resolved": "https://registry.npmjs.org/react/-/react-18.3.1.tgz", "dependencies": {"loose-envify": "^1.1.0"}}
+  }
+}`)
+
+	s := &scanner{sourceName: "test", ecosystems: []string{"javascript"}, recursive: true}
+	snap, err := s.scanDir(context.Background(), root, "v0")
+	require.NoError(t, err)
+
+	got := make(map[string]dependency.Relationship)
+	for _, p := range snap.Packages {
+		got[p.Name] = p.Relationship
+	}
+	require.Equal(t, dependency.Direct, got["react"])
+	require.Equal(t, dependency.Transitive, got["loose-envify"])
+	require.Equal(t, dependency.Transitive, got["js-tokens"])
+}
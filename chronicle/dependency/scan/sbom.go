@@ -0,0 +1,80 @@
+package scan
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/anchore/syft/syft/format/cyclonedxjson"
+	"github.com/anchore/syft/syft/format/spdxjson"
+	"github.com/anchore/syft/syft/sbom"
+)
+
+// SBOMExport asks the scanner to write the SBOM it catalogs for a ref, so a
+// release pipeline can publish it without running syft a second time.
+type SBOMExport struct {
+	Format string // one of SBOMFormats()
+	Path   string
+}
+
+// SBOMFormats lists the formats an SBOMExport can be written in, named after
+// syft's own format IDs.
+func SBOMFormats() []string {
+	return []string{string(spdxjson.ID), string(cyclonedxjson.ID)}
+}
+
+// ParseSBOMExport parses a FORMAT=PATH value (e.g. "spdx-json=sbom.spdx.json").
+func ParseSBOMExport(value string) (SBOMExport, error) {
+	format, path, ok := strings.Cut(value, "=")
+	format, path = strings.TrimSpace(format), strings.TrimSpace(path)
+	if !ok || path == "" {
+		return SBOMExport{}, fmt.Errorf("invalid sbom output %q: expected FORMAT=PATH (formats: %s)", value, strings.Join(SBOMFormats(), ", "))
+	}
+	if _, err := formatEncoder(format); err != nil {
+		return SBOMExport{}, err
+	}
+	return SBOMExport{Format: format, Path: path}, nil
+}
+
+func formatEncoder(format string) (sbom.FormatEncoder, error) {
+	switch strings.ToLower(format) {
+	case string(spdxjson.ID):
+		return spdxjson.NewFormatEncoderWithConfig(spdxjson.DefaultEncoderConfig())
+	case string(cyclonedxjson.ID):
+		return cyclonedxjson.NewFormatEncoderWithConfig(cyclonedxjson.DefaultEncoderConfig())
+	}
+	return nil, fmt.Errorf("unsupported sbom format %q (formats: %s)", format, strings.Join(SBOMFormats(), ", "))
+}
+
+// write encodes sb to the export's path. The document goes to a temp file in
+// the destination directory first and is renamed into place, so a failed encode
+// never leaves a truncated SBOM where a pipeline would pick it up.
+func (e SBOMExport) write(sb *sbom.SBOM) error {
+	enc, err := formatEncoder(e.Format)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(e.Path), "."+filepath.Base(e.Path)+".*")
+	if err != nil {
+		return fmt.Errorf("unable to create sbom file for %s: %w", e.Path, err)
+	}
+	tmp := f.Name()
+	defer os.Remove(tmp) // no-op once renamed
+
+	if err := enc.Encode(f, *sb); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("unable to encode %s sbom: %w", e.Format, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("unable to write sbom file %s: %w", e.Path, err)
+	}
+	if err := os.Chmod(tmp, 0o644); err != nil {
+		return fmt.Errorf("unable to write sbom file %s: %w", e.Path, err)
+	}
+	if err := os.Rename(tmp, e.Path); err != nil {
+		return fmt.Errorf("unable to write sbom file %s: %w", e.Path, err)
+	}
+	return nil
+}
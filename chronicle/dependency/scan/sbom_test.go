@@ -0,0 +1,76 @@
+package scan
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSBOMExport(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    SBOMExport
+		wantErr string
+	}{
+		{value: "spdx-json=sbom.spdx.json", want: SBOMExport{Format: "spdx-json", Path: "sbom.spdx.json"}},
+		{value: "cyclonedx-json = out/sbom.cdx.json", want: SBOMExport{Format: "cyclonedx-json", Path: "out/sbom.cdx.json"}},
+		{value: "spdx-json", wantErr: "expected FORMAT=PATH"},
+		{value: "spdx-json=", wantErr: "expected FORMAT=PATH"},
+		{value: "syft-table=sbom.txt", wantErr: "unsupported sbom format"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseSBOMExport(tt.value)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestScanner_SBOMExports catalogs a fixture tree and checks each requested
+// document is written for the scanned ref only, and that an unwritable
+// destination fails the ref.
+func TestScanner_SBOMExports(t *testing.T) {
+	root := t.TempDir()
+	writeManifest(t, filepath.Join(root, "requirements.txt"), "rootdep==1.0.0")
+	out := t.TempDir()
+
+	spdx := filepath.Join(out, "until.spdx.json")
+	cdx := filepath.Join(out, "until.cdx.json")
+	other := filepath.Join(out, "since.spdx.json")
+	s := &scanner{sourceName: "test", ecosystems: []string{"python"}, exports: map[string][]SBOMExport{
+		"v1": {{Format: "spdx-json", Path: spdx}, {Format: "cyclonedx-json", Path: cdx}},
+		"v0": {{Format: "spdx-json", Path: other}},
+	}}
+	_, err := s.scanDir(context.Background(), root, "v1")
+	require.NoError(t, err)
+
+	var doc map[string]any
+	raw, err := os.ReadFile(spdx)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(raw, &doc))
+	require.Contains(t, doc, "spdxVersion")
+	require.Contains(t, string(raw), "rootdep")
+
+	raw, err = os.ReadFile(cdx)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(raw, &doc))
+	require.Equal(t, "CycloneDX", doc["bomFormat"])
+
+	require.NoFileExists(t, other, "only the scanned ref's exports are written")
+	entries, err := os.ReadDir(out)
+	require.NoError(t, err)
+	require.Len(t, entries, 2, "no temp files are left behind")
+
+	s.exports = map[string][]SBOMExport{"v1": {{Format: "spdx-json", Path: filepath.Join(out, "missing", "sbom.json")}}}
+	_, err = s.scanDir(context.Background(), root, "v1")
+	require.ErrorContains(t, err, "unable to create sbom file")
+}
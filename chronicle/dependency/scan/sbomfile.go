@@ -0,0 +1,110 @@
+package scan
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/anchore/chronicle/chronicle/dependency"
+	"github.com/anchore/chronicle/internal/log"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/syft/syft/format"
+	"github.com/anchore/syft/syft/sbom"
+)
+
+// SBOMInput names the SBOM file to read for each ref, for builds that already
+// produce one per release artifact. A ref's explicit path wins; otherwise the
+// template is expanded for it.
+type SBOMInput struct {
+	// Paths maps a ref to its SBOM file.
+	Paths map[string]string
+	// Template is a path with a "{ref}" placeholder, e.g. "sboms/{ref}.spdx.json".
+	Template string
+}
+
+// Path resolves the SBOM file for ref, or returns an error when neither an
+// explicit path nor a template covers it.
+func (in SBOMInput) Path(ref string) (string, error) {
+	if p := in.Paths[ref]; p != "" {
+		return p, nil
+	}
+	if in.Template != "" {
+		return strings.ReplaceAll(in.Template, "{ref}", ref), nil
+	}
+	return "", fmt.Errorf("no sbom input configured for ref %q", ref)
+}
+
+// sbomFileScanner is the dependency.Scanner for bring-your-own SBOMs: instead of
+// materializing and cataloging the git tree it decodes the SBOM the build
+// already produced for the ref (SPDX, CycloneDX, or syft JSON). Everything after
+// that is the source scanner's: the same package mapping, the same grype match,
+// and the same SBOM exports, so ComputeDiff and the encoders can't tell the
+// difference.
+type sbomFileScanner struct {
+	input    SBOMInput
+	provider vulnerability.Provider // nil means packages-only
+	exports  map[string][]SBOMExport
+}
+
+// NewSBOMFileScanner builds a Scanner that reads each ref's packages from the
+// SBOM file input resolves for it. db and exports behave as Options.DB and
+// Exports do for NewScanner.
+func NewSBOMFileScanner(input SBOMInput, db *DB, exports map[string][]SBOMExport) dependency.Scanner {
+	s := &sbomFileScanner{input: input, exports: exports}
+	if db != nil {
+		s.provider = db.provider
+	}
+	return s
+}
+
+// Scan decodes ref's SBOM and, when a DB was supplied, matches it. A missing or
+// undecodable file is fatal for the ref, like a tree that can't be cataloged; a
+// match failure degrades to packages-only.
+func (s *sbomFileScanner) Scan(ctx context.Context, ref string) (dependency.Scan, error) {
+	path, err := s.input.Path(ref)
+	if err != nil {
+		return dependency.Scan{}, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return dependency.Scan{}, fmt.Errorf("unable to read sbom for ref %q: %w", ref, err)
+	}
+	defer f.Close()
+
+	sb, formatID, _, err := format.Decode(f)
+	if err != nil {
+		return dependency.Scan{}, fmt.Errorf("unable to decode sbom %s: %w", path, err)
+	}
+	if sb == nil {
+		return dependency.Scan{}, fmt.Errorf("unable to decode sbom %s: unrecognized format", path)
+	}
+	log.WithFields("ref", ref, "file", path, "format", formatID).Debug("loaded sbom input")
+
+	// there is no tree to re-read manifests from, so only the SBOM's own
+	// dependency graph and package metadata can classify its packages.
+	return scanSBOM(ctx, sb, ref, s.provider, s.exports[ref])
+}
+
+// scanSBOM finishes a scan from an SBOM that did not come from a source tree
+// (a decoded file, or a cataloged image): it writes the requested exports,
+// maps and classifies the packages, and matches them when a provider is set.
+func scanSBOM(ctx context.Context, sb *sbom.SBOM, ref string, provider vulnerability.Provider, exports []SBOMExport) (dependency.Scan, error) {
+	for _, e := range exports {
+		if err := e.write(sb); err != nil {
+			return dependency.Scan{}, err
+		}
+	}
+
+	snap := dependency.Scan{Packages: mapPackages(sb, classify("", sb), nil)}
+	if provider == nil {
+		return snap, nil
+	}
+	vulns, err := matchSBOM(ctx, provider, sb, snap.Packages)
+	if err != nil {
+		log.WithFields("error", err, "ref", ref).Warn("unable to match vulnerabilities; skipping")
+		return snap, nil
+	}
+	snap.Vulns = vulns
+	return snap, nil
+}
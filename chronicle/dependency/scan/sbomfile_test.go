@@ -0,0 +1,72 @@
+package scan
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/chronicle/chronicle/dependency"
+)
+
+func TestSBOMInput_Path(t *testing.T) {
+	in := SBOMInput{Paths: map[string]string{"HEAD": "build/sbom.cdx.json"}, Template: "sboms/{ref}.spdx.json"}
+
+	got, err := in.Path("HEAD")
+	require.NoError(t, err)
+	require.Equal(t, "build/sbom.cdx.json", got, "an explicit path wins over the template")
+
+	got, err = in.Path("v1.2.0")
+	require.NoError(t, err)
+	require.Equal(t, "sboms/v1.2.0.spdx.json", got)
+
+	_, err = SBOMInput{}.Path("v1.2.0")
+	require.ErrorContains(t, err, `no sbom input configured for ref "v1.2.0"`)
+}
+
+// TestSBOMFileScanner_Scan writes real SPDX and CycloneDX documents through the source scanner's
+// exports, then reads them back as the inputs, so the round trip covers both formats.
+func TestSBOMFileScanner_Scan(t *testing.T) {
+	out := t.TempDir()
+	for ref, version := range map[string]string{"v1": "1.0.0", "v2": "2.0.0"} {
+		root := t.TempDir()
+		writeManifest(t, filepath.Join(root, "requirements.txt"), "rootdep=="+version)
+		s := &scanner{sourceName: "test", ecosystems: []string{"python"}, exports: map[string][]SBOMExport{ref: {
+			{Format: "spdx-json", Path: filepath.Join(out, ref+".spdx.json")},
+			{Format: "cyclonedx-json", Path: filepath.Join(out, ref+".cdx.json")},
+		}}}
+		_, err := s.scanDir(context.Background(), root, ref)
+		require.NoError(t, err)
+	}
+
+	s := NewSBOMFileScanner(SBOMInput{
+		Paths:    map[string]string{"v2": filepath.Join(out, "v2.cdx.json")},
+		Template: filepath.Join(out, "{ref}.spdx.json"),
+	}, nil, nil)
+
+	versions := func(snap dependency.Scan) map[string]string {
+		got := make(map[string]string)
+		for _, p := range snap.Packages {
+			got[p.Name] = p.Version
+		}
+		return got
+	}
+	since, err := s.Scan(context.Background(), "v1")
+	require.NoError(t, err)
+	require.Equal(t, "1.0.0", versions(since)["rootdep"])
+	require.Nil(t, since.Vulns, "no DB means packages only")
+
+	until, err := s.Scan(context.Background(), "v2")
+	require.NoError(t, err)
+	require.Equal(t, "2.0.0", versions(until)["rootdep"])
+
+	_, err = s.Scan(context.Background(), "v3")
+	require.ErrorContains(t, err, "unable to read sbom")
+
+	garbage := filepath.Join(out, "v4.spdx.json")
+	require.NoError(t, os.WriteFile(garbage, []byte("not an sbom"), 0o600))
+	_, err = s.Scan(context.Background(), "v4")
+	require.ErrorContains(t, err, "unable to decode sbom")
+}
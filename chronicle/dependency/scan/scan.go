@@ -11,18 +11,25 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/anchore/chronicle/chronicle/dependency"
 	"github.com/anchore/chronicle/chronicle/dependency/source"
+	"github.com/anchore/chronicle/chronicle/dependency/workspace"
 	"github.com/anchore/chronicle/internal/bus"
 	"github.com/anchore/chronicle/internal/log"
 	"github.com/anchore/grype/grype"
+	"github.com/anchore/grype/grype/distro"
 	grypeMatcher "github.com/anchore/grype/grype/matcher"
 	grypePkg "github.com/anchore/grype/grype/pkg"
 	"github.com/anchore/grype/grype/vulnerability"
 	"github.com/anchore/syft/syft"
+	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/cataloging"
+	"github.com/anchore/syft/syft/pkg"
 	"github.com/anchore/syft/syft/sbom"
 	syftSource "github.com/anchore/syft/syft/source"
 )
@@ -48,9 +55,23 @@ type scanner struct {
 	excludePaths []string      // syft exclude patterns (each must start with ./, */, or **/)
 	recursive    bool          // when false, scan only the root dir (top-level subdirs are pruned)
 
+	// workspaces are the workspace members declared at each ref; a ref with
+	// an entry scans its root and exactly those members, whatever recursive
+	// says.
+	workspaces map[string][]string
+
 	// provider is the loaded grype vulnerability DB to match against; nil means
 	// packages-only (no matching).
 	provider vulnerability.Provider
+
+	// exports are the SBOMs to write, keyed by the ref they were requested for.
+	exports map[string][]SBOMExport
+
+	// cache serves catalogs and matches already computed for the same content;
+	// nil disables caching. dbBuilt keys the cached matches to the DB they
+	// came from (zero skips caching them).
+	cache   *Cache
+	dbBuilt time.Time
 }
 
 // getSourceMu serializes syft.GetSource across concurrent ref scans. GetSource
@@ -59,48 +80,128 @@ type scanner struct {
 // cataloging (CreateSBOM) still runs in parallel.
 var getSourceMu sync.Mutex
 
-// NewScanner builds a Scanner. target materializes each ref into a directory to
-// catalog; sourceName names the project so syft derives a stable artifact ID per
-// ref. ecosystems are syft cataloger selection expressions that scope cataloging
-// (e.g. ["language"] or ["go"]); empty means syft's default directory catalogers.
-// excludePaths are syft exclude patterns that prune directories from the scan
-// (each must start with ./, */, or **/); empty means scan everything. recursive
-// controls scan depth: when false, only the root dir is cataloged (every
-// top-level subdir is pruned); when true, the whole tree is scanned. db is the
-// loaded vulnerability DB to match against (from LoadDB); nil means scan packages
-// only.
-func NewScanner(target source.Target, sourceName string, ecosystems, excludePaths []string, recursive bool, db *DB) dependency.Scanner {
+// Options configures a source scanner. The zero value catalogs every ref's root
+// directory with syft's default directory catalogers, packages only.
+type Options struct {
+	// SourceName names the project, so syft derives a stable artifact ID per
+	// ref whatever directory the ref is materialized into.
+	SourceName string
+
+	// Ecosystems are syft cataloger selection expressions that scope
+	// cataloging (e.g. ["language"] or ["go"]); empty means syft's default
+	// directory catalogers.
+	Ecosystems []string
+
+	// Exclude are syft exclude patterns that prune directories from the scan,
+	// each starting with ./, */, or **/; empty scans everything.
+	Exclude []string
+
+	// Recursive scans the whole tree; when false only the root directory is
+	// cataloged and every top-level subdirectory is pruned.
+	Recursive bool
+
+	// Workspaces maps a ref to the workspace member directories it declares
+	// (see workspace.Discover). A ref present in it is scanned at its root and
+	// those members only, each package attributed to the member it was found
+	// in, whatever Recursive says; nil scans every ref by Recursive.
+	Workspaces map[string][]string
+
+	// DB is the loaded vulnerability DB to match against (from LoadDB); nil
+	// scans packages only.
+	DB *DB
+
+	// Exports maps a ref to the SBOM documents to write once it is cataloged;
+	// nil writes none.
+	Exports map[string][]SBOMExport
+
+	// Cache serves refs whose content was scanned before; it needs a target
+	// that can hash a ref's tree, like the git target. nil disables it.
+	Cache *Cache
+}
+
+// NewScanner builds a Scanner that materializes each ref through target and
+// catalogs (and, with a DB, matches) it as opts describes.
+func NewScanner(target source.Target, opts Options) dependency.Scanner {
 	s := &scanner{
 		target:       target,
-		sourceName:   sourceName,
-		ecosystems:   ecosystems,
-		excludePaths: excludePaths,
-		recursive:    recursive,
+		sourceName:   opts.SourceName,
+		ecosystems:   opts.Ecosystems,
+		excludePaths: opts.Exclude,
+		recursive:    opts.Recursive,
+		workspaces:   opts.Workspaces,
+		exports:      opts.Exports,
+		cache:        opts.Cache,
 	}
-	if db != nil {
-		s.provider = db.provider
+	if opts.DB != nil {
+		s.provider = opts.DB.provider
+		s.dbBuilt = opts.DB.built
 	}
 	return s
 }
 
 // Scan materializes ref, catalogs it, and — when the scanner is annotating —
 // waits for the shared DB and matches vulnerabilities, returning the per-ref
-// Scan. A materialize or catalog failure is fatal (returned as an error); a
-// DB-load or match failure is non-fatal — logged here and degraded to a
-// packages-only Scan (nil Vulns), which the caller surfaces as a failed vuln
-// branch.
+// Scan. A ref whose content is already cached skips materializing and
+// cataloging (and matching, when the DB is unchanged). A materialize or catalog
+// failure is fatal (returned as an error); a DB-load or match failure is
+// non-fatal — logged here and degraded to a packages-only Scan (nil Vulns),
+// which the caller surfaces as a failed vuln branch.
 func (s *scanner) Scan(ctx context.Context, ref string) (dependency.Scan, error) {
-	dir, cleanup, err := s.target.Materialize(ctx, ref)
-	if err != nil {
-		return dependency.Scan{}, fmt.Errorf("unable to materialize ref %q: %w", ref, err)
+	key := s.cacheKey(ref)
+	cat, cached := s.cache.loadCatalog(key)
+	if cached {
+		log.WithFields("ref", ref).Debug("using cached dependency catalog")
+	} else {
+		dir, cleanup, err := s.materialize(ctx, ref)
+		if err != nil {
+			return dependency.Scan{}, fmt.Errorf("unable to materialize ref %q: %w", ref, err)
+		}
+		defer func() {
+			if cerr := cleanup(); cerr != nil {
+				log.WithFields("error", cerr).Trace("unable to clean up materialized dependency source")
+			}
+		}()
+		if cat, err = s.catalog(ctx, dir, ref); err != nil {
+			return dependency.Scan{}, err
+		}
+		s.cache.storeCatalog(key, cat)
 	}
-	defer func() {
-		if cerr := cleanup(); cerr != nil {
-			log.WithFields("error", cerr).Trace("unable to clean up materialized dependency source")
+
+	return s.finish(ctx, cat, ref, key)
+}
+
+// materialize writes ref to disk, only as much of it as the scan's scoping
+// reads when the target can prune: syft would skip the rest anyway.
+func (s *scanner) materialize(ctx context.Context, ref string) (string, func() error, error) {
+	if t, ok := s.target.(source.SelectiveTarget); ok {
+		sel := source.Selection{RootOnly: !s.recursive, Exclude: s.excludePaths}
+		if members, ok := s.workspaces[ref]; ok {
+			sel.Members = members
+			if sel.Members == nil {
+				sel.Members = []string{}
+			}
 		}
-	}()
+		return t.MaterializeSelection(ctx, ref, sel)
+	}
+	return s.target.Materialize(ctx, ref)
+}
 
-	return s.scanDir(ctx, dir, ref)
+// cacheKey returns the catalog cache key for ref, or "" when there is no cache
+// or the ref's content can't be named without materializing it.
+func (s *scanner) cacheKey(ref string) string {
+	if s.cache == nil {
+		return ""
+	}
+	hasher, ok := s.target.(source.TreeHasher)
+	if !ok {
+		return ""
+	}
+	tree, err := hasher.TreeHash(ref)
+	if err != nil {
+		log.WithFields("error", err, "ref", ref).Debug("unable to hash ref for the scan cache; scanning without it")
+		return ""
+	}
+	return s.catalogKey(tree, ref)
 }
 
 // scanDir catalogs an already-materialized directory and, when a DB was supplied,
@@ -111,6 +212,20 @@ func (s *scanner) scanDir(ctx context.Context, dir, ref string) (dependency.Scan
 	if err != nil {
 		return dependency.Scan{}, err
 	}
+	return s.finish(ctx, cat, ref, "")
+}
+
+// finish writes ref's requested SBOMs from its catalog and, when a DB was
+// supplied, matches it, serving the matches from cache when catalogKey has some
+// for the same DB.
+func (s *scanner) finish(ctx context.Context, cat *catalog, ref, catalogKey string) (dependency.Scan, error) {
+	// a requested SBOM is an artifact the caller is counting on, so failing to
+	// write one fails the ref rather than being skipped.
+	for _, e := range s.exports[ref] {
+		if err := e.write(cat.sb); err != nil {
+			return dependency.Scan{}, err
+		}
+	}
 	snap := dependency.Scan{Packages: cat.packages}
 
 	// no DB supplied: packages-only.
@@ -118,11 +233,22 @@ func (s *scanner) scanDir(ctx context.Context, dir, ref string) (dependency.Scan
 		return snap, nil
 	}
 
-	vulns, err := matchSBOM(ctx, s.provider, cat.sb)
+	var key string
+	if catalogKey != "" && !s.dbBuilt.IsZero() {
+		key = vulnKey(catalogKey, s.dbBuilt)
+	}
+	if vulns, ok := s.cache.loadVulns(key); ok {
+		log.WithFields("ref", ref).Debug("using cached vulnerability matches")
+		snap.Vulns = vulns
+		return snap, nil
+	}
+
+	vulns, err := matchSBOM(ctx, s.provider, cat.sb, cat.packages)
 	if err != nil {
 		log.WithFields("error", err, "ref", ref).Warn("unable to match vulnerabilities; skipping")
 		return snap, nil
 	}
+	s.cache.storeVulns(key, vulns)
 	snap.Vulns = vulns
 	return snap, nil
 }
@@ -131,7 +257,8 @@ func (s *scanner) scanDir(ctx context.Context, dir, ref string) (dependency.Scan
 // consumes), linking the resolved syft source to the bus so the UI can attribute
 // live cataloging progress to the right ref.
 func (s *scanner) catalog(ctx context.Context, dir, ref string) (*catalog, error) {
-	if len(s.excludePaths) > 0 || !s.recursive {
+	members, inWorkspace := s.workspaces[ref]
+	if len(s.excludePaths) > 0 || (!s.recursive && !inWorkspace) {
 		// syft resolves symlinks when indexing the tree but derives exclusion
 		// roots from filepath.Abs (no symlink resolution), so when the scan dir
 		// sits behind a symlink (e.g. macOS /var → /private/var tmpdirs) the
@@ -144,7 +271,7 @@ func (s *scanner) catalog(ctx context.Context, dir, ref string) (*catalog, error
 		}
 	}
 
-	excludes, err := s.effectiveExcludes(dir)
+	excludes, err := s.effectiveExcludes(dir, inWorkspace)
 	if err != nil {
 		return nil, err
 	}
@@ -196,7 +323,38 @@ func (s *scanner) catalog(ctx context.Context, dir, ref string) (*catalog, error
 		return nil, fmt.Errorf("unable to catalog packages: %w", err)
 	}
 
-	return &catalog{packages: mapPackages(sb), sb: sb}, nil
+	var memberOf map[artifact.ID][]string
+	if inWorkspace {
+		memberOf = assignMembers(sb, members)
+	}
+	return &catalog{packages: mapPackages(sb, classify(dir, sb), memberOf), sb: sb}, nil
+}
+
+// assignMembers works out which workspace members each cataloged package was
+// found in, from the directories of its locations, and drops the packages
+// found in none of them (a fixture or tooling module below a member) from the
+// SBOM, so neither the diff, the match nor an exported document sees them.
+// Materializing a workspace selection already leaves most of those out; this
+// is what holds for a target that can't prune.
+func assignMembers(sb *sbom.SBOM, members []string) map[artifact.ID][]string {
+	out := make(map[artifact.ID][]string)
+	var outside []artifact.ID
+	for p := range sb.Artifacts.Packages.Enumerate() {
+		var found []string
+		for _, loc := range p.Locations.ToSlice() {
+			if m := workspace.Of(members, loc.RealPath); m != "" {
+				found = append(found, m)
+			}
+		}
+		if len(found) == 0 {
+			outside = append(outside, p.ID())
+			continue
+		}
+		slices.Sort(found)
+		out[p.ID()] = slices.Compact(found)
+	}
+	sb.Artifacts.Packages.Delete(outside...)
+	return out
 }
 
 // effectiveExcludes combines the user's exclude patterns with the synthetic
@@ -206,9 +364,10 @@ func (s *scanner) catalog(ctx context.Context, dir, ref string) (*catalog, error
 // top-level entries and emit one "./<name>" prune per subdir. syft's directory
 // source matches each against the indexed path and returns filepath.SkipDir for
 // the dir, pruning the whole subtree while leaving root files untouched. dir is
-// expected to already be symlink-resolved by the caller.
-func (s *scanner) effectiveExcludes(dir string) ([]string, error) {
-	if s.recursive {
+// expected to already be symlink-resolved by the caller. A workspace ref is
+// scanned as if recursive: its members, not depth, decide what is kept.
+func (s *scanner) effectiveExcludes(dir string, inWorkspace bool) ([]string, error) {
+	if s.recursive || inWorkspace {
 		return s.excludePaths, nil
 	}
 
@@ -259,23 +418,62 @@ func isDir(dir string, e os.DirEntry) bool {
 
 // mapPackages folds syft's package collection into the pure dependency.Package
 // model (identity is Type+Name). The raw syft type string carries through; the
-// human-friendly ecosystem label is derived later, in the render layer.
-func mapPackages(sb *sbom.SBOM) []dependency.Package {
+// human-friendly ecosystem label is derived later, in the render layer. classes
+// holds the direct/transitive and runtime/dev classification of the packages
+// that could be classified (see classify). Licenses carry through as declared,
+// so the diff can report license transitions. memberOf, set for a workspace
+// scan, holds the members each package was found in (see assignMembers); a
+// package found in several is emitted once per member.
+func mapPackages(sb *sbom.SBOM, classes map[artifact.ID]classification, memberOf map[artifact.ID][]string) []dependency.Package {
 	var out []dependency.Package
 	for p := range sb.Artifacts.Packages.Enumerate() {
-		out = append(out, dependency.Package{
-			Name:    p.Name,
-			Version: p.Version,
-			Type:    string(p.Type),
-		})
+		cl := classes[p.ID()]
+		dp := dependency.Package{
+			Name:         p.Name,
+			Version:      p.Version,
+			Type:         string(p.Type),
+			PURL:         p.PURL,
+			Relationship: cl.relationship,
+			Scope:        cl.scope,
+			Licenses:     licenseNames(p.Licenses),
+		}
+		if memberOf == nil {
+			out = append(out, dp)
+			continue
+		}
+		for _, m := range memberOf[p.ID()] {
+			dp.Member = m
+			out = append(out, dp)
+		}
 	}
 	return out
 }
 
+// licenseNames reduces syft's license set to the names the diff compares: each
+// license's SPDX expression where syft could normalize it, else the raw value
+// from the metadata. A license known only by its full text has no name and is
+// skipped.
+func licenseNames(set pkg.LicenseSet) []string {
+	var out []string
+	for _, l := range set.ToSlice() {
+		name := l.SPDXExpression
+		if name == "" {
+			name = strings.TrimSpace(l.Value)
+		}
+		if name != "" {
+			out = append(out, name)
+		}
+	}
+	slices.Sort(out)
+	return slices.Compact(out)
+}
+
 // matchSBOM runs grype over the syft catalog and folds the results into a map
-// keyed by package identity, so Annotate can attribute each vuln to a concrete
-// change.
-func matchSBOM(ctx context.Context, provider vulnerability.Provider, sb *sbom.SBOM) (map[dependency.PackageKey][]dependency.Vulnerability, error) {
+// keyed by package identity, version, and workspace member, so Annotate can
+// attribute each vuln to a concrete change. pkgs are the packages mapped from
+// sb: a match is filed under every member that catalogs the matched version,
+// and only those.
+func matchSBOM(ctx context.Context, provider vulnerability.Provider, sb *sbom.SBOM, pkgs []dependency.Package) (map[dependency.VulnKey][]dependency.Vulnerability, error) {
 	gpkgPtrs := grypePkg.FromCollection(sb.Artifacts.Packages, sb.Relationships, grypePkg.SynthesisConfig{})
 	// FromCollection yields pointers; the matcher wants values (mirrors grype's
 	// own pkg.Provide).
@@ -291,15 +489,21 @@ func matchSBOM(ctx context.Context, provider vulnerability.Provider, sb *sbom.SB
 		Matchers:              grypeMatcher.NewDefaultMatchers(grypeMatcher.Config{}),
 	}
 
-	matches, _, err := vm.FindMatchesContext(ctx, gpkgs, grypePkg.Context{})
+	// a source tree has no distro, but an SBOM of a container image does; grype
+	// needs it to match the image's OS packages.
+	pkgCtx := grypePkg.Context{
+		Source: &sb.Source,
+		Distro: distro.FromRelease(sb.Artifacts.LinuxDistribution, distro.DefaultFixChannels()),
+	}
+	matches, _, err := vm.FindMatchesContext(ctx, gpkgs, pkgCtx)
 	if err != nil {
 		return nil, fmt.Errorf("unable to find vulnerability matches: %w", err)
 	}
 
-	out := make(map[dependency.PackageKey][]dependency.Vulnerability)
-	seen := make(map[dependency.PackageKey]map[string]struct{})
+	matched := make(map[dependency.VulnKey][]dependency.Vulnerability)
+	seen := make(map[dependency.VulnKey]map[string]struct{})
 	for _, m := range matches.Sorted() {
-		key := dependency.PackageKey{Type: string(m.Package.Type), Name: m.Package.Name}
+		key := dependency.VulnKey{PackageKey: dependency.PackageKey{Type: string(m.Package.Type), Name: m.Package.Name}, Version: m.Package.Version}
 
 		// dedupe vuln IDs per package — a single CVE can match a package via
 		// multiple details/namespaces but is one vuln for our purposes.
@@ -313,21 +517,62 @@ func matchSBOM(ctx context.Context, provider vulnerability.Provider, sb *sbom.SB
 		}
 		ids[m.Vulnerability.ID] = struct{}{}
 
-		var severity, dataSource string
-		if m.Vulnerability.Metadata != nil {
-			severity = m.Vulnerability.Metadata.Severity
+		v := dependency.Vulnerability{
+			ID:       m.Vulnerability.ID,
+			FixState: string(m.Vulnerability.Fix.State),
+			FixedIn:  m.Vulnerability.Fix.Versions,
+		}
+		for _, r := range m.Vulnerability.RelatedVulnerabilities {
+			if r.ID != v.ID && !slices.Contains(v.Aliases, r.ID) {
+				v.Aliases = append(v.Aliases, r.ID)
+			}
+		}
+		if md := m.Vulnerability.Metadata; md != nil {
+			v.Severity = md.Severity
 			// the primary reference URL grype recorded for this ID (e.g. the NVD
 			// or GHSA page); encoders use it to make the CVE/GHSA ID clickable.
-			dataSource = m.Vulnerability.Metadata.DataSource
+			v.DataSource = md.DataSource
+			v.KnownExploited = len(md.KnownExploited) > 0
+			// a GHSA can alias several CVEs, each with its own score; the most
+			// likely to be exploited speaks for the vulnerability.
+			for _, e := range md.EPSS {
+				if e.EPSS > v.EPSS {
+					v.EPSS, v.EPSSPercentile = e.EPSS, e.Percentile
+				}
+			}
 		}
 
-		out[key] = append(out[key], dependency.Vulnerability{
-			ID:         m.Vulnerability.ID,
-			Severity:   severity,
-			FixState:   string(m.Vulnerability.Fix.State),
-			DataSource: dataSource,
-		})
+		matched[key] = append(matched[key], v)
+	}
+
+	return spreadMembers(matched, pkgs), nil
+}
+
+// spreadMembers files each package version's matches under every workspace
+// member that catalogs that version. grype sees a package once however many
+// members depend on it, but a member only carries the vulnerabilities of the
+// version it pins itself. A package outside any workspace has the single
+// member "".
+func spreadMembers(matched map[dependency.VulnKey][]dependency.Vulnerability, pkgs []dependency.Package) map[dependency.VulnKey][]dependency.Vulnerability {
+	members := make(map[dependency.VulnKey][]string)
+	for _, p := range pkgs {
+		k := dependency.VulnKey{PackageKey: dependency.PackageKey{Type: p.Type, Name: p.Name}, Version: p.Version}
+		if !slices.Contains(members[k], p.Member) {
+			members[k] = append(members[k], p.Member)
+		}
 	}
 
-	return out, nil
+	out := make(map[dependency.VulnKey][]dependency.Vulnerability, len(matched))
+	for key, vs := range matched {
+		ms := members[key]
+		if len(ms) == 0 {
+			ms = []string{""}
+		}
+		for _, m := range ms {
+			k := key
+			k.Member = m
+			out[k] = vs
+		}
+	}
+	return out
 }
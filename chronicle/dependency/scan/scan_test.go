@@ -8,6 +8,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/chronicle/chronicle/dependency"
+	"github.com/anchore/chronicle/chronicle/dependency/source"
 )
 
 // TestScanner_Scan_Exclude exercises the syft exclude wiring end-to-end: a scan
@@ -144,7 +147,7 @@ func TestScanner_effectiveExcludes(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &scanner{excludePaths: tt.exclude, recursive: tt.recursive}
-			got, err := s.effectiveExcludes(root)
+			got, err := s.effectiveExcludes(root, false)
 			require.NoError(t, err)
 			require.ElementsMatch(t, tt.want, got)
 		})
@@ -156,3 +159,102 @@ func writeManifest(t *testing.T, path, content string) {
 	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
 	require.NoError(t, os.WriteFile(path, []byte(content+"\n"), 0o644))
 }
+
+// TestScanner_Scan_Licenses checks that the licenses syft catalogs survive into the scan, named by
+// their SPDX ID where syft could normalize them and by their raw value otherwise.
+func TestScanner_Scan_Licenses(t *testing.T) {
+	root := t.TempDir()
+	writeManifest(t, filepath.Join(root, "package.json"), `{"name": "app", "version": "1.0.0"}`)
+	writeManifest(t, filepath.Join(root, "package-lock.json"), `{
+  "name": "app",
+  "version": "1.0.0",
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"name": "app", "version": "1.0.0"},
+    "node_modules/permissive": {"version": "1.0.0", "resolved": "https://registry.npmjs.org/permissive/-/permissive-1.0.0.tgz", "license": "mit"},
+    "node_modules/bespoke": {"version": "1.0.0", "resolved": "https://registry.npmjs.org/bespoke/-/bespoke-1.0.0.tgz", "license": "Acme Corp Proprietary"}
+  }
+}`)
+
+	s := &scanner{sourceName: "test", ecosystems: []string{"javascript"}, recursive: true}
+	snap, err := s.scanDir(context.Background(), root, "v0")
+	require.NoError(t, err)
+
+	got := make(map[string][]string)
+	for _, p := range snap.Packages {
+		got[p.Name] = p.Licenses
+	}
+	require.Equal(t, []string{"MIT"}, got["permissive"])
+	require.Equal(t, []string{"Acme Corp Proprietary"}, got["bespoke"])
+}
+
+// selectiveTarget records the selection the scanner materializes with.
+type selectiveTarget struct {
+	countingTarget
+	sel source.Selection
+}
+
+func (s *selectiveTarget) MaterializeSelection(ctx context.Context, ref string, sel source.Selection) (string, func() error, error) {
+	s.sel = sel
+	return s.Materialize(ctx, ref)
+}
+
+// TestScanner_Scan_Selection checks a target that can prune is asked for
+// exactly the scan's own scoping.
+func TestScanner_Scan_Selection(t *testing.T) {
+	root := t.TempDir()
+	writeManifest(t, filepath.Join(root, "requirements.txt"), "rootdep==1.0.0")
+
+	target := &selectiveTarget{countingTarget: countingTarget{dir: root}}
+	s := NewScanner(target, Options{SourceName: "test", Ecosystems: []string{"python"}, Exclude: []string{"./vendor"}})
+	_, err := s.Scan(context.Background(), "v1")
+	require.NoError(t, err)
+	require.Equal(t, source.Selection{RootOnly: true, Exclude: []string{"./vendor"}}, target.sel)
+}
+
+// TestScanner_Scan_Workspace checks a workspace ref scans its root and exactly
+// its members, whatever recursive says, attributing each package to the member
+// it was found in.
+func TestScanner_Scan_Workspace(t *testing.T) {
+	root := t.TempDir()
+	writeManifest(t, filepath.Join(root, "requirements.txt"), "rootdep==1.0.0\nshared==1.0.0")
+	writeManifest(t, filepath.Join(root, "api", "requirements.txt"), "apidep==1.0.0\nshared==1.0.0")
+	writeManifest(t, filepath.Join(root, "api", "testdata", "requirements.txt"), "fixturedep==1.0.0")
+	writeManifest(t, filepath.Join(root, ".make", "requirements.txt"), "tooldep==1.0.0")
+
+	target := &selectiveTarget{countingTarget: countingTarget{dir: root}}
+	s := NewScanner(target, Options{SourceName: "test", Ecosystems: []string{"python"}, Workspaces: map[string][]string{"v1": {"api"}}})
+	snap, err := s.Scan(context.Background(), "v1")
+	require.NoError(t, err)
+	require.Equal(t, source.Selection{RootOnly: true, Members: []string{"api"}}, target.sel)
+
+	var got []string
+	for _, p := range snap.Packages {
+		got = append(got, p.Member+":"+p.Name)
+	}
+	sort.Strings(got)
+	require.Equal(t, []string{".:rootdep", ".:shared", "api:apidep", "api:shared"}, got)
+}
+
+func TestSpreadMembers(t *testing.T) {
+	shared := dependency.PackageKey{Type: "python", Name: "shared"}
+	other := dependency.PackageKey{Type: "python", Name: "other"}
+	old := []dependency.Vulnerability{{ID: "CVE-OLD"}}
+
+	// grype matched shared@1.0.0 once; only the members pinning 1.0.0 carry it,
+	// not the one on 2.0.0. A package no member catalogs keeps member "".
+	got := spreadMembers(map[dependency.VulnKey][]dependency.Vulnerability{
+		{PackageKey: shared, Version: "1.0.0"}: old,
+		{PackageKey: other, Version: "3.0.0"}:  old,
+	}, []dependency.Package{
+		{Type: "python", Name: "shared", Version: "1.0.0", Member: "api"},
+		{Type: "python", Name: "shared", Version: "1.0.0", Member: "."},
+		{Type: "python", Name: "shared", Version: "2.0.0", Member: "web"},
+	})
+
+	require.Equal(t, map[dependency.VulnKey][]dependency.Vulnerability{
+		{PackageKey: shared, Version: "1.0.0", Member: "api"}: old,
+		{PackageKey: shared, Version: "1.0.0", Member: "."}:   old,
+		{PackageKey: other, Version: "3.0.0"}:                 old,
+	}, got)
+}
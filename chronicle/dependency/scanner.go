@@ -30,7 +30,7 @@ type Scanner interface {
 // core package owns the contract; the scan subpackage produces it.
 type Scan struct {
 	Packages []Package
-	Vulns    map[PackageKey][]Vulnerability // nil/empty when annotation disabled
+	Vulns    map[VulnKey][]Vulnerability // nil/empty when annotation disabled
 }
 
 // DistinctPackages counts the distinct packages by identity (PackageKey, i.e.
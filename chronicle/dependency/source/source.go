@@ -5,12 +5,18 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path"
 	"path/filepath"
+	"slices"
+	"strings"
 
+	"github.com/bmatcuk/doublestar/v4"
 	gogit "github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
 	"github.com/go-git/go-git/v5/plumbing/object"
 
+	"github.com/anchore/chronicle/chronicle/dependency/workspace"
 	"github.com/anchore/chronicle/internal/git"
 	"github.com/anchore/chronicle/internal/log"
 )
@@ -21,6 +27,52 @@ type Target interface {
 	Materialize(ctx context.Context, ref string) (dir string, cleanup func() error, err error)
 }
 
+// TreeHasher is implemented by targets that can name a ref's content without
+// materializing it, so a scan of content already seen can be served from cache.
+type TreeHasher interface {
+	// TreeHash returns a hash that changes whenever the files at ref do.
+	TreeHash(ref string) (string, error)
+}
+
+// Selection narrows what a SelectiveTarget writes to the part of a tree a scan
+// will read, so a root-only scan of a large repository doesn't write out every
+// file it holds. The zero Selection writes everything.
+type Selection struct {
+	// RootOnly writes only the files at the root of the tree.
+	RootOnly bool
+	// Exclude holds syft exclude patterns (./vendor, */examples, **/testdata);
+	// files under a matching path are not written. Patterns syft would reject
+	// are ignored here and left for syft to report.
+	Exclude []string
+	// Members, when non-nil, are the workspace member directories declared at
+	// the ref: only the files at the root and directly in a member directory
+	// are written, in place of RootOnly. An empty, non-nil Members is a
+	// workspace with no members yet, which writes the root alone.
+	Members []string
+}
+
+// Keeps reports whether the file at p (slash separated, relative to the tree
+// root) is one the selection writes, so code reading single files rather than
+// a materialized tree can scope itself exactly as a selective scan does.
+func (sel Selection) Keeps(p string) bool {
+	pr := newPruner(sel)
+	p = strings.TrimPrefix(p, "./")
+	// check each directory on the way down, as the tree walk would
+	for i, r := range p {
+		if r == '/' && pr.prunesDir(p[:i]) {
+			return false
+		}
+	}
+	return !pr.prunesFile(p)
+}
+
+// SelectiveTarget is implemented by targets that can materialize only part of a
+// ref. Materializing with a Selection must not change what a scan with the same
+// scoping finds, only how much is written to find it.
+type SelectiveTarget interface {
+	MaterializeSelection(ctx context.Context, ref string, sel Selection) (dir string, cleanup func() error, err error)
+}
+
 // GitTarget materializes a git ref into a temporary directory by walking the
 // commit tree at that ref with go-git. No subprocess invocation is used.
 type GitTarget struct {
@@ -37,6 +89,13 @@ func NewGitTarget(repoPath string) *GitTarget {
 // cleanup function removes the directory; callers must always call it (even on
 // error) to avoid leaking disk space.
 func (g *GitTarget) Materialize(ctx context.Context, ref string) (string, func() error, error) {
+	return g.MaterializeSelection(ctx, ref, Selection{})
+}
+
+// MaterializeSelection is Materialize writing only the files sel selects.
+// Pruned subtrees are skipped without reading their blobs (or, for a root-only
+// selection, even walking them).
+func (g *GitTarget) MaterializeSelection(ctx context.Context, ref string, sel Selection) (string, func() error, error) {
 	noopCleanup := func() error { return nil }
 
 	r, err := git.OpenRepository(g.repoPath)
@@ -63,7 +122,7 @@ func (g *GitTarget) Materialize(ctx context.Context, ref string) (string, func()
 		return os.RemoveAll(dir)
 	}
 
-	if err := materializeTree(ctx, tree, dir); err != nil {
+	if err := materializeTree(ctx, tree, dir, newPruner(sel)); err != nil {
 		// best-effort cleanup on failure; callers may also call the returned cleanup
 		_ = os.RemoveAll(dir)
 		return "", noopCleanup, fmt.Errorf("materialize tree for ref %q: %w", ref, err)
@@ -74,6 +133,20 @@ func (g *GitTarget) Materialize(ctx context.Context, ref string) (string, func()
 	return dir, cleanup, nil
 }
 
+// TreeHash returns the hash of the tree ref's commit points at: two refs with
+// identical files share it, whatever their history.
+func (g *GitTarget) TreeHash(ref string) (string, error) {
+	r, err := git.OpenRepository(g.repoPath)
+	if err != nil {
+		return "", fmt.Errorf("open repo %q: %w", g.repoPath, err)
+	}
+	commit, err := resolveCommit(r, ref)
+	if err != nil {
+		return "", fmt.Errorf("resolve ref %q: %w", ref, err)
+	}
+	return commit.TreeHash.String(), nil
+}
+
 // resolveCommit resolves a ref to a commit. It tries a tag reference first
 // (both lightweight and annotated, which ResolveRevision does not reliably find
 // or peel), then falls back to go-git's revision resolution for HEAD, branch
@@ -105,25 +178,12 @@ func commitFromTagRef(r *gogit.Repository, t *plumbing.Reference) (*object.Commi
 	return r.CommitObject(tagObj.Target)
 }
 
-// materializeTree walks every file in the given tree and writes it under dir,
-// preserving relative paths. Submodule entries (mode 0160000) are skipped
-// silently since they have no blob content. Context cancellation is honoured
-// between files.
-func materializeTree(ctx context.Context, tree *object.Tree, dir string) error {
-	files := tree.Files()
-	defer files.Close()
-
-	return files.ForEach(func(f *object.File) error {
-		if err := ctx.Err(); err != nil {
-			return err
-		}
-
-		// skip submodule gitlinks — they carry no blob data
-		if f.Mode == 0160000 {
-			log.WithFields("path", f.Name).Trace("skipping submodule entry during tree materialization")
-			return nil
-		}
-
+// materializeTree walks the files in the given tree that p keeps and writes
+// them under dir, preserving relative paths. Submodule entries (mode 0160000)
+// are skipped silently since they have no blob content. Context cancellation is
+// honoured between files.
+func materializeTree(ctx context.Context, tree *object.Tree, dir string, p pruner) error {
+	return walkTree(ctx, tree, "", p, func(f *object.File) error {
 		dest := filepath.Join(dir, filepath.FromSlash(f.Name))
 
 		if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
@@ -138,6 +198,99 @@ func materializeTree(ctx context.Context, tree *object.Tree, dir string) error {
 	})
 }
 
+// walkTree calls fn for each file under tree (named by its path from the root,
+// which tree sits at prefix under) that p keeps. A directory p prunes is not
+// descended into, so its objects are never read.
+func walkTree(ctx context.Context, tree *object.Tree, prefix string, p pruner, fn func(*object.File) error) error {
+	for _, entry := range tree.Entries {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		name := path.Join(prefix, entry.Name)
+		switch entry.Mode {
+		case filemode.Dir:
+			if p.prunesDir(name) {
+				continue
+			}
+			sub, err := tree.Tree(entry.Name)
+			if err != nil {
+				return fmt.Errorf("read tree %q: %w", name, err)
+			}
+			if err := walkTree(ctx, sub, name, p, fn); err != nil {
+				return err
+			}
+		case filemode.Submodule:
+			// skip submodule gitlinks — they carry no blob data
+			log.WithFields("path", name).Trace("skipping submodule entry during tree materialization")
+		default:
+			if p.prunesFile(name) {
+				continue
+			}
+			f, err := tree.TreeEntryFile(&entry)
+			if err != nil {
+				return fmt.Errorf("read file %q: %w", name, err)
+			}
+			f.Name = name
+			if err := fn(f); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// pruner decides which tree paths a Selection leaves out. Paths are slash
+// separated and relative to the tree root, as go-git names them.
+type pruner struct {
+	rootOnly bool
+	exclude  []string
+	members  []string // nil unless the selection is a workspace
+}
+
+func newPruner(sel Selection) pruner {
+	p := pruner{rootOnly: sel.RootOnly, members: sel.Members}
+	for _, e := range sel.Exclude {
+		// mirror syft's own reading of the patterns: relative to the scan root,
+		// with a trailing slash meaning nothing.
+		if !strings.HasPrefix(e, "./") && !strings.HasPrefix(e, "*/") && !strings.HasPrefix(e, "**/") {
+			continue
+		}
+		p.exclude = append(p.exclude, strings.TrimSuffix(strings.TrimPrefix(e, "./"), "/"))
+	}
+	return p
+}
+
+func (p pruner) prunesDir(name string) bool {
+	if p.members != nil {
+		// keep walking only towards a member: below one, or beside all of
+		// them, there is nothing a workspace scan reads.
+		return !slices.ContainsFunc(p.members, func(m string) bool {
+			return m == name || strings.HasPrefix(m, name+"/")
+		}) || p.excluded(name)
+	}
+	return p.rootOnly || p.excluded(name)
+}
+
+// prunesFile reports whether a file is left out. Its directories have already
+// been checked on the way down, so only the file itself is matched (and, in a
+// workspace, whether it sits in a member rather than on the way to one).
+func (p pruner) prunesFile(name string) bool {
+	if p.members != nil && !workspace.Contains(p.members, name) {
+		return true
+	}
+	return p.excluded(name)
+}
+
+func (p pruner) excluded(name string) bool {
+	for _, e := range p.exclude {
+		if ok, err := doublestar.Match(e, name); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
 // writeBlob streams the content of a git file object to the given destination path.
 func writeBlob(f *object.File, dest string) error {
 	rc, err := f.Reader()
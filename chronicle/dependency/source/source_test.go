@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 	"time"
 
@@ -147,6 +148,90 @@ func TestGitTarget_Materialize(t *testing.T) {
 	}
 }
 
+// TestGitTarget_MaterializeSelection checks that pruned paths are never
+// written while everything a scan with the same scoping reads still is.
+func TestGitTarget_MaterializeSelection(t *testing.T) {
+	dir := t.TempDir()
+	r, err := gogit.PlainInit(dir, false)
+	require.NoError(t, err)
+	w, err := r.Worktree()
+	require.NoError(t, err)
+
+	writeFile(t, dir, "go.mod", "module example.com/app\n")
+	writeFile(t, dir, "go.sum", "")
+	writeFile(t, dir, "vendor/modules.txt", "# vendored\n")
+	writeFile(t, dir, "tools/go.mod", "module example.com/tools\n")
+	writeFile(t, dir, "pkg/testdata/requirements.txt", "testdep==1.0.0\n")
+	writeFile(t, dir, "pkg/lib/requirements.txt", "libdep==1.0.0\n")
+	_, err = w.Add(".")
+	require.NoError(t, err)
+	h, err := w.Commit("commit", &gogit.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+
+	all := []string{"go.mod", "go.sum", "pkg/lib/requirements.txt", "pkg/testdata/requirements.txt", "tools/go.mod", "vendor/modules.txt"}
+	tests := []struct {
+		name string
+		sel  Selection
+		want []string
+	}{
+		{
+			name: "zero selection writes everything",
+			want: all,
+		},
+		{
+			name: "root only",
+			sel:  Selection{RootOnly: true},
+			want: []string{"go.mod", "go.sum"},
+		},
+		{
+			name: "excludes",
+			sel:  Selection{Exclude: []string{"./vendor/", "**/testdata", "*/go.mod"}},
+			want: []string{"go.mod", "go.sum", "pkg/lib/requirements.txt"},
+		},
+		{
+			name: "patterns syft rejects are left alone",
+			sel:  Selection{Exclude: []string{"vendor"}},
+			want: all,
+		},
+		{
+			name: "workspace members",
+			sel:  Selection{RootOnly: true, Members: []string{"pkg/lib"}},
+			want: []string{"go.mod", "go.sum", "pkg/lib/requirements.txt"},
+		},
+		{
+			name: "workspace without members",
+			sel:  Selection{Members: []string{}},
+			want: []string{"go.mod", "go.sum"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, cleanup, err := NewGitTarget(dir).MaterializeSelection(context.Background(), h.String(), tt.sel)
+			require.NoError(t, err)
+			t.Cleanup(func() { assert.NoError(t, cleanup()) })
+
+			var got []string
+			require.NoError(t, filepath.WalkDir(out, func(p string, d os.DirEntry, err error) error {
+				if err != nil || d.IsDir() {
+					return err
+				}
+				rel, err := filepath.Rel(out, p)
+				got = append(got, filepath.ToSlash(rel))
+				return err
+			}))
+			assert.Equal(t, tt.want, got)
+
+			// Keeps scopes single files the same way
+			for _, p := range all {
+				assert.Equal(t, slices.Contains(tt.want, p), tt.sel.Keeps(p), p)
+			}
+		})
+	}
+}
+
 // TestGitTarget_Materialize_LenientBranchConfig is a regression test for repos
 // whose .git/config carries a branch whose `merge` value go-git's validator
 // rejects (e.g. a tracking ref that is not under refs/heads/). Real git tolerates
@@ -272,3 +357,29 @@ func TestGitTarget_Materialize_BadRepoPath(t *testing.T) {
 	_, _, err := target.Materialize(context.Background(), "HEAD")
 	require.Error(t, err)
 }
+
+// TestGitTarget_TreeHash checks the tree hash follows a ref's content rather
+// than its commit: a tag on a commit and the commit itself agree, and a commit
+// that changed files gets a new hash.
+func TestGitTarget_TreeHash(t *testing.T) {
+	repoPath, firstHash, secondHash := buildTestRepo(t)
+
+	r, err := gogit.PlainOpen(repoPath)
+	require.NoError(t, err)
+	_, err = r.CreateTag("v0.1.0", plumbingHash(t, firstHash), nil)
+	require.NoError(t, err)
+
+	target := NewGitTarget(repoPath)
+	first, err := target.TreeHash(firstHash)
+	require.NoError(t, err)
+	tagged, err := target.TreeHash("v0.1.0")
+	require.NoError(t, err)
+	second, err := target.TreeHash(secondHash)
+	require.NoError(t, err)
+
+	assert.Equal(t, first, tagged)
+	assert.NotEqual(t, first, second)
+
+	_, err = target.TreeHash("does-not-exist")
+	assert.Error(t, err)
+}
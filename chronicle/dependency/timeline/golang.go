@@ -0,0 +1,28 @@
+package timeline
+
+import (
+	"golang.org/x/mod/modfile"
+
+	"github.com/anchore/chronicle/chronicle/dependency"
+)
+
+// goModParser reads the require directives of a go.mod. go.sum is left alone:
+// it also lists modules the build never selects, so it would report versions
+// the scan doesn't.
+type goModParser struct{}
+
+func (goModParser) Ecosystem() dependency.Ecosystem { return dependency.EcosystemGo }
+
+func (goModParser) Names() []string { return []string{"go.mod"} }
+
+func (goModParser) Packages(content []byte) ([]dependency.Package, error) {
+	f, err := modfile.ParseLax("go.mod", content, nil)
+	if err != nil {
+		return nil, err
+	}
+	pkgs := make([]dependency.Package, 0, len(f.Require))
+	for _, r := range f.Require {
+		pkgs = append(pkgs, dependency.Package{Name: r.Mod.Path, Version: r.Mod.Version, Type: "go-module"})
+	}
+	return pkgs, nil
+}
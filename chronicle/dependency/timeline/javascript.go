@@ -0,0 +1,56 @@
+package timeline
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/anchore/chronicle/chronicle/dependency"
+)
+
+// npmLockParser reads package-lock.json and npm-shrinkwrap.json. Only packages
+// installed at the top of node_modules are read; a copy nested under another
+// package is a second version of the same name, and keeping both would make
+// every commit that touches either look like a change.
+type npmLockParser struct{}
+
+type npmLock struct {
+	// lockfileVersion 2 and 3
+	Packages map[string]struct {
+		Version string `json:"version"`
+		Link    bool   `json:"link"`
+	} `json:"packages"`
+	// lockfileVersion 1
+	Dependencies map[string]struct {
+		Version string `json:"version"`
+	} `json:"dependencies"`
+}
+
+func (npmLockParser) Ecosystem() dependency.Ecosystem { return dependency.EcosystemJavaScript }
+
+func (npmLockParser) Names() []string { return []string{"package-lock.json", "npm-shrinkwrap.json"} }
+
+func (npmLockParser) Packages(content []byte) ([]dependency.Package, error) {
+	var lock npmLock
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return nil, err
+	}
+
+	var pkgs []dependency.Package
+	if len(lock.Packages) > 0 {
+		for key, p := range lock.Packages {
+			name, ok := strings.CutPrefix(key, "node_modules/")
+			if !ok || strings.Contains(name, "/node_modules/") || p.Link || p.Version == "" {
+				continue
+			}
+			pkgs = append(pkgs, dependency.Package{Name: name, Version: p.Version, Type: "npm"})
+		}
+		return pkgs, nil
+	}
+	for name, p := range lock.Dependencies {
+		if p.Version == "" {
+			continue
+		}
+		pkgs = append(pkgs, dependency.Package{Name: name, Version: p.Version, Type: "npm"})
+	}
+	return pkgs, nil
+}
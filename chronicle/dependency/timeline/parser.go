@@ -0,0 +1,161 @@
+package timeline
+
+import (
+	"path"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+
+	"github.com/anchore/chronicle/chronicle/dependency"
+	"github.com/anchore/chronicle/chronicle/dependency/source"
+	"github.com/anchore/chronicle/internal/git"
+	"github.com/anchore/chronicle/internal/log"
+)
+
+// registry holds the lockfile parsers in the order they are tried.
+var registry = []parser{
+	goModParser{},
+	npmLockParser{},
+	requirementsParser{},
+	pythonLockParser{},
+	cargoLockParser{},
+	gemfileLockParser{},
+}
+
+// parser reads the resolved packages out of one kind of manifest or lockfile.
+// Parsers only see file content read from git; they never resolve anything, so
+// a manifest that declares a range rather than a pin (a bare package.json)
+// has no parser here.
+type parser interface {
+	// Ecosystem is the ecosystem the parser's packages belong to.
+	Ecosystem() dependency.Ecosystem
+	// Names are the basename globs of the files the parser reads.
+	Names() []string
+	// Packages returns the packages the file pins, typed like syft types them
+	// so they line up with the endpoint scan.
+	Packages(content []byte) ([]dependency.Package, error)
+}
+
+// KnownEcosystems returns the ecosystems with a lockfile parser, in canonical
+// order.
+func KnownEcosystems() []dependency.Ecosystem {
+	have := map[dependency.Ecosystem]bool{}
+	for _, p := range registry {
+		have[p.Ecosystem()] = true
+	}
+	var out []dependency.Ecosystem
+	for _, e := range dependency.Ecosystems() {
+		if have[e] {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+func selectedParsers(ecosystems []dependency.Ecosystem) []parser {
+	if len(ecosystems) == 0 {
+		return registry
+	}
+	want := map[dependency.Ecosystem]bool{}
+	for _, e := range ecosystems {
+		want[e] = true
+	}
+	var out []parser
+	for _, p := range registry {
+		if want[p.Ecosystem()] {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// matcher routes changed paths to the parser that reads them, honoring the
+// ignore globs and the recursive setting.
+type matcher struct {
+	parsers []parser
+	ignore  []string
+	// scope is the endpoint scan's selection: its depth, excludes and
+	// workspace members.
+	scope source.Selection
+}
+
+func newMatcher(parsers []parser, cfg Config) *matcher {
+	return &matcher{
+		parsers: parsers,
+		ignore:  cfg.Ignore,
+		scope:   source.Selection{RootOnly: !cfg.Recursive, Exclude: cfg.Exclude, Members: cfg.Members},
+	}
+}
+
+// match is the predicate handed to the commit diff.
+func (m *matcher) match(p string) bool {
+	return m.parserFor(p) != nil
+}
+
+func (m *matcher) parserFor(p string) parser {
+	p = strings.TrimPrefix(p, "./")
+	if !m.scope.Keeps(p) || m.isIgnored(p) {
+		return nil
+	}
+	base := path.Base(p)
+	for _, pr := range m.parsers {
+		for _, name := range pr.Names() {
+			if ok, _ := doublestar.Match(name, base); ok {
+				return pr
+			}
+		}
+	}
+	return nil
+}
+
+// isIgnored reports whether p, or a directory above it, matches an ignore glob,
+// so "./vendor" keeps out everything under vendor/ as it does for the scan.
+func (m *matcher) isIgnored(p string) bool {
+	for _, ig := range m.ignore {
+		ig = strings.TrimPrefix(ig, "./")
+		for dir := p; dir != "." && dir != "/"; dir = path.Dir(dir) {
+			if ok, _ := doublestar.Match(ig, dir); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// diff compares the packages pinned by one commit's manifest edits. Each file
+// is parsed on both sides of the edit; a file that fails to parse on either
+// side is left out entirely, since half of it would read as every package
+// being added or removed.
+func (m *matcher) diff(hash string, files []git.FileChange, cmp dependency.VersionComparer) []dependency.PackageChange {
+	var before, after dependency.Scan
+	for _, f := range files {
+		pr := m.parserFor(f.Path)
+		if pr == nil {
+			continue
+		}
+		b, err := parse(pr, f.Before)
+		if err == nil {
+			var a []dependency.Package
+			a, err = parse(pr, f.After)
+			if err == nil {
+				before.Packages = append(before.Packages, b...)
+				after.Packages = append(after.Packages, a...)
+				continue
+			}
+		}
+		log.WithFields("error", err, "commit", hash, "path", f.Path).Debug("unable to parse manifest for the dependency timeline")
+	}
+	if len(before.Packages) == 0 && len(after.Packages) == 0 {
+		return nil
+	}
+	return dependency.Compare(before, after, cmp).Changes
+}
+
+// parse reads one side of an edit; a missing side (the file was added or
+// deleted) pins nothing.
+func parse(p parser, content []byte) ([]dependency.Package, error) {
+	if content == nil {
+		return nil, nil
+	}
+	return p.Packages(content)
+}
@@ -0,0 +1,104 @@
+package timeline
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/chronicle/chronicle/dependency"
+)
+
+func TestParsers(t *testing.T) {
+	tests := []struct {
+		name    string
+		parser  parser
+		content string
+		want    []dependency.Package
+		wantErr require.ErrorAssertionFunc
+	}{
+		{
+			name:    "go.mod",
+			parser:  goModParser{},
+			content: "module example.com/app\n\ngo 1.22\n\nrequire golang.org/x/net v0.23.0\n\nrequire (\n\tgithub.com/google/uuid v1.6.0 // indirect\n)\n",
+			want: []dependency.Package{
+				{Name: "github.com/google/uuid", Version: "v1.6.0", Type: "go-module"},
+				{Name: "golang.org/x/net", Version: "v0.23.0", Type: "go-module"},
+			},
+		},
+		{
+			name:    "package-lock v3 skips the root, nested copies and links",
+			parser:  npmLockParser{},
+			content: `{"lockfileVersion": 3, "packages": {"": {"name": "app", "version": "1.0.0"}, "node_modules/lodash": {"version": "4.17.21"}, "node_modules/@types/node": {"version": "20.0.0"}, "node_modules/a/node_modules/lodash": {"version": "3.0.0"}, "node_modules/local": {"link": true}}}`,
+			want: []dependency.Package{
+				{Name: "@types/node", Version: "20.0.0", Type: "npm"},
+				{Name: "lodash", Version: "4.17.21", Type: "npm"},
+			},
+		},
+		{
+			name:    "package-lock v1",
+			parser:  npmLockParser{},
+			content: `{"lockfileVersion": 1, "dependencies": {"left-pad": {"version": "1.3.0"}}}`,
+			want:    []dependency.Package{{Name: "left-pad", Version: "1.3.0", Type: "npm"}},
+		},
+		{
+			name:    "package-lock that isn't json",
+			parser:  npmLockParser{},
+			content: "{",
+			wantErr: require.Error,
+		},
+		{
+			name:    "requirements keep only pins",
+			parser:  requirementsParser{},
+			content: "# pinned\nrequests==2.31.0\nuvicorn[standard] == 0.29.0 ; python_version >= '3.8'\nflask>=3.0\n-r base.txt\nlegacy===1.0-custom # note\n",
+			want: []dependency.Package{
+				{Name: "legacy", Version: "1.0-custom", Type: "python"},
+				{Name: "requests", Version: "2.31.0", Type: "python"},
+				{Name: "uvicorn", Version: "0.29.0", Type: "python"},
+			},
+		},
+		{
+			name:    "poetry.lock",
+			parser:  pythonLockParser{},
+			content: "[[package]]\nname = \"certifi\"\nversion = \"2024.2.2\"\n\n[[package]]\nname = \"idna\"\nversion = \"3.7\"\n\n[metadata]\nlock-version = \"2.0\"\n",
+			want: []dependency.Package{
+				{Name: "certifi", Version: "2024.2.2", Type: "python"},
+				{Name: "idna", Version: "3.7", Type: "python"},
+			},
+		},
+		{
+			name:    "Cargo.lock",
+			parser:  cargoLockParser{},
+			content: "version = 3\n\n[[package]]\nname = \"serde\"\nversion = \"1.0.200\"\nsource = \"registry+https://github.com/rust-lang/crates.io-index\"\n",
+			want:    []dependency.Package{{Name: "serde", Version: "1.0.200", Type: "rust-crate"}},
+		},
+		{
+			name:   "Gemfile.lock reads only resolved specs",
+			parser: gemfileLockParser{},
+			content: "GEM\n  remote: https://rubygems.org/\n  specs:\n    nokogiri (1.16.0-x86_64-linux)\n      racc (~> 1.4)\n    racc (1.7.3)\n\nPLATFORMS\n  x86_64-linux\n\nDEPENDENCIES\n  nokogiri (~> 1.16)\n\n" +
+				"BUNDLED WITH\n   2.5.6\n",
+			want: []dependency.Package{
+				{Name: "nokogiri", Version: "1.16.0-x86_64-linux", Type: "gem"},
+				{Name: "racc", Version: "1.7.3", Type: "gem"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr == nil {
+				tt.wantErr = require.NoError
+			}
+			got, err := tt.parser.Packages([]byte(tt.content))
+			tt.wantErr(t, err)
+			if err != nil {
+				return
+			}
+			sort.Slice(got, func(i, j int) bool { return got[i].Name < got[j].Name })
+			if d := cmp.Diff(tt.want, got); d != "" {
+				t.Errorf("packages mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package timeline
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/anchore/chronicle/chronicle/dependency"
+)
+
+// requirementsParser reads the pinned (== or ===) entries of a pip
+// requirements file. Ranges don't say which version is installed, so they are
+// skipped.
+type requirementsParser struct{}
+
+// pinnedRequirement matches "name==1.2.3", allowing extras ("name[extra]==")
+// and the arbitrary-equality "===".
+var pinnedRequirement = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)\s*(?:\[[^\]]*\])?\s*===?\s*([^\s;#,]+)`)
+
+func (requirementsParser) Ecosystem() dependency.Ecosystem { return dependency.EcosystemPython }
+
+func (requirementsParser) Names() []string {
+	return []string{"requirements*.txt", "constraints*.txt"}
+}
+
+func (requirementsParser) Packages(content []byte) ([]dependency.Package, error) {
+	var pkgs []dependency.Package
+	sc := bufio.NewScanner(bytes.NewReader(content))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-") {
+			continue
+		}
+		if m := pinnedRequirement.FindStringSubmatch(line); m != nil {
+			pkgs = append(pkgs, dependency.Package{Name: m[1], Version: m[2], Type: "python"})
+		}
+	}
+	return pkgs, sc.Err()
+}
+
+// pythonLockParser reads the [[package]] tables of the TOML lockfiles poetry,
+// uv and pdm write.
+type pythonLockParser struct{}
+
+func (pythonLockParser) Ecosystem() dependency.Ecosystem { return dependency.EcosystemPython }
+
+func (pythonLockParser) Names() []string { return []string{"poetry.lock", "uv.lock", "pdm.lock"} }
+
+func (pythonLockParser) Packages(content []byte) ([]dependency.Package, error) {
+	return tomlPackages(content, "python")
+}
+
+// tomlLock is the shape Cargo.lock shares with the python lockfiles: an array
+// of package tables, each with a name and version.
+type tomlLock struct {
+	Package []struct {
+		Name    string `toml:"name"`
+		Version string `toml:"version"`
+	} `toml:"package"`
+}
+
+func tomlPackages(content []byte, pkgType string) ([]dependency.Package, error) {
+	var lock tomlLock
+	if err := toml.Unmarshal(content, &lock); err != nil {
+		return nil, err
+	}
+	pkgs := make([]dependency.Package, 0, len(lock.Package))
+	for _, p := range lock.Package {
+		if p.Name == "" || p.Version == "" {
+			continue
+		}
+		pkgs = append(pkgs, dependency.Package{Name: p.Name, Version: p.Version, Type: pkgType})
+	}
+	return pkgs, nil
+}
@@ -0,0 +1,44 @@
+package timeline
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strings"
+
+	"github.com/anchore/chronicle/chronicle/dependency"
+)
+
+// gemfileLockParser reads the resolved gems of a Gemfile.lock: the entries
+// indented four spaces under a "specs:" line. The deeper-indented lines under
+// each are that gem's own requirements, not resolved versions.
+type gemfileLockParser struct{}
+
+// lockedGem matches "    rack (2.2.8)" and platform gems like
+// "    nokogiri (1.16.0-x86_64-linux)".
+var lockedGem = regexp.MustCompile(`^ {4}([^ (]+) \(([^)]+)\)$`)
+
+func (gemfileLockParser) Ecosystem() dependency.Ecosystem { return dependency.EcosystemRuby }
+
+func (gemfileLockParser) Names() []string { return []string{"Gemfile.lock", "gems.locked"} }
+
+func (gemfileLockParser) Packages(content []byte) ([]dependency.Package, error) {
+	var pkgs []dependency.Package
+	var inSpecs bool
+	sc := bufio.NewScanner(bytes.NewReader(content))
+	for sc.Scan() {
+		line := strings.TrimRight(sc.Text(), "\r")
+		switch {
+		case strings.TrimSpace(line) == "specs:":
+			inSpecs = true
+		case !strings.HasPrefix(line, "    "):
+			// a section header or a "remote:" line ends the specs block
+			inSpecs = false
+		case inSpecs:
+			if m := lockedGem.FindStringSubmatch(line); m != nil {
+				pkgs = append(pkgs, dependency.Package{Name: m[1], Version: m[2], Type: "gem"})
+			}
+		}
+	}
+	return pkgs, sc.Err()
+}
@@ -0,0 +1,16 @@
+package timeline
+
+import (
+	"github.com/anchore/chronicle/chronicle/dependency"
+)
+
+// cargoLockParser reads the [[package]] tables of a Cargo.lock.
+type cargoLockParser struct{}
+
+func (cargoLockParser) Ecosystem() dependency.Ecosystem { return dependency.EcosystemRust }
+
+func (cargoLockParser) Names() []string { return []string{"Cargo.lock"} }
+
+func (cargoLockParser) Packages(content []byte) ([]dependency.Package, error) {
+	return tomlPackages(content, "rust-crate")
+}
@@ -0,0 +1,138 @@
+// Package timeline places a release's dependency changes on the commits that
+// made them. The dependency diff compares only the release's two endpoints, so
+// a package that regressed and recovered inside the release, or was bumped
+// three times, shows up as a single transition (or not at all). The timeline
+// walks every commit on the release's first-parent line instead and diffs the
+// manifests and lockfiles each one edited, parsing them directly rather than
+// running a full scan per commit, so the trunk view can show each dependency
+// event next to the commit that caused it.
+package timeline
+
+import (
+	"github.com/anchore/chronicle/chronicle/dependency"
+	"github.com/anchore/chronicle/chronicle/release"
+	"github.com/anchore/chronicle/internal/git"
+	"github.com/anchore/chronicle/internal/log"
+)
+
+// gitReader is the slice of git.Interface the timeline depends on: the commits
+// in the release's range and the files each one changed.
+type gitReader interface {
+	CommitsBetweenWithMeta(git.Range) ([]git.Commit, error)
+	FileChangesInCommit(hash string, match func(path string) bool) ([]git.FileChange, error)
+}
+
+// Config controls the timeline.
+type Config struct {
+	// Enabled is the opt-in switch. When false, Build is a no-op.
+	Enabled bool
+	// Ecosystems limits which lockfile parsers run. Empty means all known.
+	Ecosystems []dependency.Ecosystem
+	// Ignore holds path globs whose manifests are never read (e.g. "**/vendor/**").
+	// A glob matching a directory ignores everything beneath it.
+	Ignore []string
+	// Recursive reads manifests below the repository root too. When false,
+	// only root-level manifests count, matching the endpoint scan.
+	Recursive bool
+	// Exclude holds the scan's syft exclude patterns (./vendor, **/testdata),
+	// pruned the way the scan prunes them so the timeline reads no manifest
+	// the release diff left out.
+	Exclude []string
+	// Members, when non-nil, are the workspace member directories the release
+	// declares (see workspace.Discover): only the manifests at the root and
+	// directly in a member are read, in place of the Recursive setting.
+	Members []string
+	// Comparer decides whether a version change is an update or a downgrade.
+	Comparer dependency.VersionComparer
+}
+
+// Timeline maps a commit hash to the package changes its manifest edits made,
+// sorted like a Diff's changes. Commits that changed no dependency are absent.
+type Timeline map[string][]dependency.PackageChange
+
+// Build walks the commits between sinceRef (exclusive) and untilRef and diffs
+// the manifests each first-parent commit edited against that commit's first
+// parent. Commits only reachable through a merge are skipped: the merge commit
+// brings their edits to the trunk, and that is where the timeline shows them.
+//
+// The timeline is best-effort. A commit that can't be read, or a manifest that
+// can't be parsed on either side of the edit, is logged and skipped. Only an
+// unresolvable range is an error.
+func Build(gitter gitReader, cfg Config, sinceRef, untilRef string) (Timeline, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	if sinceRef == "" {
+		// without a previous release every commit back to the root is in
+		// range, and its first commit "adds" every dependency.
+		log.Debug("dependency timeline skipped: no since ref to compare against")
+		return nil, nil
+	}
+
+	parsers := selectedParsers(cfg.Ecosystems)
+	if len(parsers) == 0 {
+		return nil, nil
+	}
+	m := newMatcher(parsers, cfg)
+
+	commits, err := gitter.CommitsBetweenWithMeta(git.Range{
+		SinceRef:   sinceRef,
+		UntilRef:   untilRef,
+		IncludeEnd: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := Timeline{}
+	for _, c := range firstParentLine(commits) {
+		files, err := gitter.FileChangesInCommit(c.Hash, m.match)
+		if err != nil {
+			log.WithFields("error", err, "commit", c.Hash).Debug("unable to read manifest changes for the dependency timeline")
+			continue
+		}
+		if changes := m.diff(c.Hash, files, cfg.Comparer); len(changes) > 0 {
+			out[c.Hash] = changes
+		}
+	}
+	return out, nil
+}
+
+// firstParentLine returns the commits on the first-parent line from the newest
+// commit in range, in the order given (newest first). The walk stops at the
+// first commit that is out of range.
+func firstParentLine(commits []git.Commit) []git.Commit {
+	if len(commits) == 0 {
+		return nil
+	}
+	byHash := make(map[string]git.Commit, len(commits))
+	for _, c := range commits {
+		byHash[c.Hash] = c
+	}
+
+	var out []git.Commit
+	for c, ok := commits[0], true; ok; {
+		out = append(out, c)
+		if len(c.Parents) == 0 {
+			break
+		}
+		c, ok = byHash[c.Parents[0]]
+	}
+	return out
+}
+
+// Attach sets each trunk commit's Dependencies from the timeline and returns
+// how many commits got any.
+func (t Timeline) Attach(trunk *release.TrunkData) int {
+	if trunk == nil || len(t) == 0 {
+		return 0
+	}
+	var n int
+	for i := range trunk.Commits {
+		if changes, ok := t[trunk.Commits[i].Hash]; ok {
+			trunk.Commits[i].Dependencies = changes
+			n++
+		}
+	}
+	return n
+}
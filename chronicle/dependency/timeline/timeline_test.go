@@ -0,0 +1,149 @@
+package timeline
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/mod/semver"
+
+	"github.com/anchore/chronicle/chronicle/dependency"
+	"github.com/anchore/chronicle/chronicle/release"
+	"github.com/anchore/chronicle/internal/git"
+)
+
+// semverComparer orders go module versions; enough for the fixtures here.
+type semverComparer struct{}
+
+func (semverComparer) Compare(_, a, b string) (int, bool) {
+	if !semver.IsValid(a) || !semver.IsValid(b) {
+		return 0, false
+	}
+	return semver.Compare(a, b), true
+}
+
+func goMod(requires ...string) []byte {
+	s := "module example.com/app\n\ngo 1.22\n\nrequire (\n"
+	for _, r := range requires {
+		s += "\t" + r + "\n"
+	}
+	return []byte(s + ")\n")
+}
+
+func TestBuild(t *testing.T) {
+	// history, newest first:
+	//
+	//	m4  merge of the feature branch (b1, b2) into trunk
+	//	c3  downgrades x/net
+	//	c2  README only
+	//	c1  bumps x/net, adds uuid
+	//	v1  the previous release (out of range)
+	gitter := git.MockInterface{
+		MockCommitsBetweenWithMeta: []git.Commit{
+			{Hash: "m4", Parents: []string{"c3", "b2"}},
+			{Hash: "b2", Parents: []string{"b1"}},
+			{Hash: "b1", Parents: []string{"c1"}},
+			{Hash: "c3", Parents: []string{"c2"}},
+			{Hash: "c2", Parents: []string{"c1"}},
+			{Hash: "c1", Parents: []string{"v1"}},
+		},
+		MockFileChanges: map[string][]git.FileChange{
+			"c1": {{
+				Path:   "go.mod",
+				Before: goMod("golang.org/x/net v0.20.0"),
+				After:  goMod("golang.org/x/net v0.23.0", "github.com/google/uuid v1.6.0"),
+			}},
+			"c2": {{Path: "README.md", Before: []byte("a"), After: []byte("b")}},
+			"c3": {{
+				Path:   "go.mod",
+				Before: goMod("golang.org/x/net v0.23.0", "github.com/google/uuid v1.6.0"),
+				After:  goMod("golang.org/x/net v0.22.0", "github.com/google/uuid v1.6.0"),
+			}},
+			// the branch's own commits are only credited through the merge
+			"b1": {{Path: "go.mod", Before: goMod("golang.org/x/net v0.23.0"), After: goMod("golang.org/x/net v0.24.0")}},
+			"m4": {
+				{Path: "go.mod", Before: goMod("golang.org/x/net v0.22.0", "github.com/google/uuid v1.6.0"), After: goMod("golang.org/x/net v0.24.0")},
+				// a vendored manifest is ignored, and an unparsable one is skipped
+				{Path: "vendor/go.mod", Before: nil, After: goMod("example.com/vendored v1.0.0")},
+				{Path: "package-lock.json", Before: []byte("{"), After: []byte(`{"packages": {"node_modules/a": {"version": "1.0.0"}}}`)},
+			},
+		},
+	}
+
+	got, err := Build(gitter, Config{Enabled: true, Ignore: []string{"./vendor"}, Recursive: true, Comparer: semverComparer{}}, "v1", "HEAD")
+	require.NoError(t, err)
+
+	want := Timeline{
+		"c1": {
+			{Name: "github.com/google/uuid", Type: "go-module", ToVersion: "v1.6.0", Kind: dependency.Added},
+			{Name: "golang.org/x/net", Type: "go-module", FromVersion: "v0.20.0", ToVersion: "v0.23.0", Kind: dependency.Updated},
+		},
+		"c3": {
+			{Name: "golang.org/x/net", Type: "go-module", FromVersion: "v0.23.0", ToVersion: "v0.22.0", Kind: dependency.Downgraded},
+		},
+		"m4": {
+			{Name: "github.com/google/uuid", Type: "go-module", FromVersion: "v1.6.0", Kind: dependency.Removed},
+			{Name: "golang.org/x/net", Type: "go-module", FromVersion: "v0.22.0", ToVersion: "v0.24.0", Kind: dependency.Updated},
+		},
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("timeline mismatch (-want +got):\n%s", d)
+	}
+}
+
+func TestBuild_Disabled(t *testing.T) {
+	got, err := Build(git.MockInterface{}, Config{}, "v1", "HEAD")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	// no previous release: nothing to place changes against
+	got, err = Build(git.MockInterface{}, Config{Enabled: true}, "", "HEAD")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestMatcher(t *testing.T) {
+	root := newMatcher(registry, Config{Ignore: []string{"**/testdata/**"}})
+	assert.True(t, root.match("go.mod"))
+	assert.True(t, root.match("requirements-dev.txt"))
+	assert.False(t, root.match("tools/go.mod"), "subdirectories need recursive")
+	assert.False(t, root.match("package.json"), "ranges say nothing about the installed version")
+
+	rec := newMatcher(registry, Config{Ignore: []string{"**/testdata/**", "./examples"}, Recursive: true})
+	assert.True(t, rec.match("tools/go.mod"))
+	assert.False(t, rec.match("internal/testdata/go.mod"))
+	assert.False(t, rec.match("examples/app/Cargo.lock"))
+
+	// the scan's excludes prune as they do for the scan: a bare "vendor" is
+	// not a pattern syft accepts, so it prunes nothing
+	excl := newMatcher(registry, Config{Exclude: []string{"./tools", "**/fixtures", "vendor"}, Recursive: true})
+	assert.False(t, excl.match("tools/go.mod"))
+	assert.False(t, excl.match("pkg/fixtures/go.mod"))
+	assert.True(t, excl.match("vendor/go.mod"))
+	assert.True(t, excl.match("pkg/go.mod"))
+
+	// a workspace reads the root and its members' own manifests, whatever Recursive says
+	ws := newMatcher(registry, Config{Members: []string{"api", "libs/core"}})
+	assert.True(t, ws.match("go.mod"))
+	assert.True(t, ws.match("api/go.mod"))
+	assert.True(t, ws.match("libs/core/Cargo.lock"))
+	assert.False(t, ws.match("tools/go.mod"), "not a member")
+	assert.False(t, ws.match("api/testdata/go.mod"), "below a member")
+	assert.False(t, newMatcher(registry, Config{Members: []string{"api"}, Exclude: []string{"./api"}}).match("api/go.mod"))
+
+	goOnly := newMatcher(selectedParsers([]dependency.Ecosystem{dependency.EcosystemGo}), Config{})
+	assert.True(t, goOnly.match("go.mod"))
+	assert.False(t, goOnly.match("Cargo.lock"))
+}
+
+func TestTimeline_Attach(t *testing.T) {
+	change := dependency.PackageChange{Name: "a", Type: "npm", ToVersion: "1.0.0", Kind: dependency.Added}
+	trunk := &release.TrunkData{Commits: []release.TrunkCommit{{Hash: "c2"}, {Hash: "c1"}}}
+
+	assert.Equal(t, 1, Timeline{"c1": {change}, "gone": {change}}.Attach(trunk))
+	assert.Nil(t, trunk.Commits[0].Dependencies)
+	assert.Equal(t, []dependency.PackageChange{change}, trunk.Commits[1].Dependencies)
+
+	assert.Equal(t, 0, Timeline{"c1": {change}}.Attach(nil))
+}
@@ -15,6 +15,13 @@ type Config struct {
 	// Recursive controls discovery depth. When false (default), only root-level manifests are
 	// considered; any path in a subdirectory is ignored regardless of the per-ecosystem globs.
 	Recursive bool
+	// Members maps a ref to the workspace member directories declared at it (see
+	// workspace.Discover). At a ref with an entry, discovery reads the manifests at the root and
+	// directly in those members, in place of the Recursive setting. Nil reads every ref by
+	// Recursive.
+	Members map[string][]string
+	// GoMod selects the go.mod directives DetectGoMod reports. All false means it is a no-op.
+	GoMod GoModConfig
 }
 
 // DefaultIgnore returns the path globs excluded from toolchain source discovery by default, so a
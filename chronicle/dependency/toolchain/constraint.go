@@ -0,0 +1,122 @@
+package toolchain
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/anchore/chronicle/internal"
+)
+
+// Most ecosystems declare their toolchain requirement as a constraint range rather than a single
+// version (e.g. ">=3.9,<4" in pyproject.toml, "^18 || >=20" in package.json, ">= 2.7, < 4" in a
+// gemspec). What a changelog reader cares about is the minimum the project now demands, so these
+// helpers reduce a constraint to the lowest version it admits and order constraints by that.
+
+// compareMinimums orders two constraints by the lowest version each admits. ok is false when either
+// constraint has no lower bound (e.g. "<4" or "*"), since then the direction is unknown. Two
+// constraints with the same minimum compare equal even if their upper bounds differ.
+func compareMinimums(from, to string) (int, bool) {
+	fv, ok := minimumVersion(from)
+	if !ok {
+		return 0, false
+	}
+	tv, ok := minimumVersion(to)
+	if !ok {
+		return 0, false
+	}
+	return internal.CompareVersionSegments(tv, fv), true
+}
+
+// minimumVersion returns the lowest version a constraint admits. Clauses within an alternative are
+// separated by commas or whitespace and must all hold, so the alternative's minimum is its highest
+// lower bound; alternatives are separated by "||" and the constraint's minimum is the lowest of
+// theirs. Lower bounds come from >=, >, ==, =, ~=, ~>, ^, ~, bare versions and the start of a
+// hyphen range; upper bounds and exclusions (<, <=, !=, the end of a hyphen range) are ignored.
+func minimumVersion(constraint string) ([]int, bool) {
+	var best []int
+	for _, alt := range strings.Split(constraint, "||") {
+		v, ok := alternativeMinimum(alt)
+		if !ok {
+			continue
+		}
+		if best == nil || internal.CompareVersionSegments(v, best) < 0 {
+			best = v
+		}
+	}
+	return best, best != nil
+}
+
+func alternativeMinimum(alt string) ([]int, bool) {
+	var best []int
+	for _, clause := range constraintClauses(alt) {
+		v, ok := lowerBound(clause)
+		if !ok {
+			continue
+		}
+		if best == nil || internal.CompareVersionSegments(v, best) > 0 {
+			best = v
+		}
+	}
+	return best, best != nil
+}
+
+// constraintClauses splits one alternative into clauses, rejoining an operator separated from its
+// version by whitespace (">= 2.7" is one clause, not two). An npm hyphen range "A - B" reads as
+// ">=A <=B", so its upper end isn't mistaken for a bare lower bound.
+func constraintClauses(alt string) []string {
+	fields := strings.FieldsFunc(alt, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })
+	var out []string
+	for i := 0; i < len(fields); i++ {
+		f := fields[i]
+		if f == "-" && i+1 < len(fields) {
+			out = append(out, "<="+fields[i+1])
+			i++
+			continue
+		}
+		if strings.Trim(f, "<>=!~^") == "" && i+1 < len(fields) {
+			f += fields[i+1]
+			i++
+		}
+		out = append(out, f)
+	}
+	return out
+}
+
+func lowerBound(clause string) ([]int, bool) {
+	for _, op := range []string{"<=", "!=", "<"} {
+		if strings.HasPrefix(clause, op) {
+			return nil, false
+		}
+	}
+	for _, op := range []string{">=", "==", "~=", "~>", ">", "=", "^", "~"} {
+		if strings.HasPrefix(clause, op) {
+			clause = strings.TrimPrefix(clause, op)
+			break
+		}
+	}
+	return parseVersion(clause)
+}
+
+// parseVersion reads the leading numeric segments of a dotted version ("3.9", "v1.70.0", "18.x",
+// "3.0.0-rc1"). Parsing stops at the first wildcard or non-numeric segment; ok is false when there
+// is no numeric segment at all.
+func parseVersion(s string) ([]int, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	var out []int
+	for _, seg := range strings.Split(s, ".") {
+		digits := seg
+		if i := strings.IndexFunc(seg, func(r rune) bool { return r < '0' || r > '9' }); i >= 0 {
+			digits = seg[:i]
+		}
+		n, err := strconv.Atoi(digits)
+		if err != nil {
+			break
+		}
+		out = append(out, n)
+		if digits != seg {
+			// a suffix such as "-rc1" ends the numeric part of the version.
+			break
+		}
+	}
+	return out, len(out) > 0
+}
@@ -0,0 +1,70 @@
+package toolchain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMinimumVersion(t *testing.T) {
+	tests := []struct {
+		constraint string
+		want       []int
+		wantOK     bool
+	}{
+		{constraint: "3.9", want: []int{3, 9}, wantOK: true},
+		{constraint: ">=3.9,<4", want: []int{3, 9}, wantOK: true},
+		{constraint: ">= 2.7, < 4", want: []int{2, 7}, wantOK: true},
+		{constraint: "~=3.10", want: []int{3, 10}, wantOK: true},
+		{constraint: "~> 3.1", want: []int{3, 1}, wantOK: true},
+		{constraint: "^18.18 || >=20", want: []int{18, 18}, wantOK: true},
+		{constraint: ">=20 || ^18", want: []int{18}, wantOK: true},
+		{constraint: ">=18.0.0 <21", want: []int{18, 0, 0}, wantOK: true},
+		{constraint: ">=3.8, >=3.9", want: []int{3, 9}, wantOK: true},
+		{constraint: "18 - 20", want: []int{18}, wantOK: true},
+		{constraint: "16.14.0 - 18 || >=20", want: []int{16, 14, 0}, wantOK: true},
+		{constraint: "18.x", want: []int{18}, wantOK: true},
+		{constraint: "v1.70", want: []int{1, 70}, wantOK: true},
+		{constraint: "3.0.0-rc1", want: []int{3, 0, 0}, wantOK: true},
+		{constraint: "<4", wantOK: false},
+		{constraint: "*", wantOK: false},
+		{constraint: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			got, ok := minimumVersion(tt.constraint)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCompareMinimums(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		wantCmp int
+		wantOK  bool
+	}{
+		{name: "raised minimum", from: ">=3.9,<4", to: ">=3.10,<4", wantCmp: 1, wantOK: true},
+		{name: "lowered minimum", from: ">=3.10", to: ">=3.8", wantCmp: -1, wantOK: true},
+		{name: "only the upper bound moved", from: ">=3.9,<4", to: ">=3.9,<5", wantCmp: 0, wantOK: true},
+		{name: "trailing zeros are equal", from: ">=3.9", to: ">=3.9.0", wantCmp: 0, wantOK: true},
+		{name: "dropped an old alternative", from: "^16 || ^18", to: "^18 || ^20", wantCmp: 1, wantOK: true},
+		{name: "hyphen range upper end moved", from: "18 - 20", to: "18 - 22", wantCmp: 0, wantOK: true},
+		{name: "no lower bound", from: "<4", to: ">=3.9", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmp, ok := compareMinimums(tt.from, tt.to)
+			assert.Equal(t, tt.wantOK, ok)
+			if !ok {
+				return
+			}
+			assert.Equal(t, tt.wantCmp, cmp)
+		})
+	}
+}
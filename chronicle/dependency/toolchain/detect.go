@@ -39,19 +39,21 @@ func Detect(gitter fileLister, cfg Config, sinceRef, untilRef string) (*release.
 
 	m := newMatcher(detectors, cfg)
 
-	sinceFiles, err := gitter.ListFilesAtRef(sinceRef, m.match)
+	sinceM, untilM := m.at(cfg, sinceRef), m.at(cfg, untilRef)
+
+	sinceFiles, err := gitter.ListFilesAtRef(sinceRef, sinceM.match)
 	if err != nil {
 		log.WithFields("error", err, "ref", sinceRef).Warn("toolchain detection: unable to list files at since ref; skipping")
 		return nil, nil
 	}
-	untilFiles, err := gitter.ListFilesAtRef(untilRef, m.match)
+	untilFiles, err := gitter.ListFilesAtRef(untilRef, untilM.match)
 	if err != nil {
 		log.WithFields("error", err, "ref", untilRef).Warn("toolchain detection: unable to list files at until ref; skipping")
 		return nil, nil
 	}
 
-	since := extractRequirements(m, sinceFiles)
-	until := extractRequirements(m, untilFiles)
+	since := extractRequirements(sinceM, sinceFiles)
+	until := extractRequirements(untilM, untilFiles)
 
 	byTool := make(map[dependency.Ecosystem]Detector, len(detectors))
 	for _, d := range detectors {
@@ -63,6 +63,19 @@ func TestDetect(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:       "constraint range bump in another ecosystem",
+			cfg:        baseCfg(),
+			since:      "v1",
+			until:      "v2",
+			sinceFiles: []git.FileBlob{{Path: "pyproject.toml", Content: []byte("[project]\nrequires-python = \">=3.9,<4\"\n")}},
+			untilFiles: []git.FileBlob{{Path: "pyproject.toml", Content: []byte("[project]\nrequires-python = \">=3.10,<4\"\n")}},
+			want: &release.ToolchainData{
+				Updates: []release.ToolchainUpdate{
+					{Tool: "python", Source: "requires-python", File: "pyproject.toml", From: ">=3.9,<4", To: ">=3.10,<4", Direction: release.ToolchainUpgrade},
+				},
+			},
+		},
 		{
 			name:       "downgrade is flagged",
 			cfg:        baseCfg(),
@@ -214,6 +227,30 @@ func TestDetect(t *testing.T) {
 			},
 			want: nil,
 		},
+		{
+			name: "workspace members replace the recursive setting",
+			cfg: func() Config {
+				c := baseCfg()
+				c.Recursive = false
+				c.Members = map[string][]string{"v1": {"api"}, "v2": {"api"}}
+				return c
+			}(),
+			since: "v1",
+			until: "v2",
+			sinceFiles: []git.FileBlob{
+				{Path: "api/go.mod", Content: goMod("1.21")},
+				{Path: ".make/go.mod", Content: goMod("1.21")},
+			},
+			untilFiles: []git.FileBlob{
+				{Path: "api/go.mod", Content: goMod("1.22")},
+				{Path: ".make/go.mod", Content: goMod("1.23")}, // tooling, not a member
+			},
+			want: &release.ToolchainData{
+				Updates: []release.ToolchainUpdate{
+					{Tool: "go", Source: "go directive", File: "api/go.mod", From: "1.21", To: "1.22", Direction: release.ToolchainUpgrade},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
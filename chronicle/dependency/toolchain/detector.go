@@ -13,6 +13,11 @@ func register(d Detector) {
 
 func init() {
 	register(goDetector{})
+	register(nodeDetector{})
+	register(pythonDetector{})
+	register(javaDetector{})
+	register(rubyDetector{})
+	register(rustDetector{})
 }
 
 // requirement is a single declared toolchain requirement extracted from one source file.
@@ -0,0 +1,187 @@
+package toolchain
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDetectors_Requirement runs each non-go detector over its fixtures in testdata/<ecosystem>.
+// The path handed to the detector is the file's base name (as a repo-root manifest would be), so
+// detectors that dispatch on file name see what they would in a real tree.
+func TestDetectors_Requirement(t *testing.T) {
+	tests := []struct {
+		detector Detector
+		fixture  string
+		want     []requirement
+		wantErr  require.ErrorAssertionFunc
+	}{
+		{
+			detector: pythonDetector{},
+			fixture:  "python/pyproject.toml",
+			want:     []requirement{{source: "requires-python", version: ">=3.9,<4"}},
+		},
+		{
+			detector: pythonDetector{},
+			fixture:  "python/poetry.toml",
+			want:     []requirement{{source: "poetry python dependency", version: "^3.10"}},
+		},
+		{
+			detector: pythonDetector{},
+			fixture:  "python/poetry-table.toml",
+			want:     []requirement{{source: "poetry python dependency", version: "~=3.11"}},
+		},
+		{
+			detector: pythonDetector{},
+			fixture:  "python/none.toml",
+			want:     nil,
+		},
+		{
+			detector: nodeDetector{},
+			fixture:  "node/package.json",
+			want:     []requirement{{source: "engines.node", version: "^18.18 || >=20"}},
+		},
+		{
+			detector: nodeDetector{},
+			fixture:  "node/no-engines.json",
+			want:     nil,
+		},
+		{
+			detector: nodeDetector{},
+			fixture:  "node/legacy-engines.json",
+			want:     nil,
+		},
+		{
+			detector: rustDetector{},
+			fixture:  "rust/Cargo.toml",
+			want:     []requirement{{source: "rust-version", version: "1.70"}},
+		},
+		{
+			detector: rustDetector{},
+			fixture:  "rust/workspace.toml",
+			want:     []requirement{{source: "workspace rust-version", version: "1.74.0"}},
+		},
+		{
+			detector: rustDetector{},
+			fixture:  "rust/member.toml",
+			want:     nil,
+		},
+		{
+			// the plugin setting is reported alongside the property it overrides, so a change to
+			// either shows up.
+			detector: javaDetector{},
+			fixture:  "java/pom.xml",
+			want: []requirement{
+				{source: "maven.compiler.release", version: "17"},
+				{source: "maven-compiler-plugin release", version: "21"},
+			},
+		},
+		{
+			detector: javaDetector{},
+			fixture:  "java/legacy-pom.xml",
+			want:     []requirement{{source: "maven.compiler.target", version: "1.8"}},
+		},
+		{
+			detector: javaDetector{},
+			fixture:  "java/build.gradle",
+			want:     []requirement{{source: "gradle toolchain languageVersion", version: "17"}},
+		},
+		{
+			detector: javaDetector{},
+			fixture:  "java/build.gradle.kts",
+			want:     []requirement{{source: "gradle jvmToolchain", version: "21"}},
+		},
+		{
+			detector: rubyDetector{},
+			fixture:  "ruby/example.gemspec",
+			want:     []requirement{{source: "required_ruby_version", version: ">= 2.7, < 4"}},
+		},
+		{
+			detector: rubyDetector{},
+			fixture:  "ruby/requirement.gemspec",
+			want:     []requirement{{source: "required_ruby_version", version: ">= 3.1"}},
+		},
+		{
+			detector: rubyDetector{},
+			fixture:  "ruby/none.gemspec",
+			want:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			if tt.wantErr == nil {
+				tt.wantErr = require.NoError
+			}
+			content, err := os.ReadFile(filepath.Join("testdata", tt.fixture))
+			require.NoError(t, err)
+
+			got, err := tt.detector.Requirement(filepath.Base(tt.fixture), content)
+			tt.wantErr(t, err)
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got, cmp.AllowUnexported(requirement{})); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDetectors_RequirementErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		detector Detector
+		path     string
+		content  string
+	}{
+		{name: "malformed pyproject", detector: pythonDetector{}, path: "pyproject.toml", content: "[project\n"},
+		{name: "malformed package.json", detector: nodeDetector{}, path: "package.json", content: "{"},
+		{name: "malformed Cargo.toml", detector: rustDetector{}, path: "Cargo.toml", content: "rust-version = \n"},
+		{name: "malformed pom.xml", detector: javaDetector{}, path: "pom.xml", content: "<project><properties>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.detector.Requirement(tt.path, []byte(tt.content))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestDetectors_Compare(t *testing.T) {
+	tests := []struct {
+		name     string
+		detector Detector
+		from     string
+		to       string
+		wantCmp  int
+		wantOK   bool
+	}{
+		{name: "python raised minimum", detector: pythonDetector{}, from: ">=3.9,<4", to: ">=3.10,<4", wantCmp: 1, wantOK: true},
+		{name: "python poetry caret", detector: pythonDetector{}, from: "^3.10", to: "^3.8", wantCmp: -1, wantOK: true},
+		{name: "python upper bound only", detector: pythonDetector{}, from: ">=3.9,<4", to: ">=3.9,<5", wantCmp: 0, wantOK: true},
+		{name: "node dropped old major", detector: nodeDetector{}, from: "^16 || ^18", to: "^18 || >=20", wantCmp: 1, wantOK: true},
+		{name: "node opaque", detector: nodeDetector{}, from: "*", to: ">=18", wantOK: false},
+		{name: "rust upgrade", detector: rustDetector{}, from: "1.70", to: "1.74.0", wantCmp: 1, wantOK: true},
+		{name: "rust not a version", detector: rustDetector{}, from: "1.70", to: "stable", wantOK: false},
+		{name: "java legacy to modern", detector: javaDetector{}, from: "1.8", to: "11", wantCmp: 1, wantOK: true},
+		{name: "java downgrade", detector: javaDetector{}, from: "21", to: "17", wantCmp: -1, wantOK: true},
+		{name: "ruby pessimistic", detector: rubyDetector{}, from: ">= 2.7, < 4", to: "~> 3.1", wantCmp: 1, wantOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmp, ok := tt.detector.Compare(tt.from, tt.to)
+			assert.Equal(t, tt.wantOK, ok)
+			if !ok {
+				return
+			}
+			assert.Equal(t, tt.wantCmp, cmp)
+		})
+	}
+}
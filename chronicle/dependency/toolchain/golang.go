@@ -9,7 +9,7 @@ import (
 
 // goDetector reads the minimum Go version declared by the `go` directive in a go.mod file. The
 // `toolchain` directive (a pinned toolchain) is intentionally ignored — only the declared minimum
-// is reported.
+// is reported here; DetectGoMod reports the toolchain directive as a separate, optional line.
 type goDetector struct{}
 
 func (goDetector) Tool() dependency.Ecosystem { return dependency.EcosystemGo }
@@ -0,0 +1,227 @@
+package toolchain
+
+import (
+	"slices"
+	"sort"
+
+	"golang.org/x/mod/modfile"
+
+	"github.com/anchore/chronicle/chronicle/dependency"
+	"github.com/anchore/chronicle/chronicle/release"
+	"github.com/anchore/chronicle/internal/git"
+	"github.com/anchore/chronicle/internal/log"
+)
+
+// GoModConfig selects which go.mod directives the insight detector reports. All of them only
+// take effect when the module is built as the main module, which is exactly why reviewers want to
+// see them: a replace that works in this repo does nothing (or breaks) for a consumer.
+type GoModConfig struct {
+	// Replace reports replace directives that were added, removed or retargeted.
+	Replace bool
+	// Retract reports retract statements added for the module's own versions.
+	Retract bool
+	// Godebug reports godebug settings that were added, removed or changed.
+	Godebug bool
+	// Toolchain reports changes to the toolchain directive (the pinned toolchain), which the
+	// toolchain rollup ignores in favor of the go directive's minimum.
+	Toolchain bool
+}
+
+func (c GoModConfig) enabled() bool {
+	return c.Replace || c.Retract || c.Godebug || c.Toolchain
+}
+
+// DetectGoMod reads the go.mod files at sinceRef and untilRef and reports changes to their
+// replace, retract, godebug and toolchain directives, as selected by cfg.GoMod. It discovers
+// files like Detect (Go's globs, the ignore list, and the recursive setting or workspace
+// members) and degrades the same way: unparseable files are logged and skipped, listing
+// failures abort detection without an error, and a run that finds nothing returns (nil, nil).
+// Only files present at both refs are compared, so a module added or removed in the range
+// doesn't report every directive it carries.
+func DetectGoMod(gitter fileLister, cfg Config, sinceRef, untilRef string) (*release.GoModData, error) {
+	if !cfg.Enabled || !cfg.GoMod.enabled() {
+		return nil, nil
+	}
+	if sinceRef == "" {
+		log.Debug("go.mod insight detection skipped: no since ref to compare against")
+		return nil, nil
+	}
+	if !slices.ContainsFunc(selectedDetectors(cfg.Ecosystems), func(d Detector) bool { return d.Tool() == dependency.EcosystemGo }) {
+		return nil, nil
+	}
+
+	m := newMatcher([]Detector{goDetector{}}, cfg)
+
+	sinceM, untilM := m.at(cfg, sinceRef), m.at(cfg, untilRef)
+
+	sinceFiles, err := gitter.ListFilesAtRef(sinceRef, sinceM.match)
+	if err != nil {
+		log.WithFields("error", err, "ref", sinceRef).Warn("go.mod insight detection: unable to list files at since ref; skipping")
+		return nil, nil
+	}
+	untilFiles, err := gitter.ListFilesAtRef(untilRef, untilM.match)
+	if err != nil {
+		log.WithFields("error", err, "ref", untilRef).Warn("go.mod insight detection: unable to list files at until ref; skipping")
+		return nil, nil
+	}
+
+	since, until := readGoMods(sinceFiles), readGoMods(untilFiles)
+	paths := make([]string, 0, len(until))
+	for p := range until {
+		if _, ok := since[p]; ok {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+
+	data := &release.GoModData{}
+	for _, p := range paths {
+		data.Changes = append(data.Changes, diffGoMod(p, since[p], until[p], cfg.GoMod)...)
+	}
+	if len(data.Changes) == 0 {
+		return nil, nil
+	}
+	return data, nil
+}
+
+// goModDirectives holds the main-module-only statements of one go.mod, keyed for diffing.
+type goModDirectives struct {
+	replace   map[string]string // replaced module (path, plus version when set) -> replacement
+	retract   map[string]string // retracted version or range -> rationale
+	godebug   map[string]string // setting -> value
+	toolchain string
+}
+
+func readGoMods(files []git.FileBlob) map[string]*goModDirectives {
+	out := make(map[string]*goModDirectives, len(files))
+	for _, f := range files {
+		d, err := readGoMod(f.Path, f.Content)
+		if err != nil {
+			log.WithFields("error", err, "file", f.Path).Debug("go.mod insight detection: unable to parse file; skipping")
+			continue
+		}
+		out[f.Path] = d
+	}
+	return out
+}
+
+func readGoMod(path string, content []byte) (*goModDirectives, error) {
+	// unlike goDetector this parses strictly: ParseLax reads a go.mod the way the go command reads
+	// a dependency's, and drops exactly the main-module-only directives we are after.
+	f, err := modfile.Parse(path, content, nil)
+	if err != nil {
+		return nil, err
+	}
+	d := &goModDirectives{
+		replace: make(map[string]string, len(f.Replace)),
+		retract: make(map[string]string, len(f.Retract)),
+		godebug: make(map[string]string, len(f.Godebug)),
+	}
+	for _, r := range f.Replace {
+		d.replace[moduleString(r.Old.Path, r.Old.Version)] = moduleString(r.New.Path, r.New.Version)
+	}
+	for _, r := range f.Retract {
+		d.retract[retractKey(r.VersionInterval)] = r.Rationale
+	}
+	for _, g := range f.Godebug {
+		d.godebug[g.Key] = g.Value
+	}
+	if f.Toolchain != nil {
+		d.toolchain = f.Toolchain.Name
+	}
+	return d, nil
+}
+
+// moduleString renders a module the way go.mod writes it on either side of a replace: the path,
+// then the version when there is one (a directory replacement has none).
+func moduleString(path, version string) string {
+	if version == "" {
+		return path
+	}
+	return path + " " + version
+}
+
+func retractKey(v modfile.VersionInterval) string {
+	if v.Low == v.High {
+		return v.Low
+	}
+	return "[" + v.Low + ", " + v.High + "]"
+}
+
+// diffGoMod compares one go.mod's directives across the two refs, in go.mod order by directive
+// and sorted by key within each.
+func diffGoMod(file string, since, until *goModDirectives, cfg GoModConfig) []release.GoModChange {
+	var out []release.GoModChange
+	if cfg.Replace {
+		for _, c := range diffDirective(release.GoModReplace, file, since.replace, until.replace) {
+			// a removed replace is flagged too: dropping a local-path replace is the fix for one
+			value := c.To
+			if c.Kind == release.GoModRemoved {
+				value = c.From
+			}
+			c.LocalPath = modfile.IsDirectoryPath(value)
+			out = append(out, c)
+		}
+	}
+	if cfg.Retract {
+		// only new retractions are news; un-retracting a version is rare and reads as a fix-up.
+		for _, c := range diffDirective(release.GoModRetract, file, since.retract, until.retract) {
+			if c.Kind != release.GoModAdded {
+				continue
+			}
+			c.Rationale, c.To = c.To, ""
+			out = append(out, c)
+		}
+	}
+	if cfg.Godebug {
+		out = append(out, diffDirective(release.GoModGodebug, file, since.godebug, until.godebug)...)
+	}
+	if cfg.Toolchain {
+		out = append(out, diffDirective(release.GoModToolchain, file, single(since.toolchain), single(until.toolchain))...)
+	}
+	return out
+}
+
+// single keys a directive that appears at most once, so it diffs like the repeatable ones.
+func single(value string) map[string]string {
+	if value == "" {
+		return nil
+	}
+	return map[string]string{"": value}
+}
+
+// diffDirective reports each key added, removed or given a different value between the two
+// maps, sorted by key.
+func diffDirective(directive release.GoModDirective, file string, since, until map[string]string) []release.GoModChange {
+	keys := make(map[string]bool, len(since)+len(until))
+	for k := range since {
+		keys[k] = true
+	}
+	for k := range until {
+		keys[k] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	var out []release.GoModChange
+	for _, k := range sorted {
+		from, wasSet := since[k]
+		to, isSet := until[k]
+		c := release.GoModChange{Directive: directive, File: file, Key: k, From: from, To: to}
+		switch {
+		case !wasSet:
+			c.Kind = release.GoModAdded
+		case !isSet:
+			c.Kind = release.GoModRemoved
+		case from != to:
+			c.Kind = release.GoModChanged
+		default:
+			continue
+		}
+		out = append(out, c)
+	}
+	return out
+}
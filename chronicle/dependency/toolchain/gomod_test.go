@@ -0,0 +1,176 @@
+package toolchain
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/chronicle/chronicle/dependency"
+	"github.com/anchore/chronicle/chronicle/release"
+	"github.com/anchore/chronicle/internal/git"
+)
+
+func goModWith(directives string) []byte {
+	return []byte("module example.com/foo\n\ngo 1.22\n\n" + directives)
+}
+
+func TestDetectGoMod(t *testing.T) {
+	allCfg := func() Config {
+		return Config{
+			Enabled:   true,
+			Recursive: true,
+			Ignore:    []string{"**/testdata/**"},
+			GoMod:     GoModConfig{Replace: true, Retract: true, Godebug: true, Toolchain: true},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		cfg        Config
+		since      string
+		sinceFiles []git.FileBlob
+		untilFiles []git.FileBlob
+		want       *release.GoModData
+	}{
+		{
+			name:  "disabled is a no-op",
+			cfg:   Config{Enabled: true},
+			since: "v1",
+			sinceFiles: []git.FileBlob{
+				{Path: "go.mod", Content: goModWith("")},
+			},
+			untilFiles: []git.FileBlob{
+				{Path: "go.mod", Content: goModWith("replace example.com/a => ../a\n")},
+			},
+			want: nil,
+		},
+		{
+			name:  "no since ref skips detection",
+			cfg:   allCfg(),
+			since: "",
+			want:  nil,
+		},
+		{
+			name: "go not among the ecosystems",
+			cfg: func() Config {
+				c := allCfg()
+				c.Ecosystems = []dependency.Ecosystem{dependency.EcosystemPython}
+				return c
+			}(),
+			since: "v1",
+			sinceFiles: []git.FileBlob{
+				{Path: "go.mod", Content: goModWith("")},
+			},
+			untilFiles: []git.FileBlob{
+				{Path: "go.mod", Content: goModWith("replace example.com/a => ../a\n")},
+			},
+			want: nil,
+		},
+		{
+			name:  "replace directives",
+			cfg:   allCfg(),
+			since: "v1",
+			sinceFiles: []git.FileBlob{
+				{Path: "go.mod", Content: goModWith("replace (\n\texample.com/gone => ../gone\n\texample.com/moved v1.0.0 => example.com/fork v1.0.0\n)\n")},
+			},
+			untilFiles: []git.FileBlob{
+				{Path: "go.mod", Content: goModWith("replace (\n\texample.com/local => ./local\n\texample.com/moved v1.0.0 => example.com/fork v1.1.0\n\texample.com/remote => example.com/remote-fork v0.2.0\n)\n")},
+			},
+			want: &release.GoModData{Changes: []release.GoModChange{
+				{Directive: release.GoModReplace, Kind: release.GoModRemoved, File: "go.mod", Key: "example.com/gone", From: "../gone", LocalPath: true},
+				{Directive: release.GoModReplace, Kind: release.GoModAdded, File: "go.mod", Key: "example.com/local", To: "./local", LocalPath: true},
+				{Directive: release.GoModReplace, Kind: release.GoModChanged, File: "go.mod", Key: "example.com/moved v1.0.0", From: "example.com/fork v1.0.0", To: "example.com/fork v1.1.0"},
+				{Directive: release.GoModReplace, Kind: release.GoModAdded, File: "go.mod", Key: "example.com/remote", To: "example.com/remote-fork v0.2.0"},
+			}},
+		},
+		{
+			name:  "only new retractions are reported",
+			cfg:   allCfg(),
+			since: "v1",
+			sinceFiles: []git.FileBlob{
+				{Path: "go.mod", Content: goModWith("retract v1.0.0 // published too early\n")},
+			},
+			untilFiles: []git.FileBlob{
+				{Path: "go.mod", Content: goModWith("retract (\n\t// corrupts the cache\n\tv1.2.0\n\t[v1.3.0, v1.3.2] // bad release tooling\n)\n")},
+			},
+			want: &release.GoModData{Changes: []release.GoModChange{
+				{Directive: release.GoModRetract, Kind: release.GoModAdded, File: "go.mod", Key: "[v1.3.0, v1.3.2]", Rationale: "bad release tooling"},
+				{Directive: release.GoModRetract, Kind: release.GoModAdded, File: "go.mod", Key: "v1.2.0", Rationale: "corrupts the cache"},
+			}},
+		},
+		{
+			name:  "godebug and toolchain",
+			cfg:   allCfg(),
+			since: "v1",
+			sinceFiles: []git.FileBlob{
+				{Path: "go.mod", Content: goModWith("toolchain go1.22.1\n\ngodebug (\n\tpanicnil=1\n\thttp2client=0\n)\n")},
+			},
+			untilFiles: []git.FileBlob{
+				{Path: "go.mod", Content: goModWith("toolchain go1.23.0\n\ngodebug (\n\tpanicnil=0\n\tx509sha1=1\n)\n")},
+			},
+			want: &release.GoModData{Changes: []release.GoModChange{
+				{Directive: release.GoModGodebug, Kind: release.GoModRemoved, File: "go.mod", Key: "http2client", From: "0"},
+				{Directive: release.GoModGodebug, Kind: release.GoModChanged, File: "go.mod", Key: "panicnil", From: "1", To: "0"},
+				{Directive: release.GoModGodebug, Kind: release.GoModAdded, File: "go.mod", Key: "x509sha1", To: "1"},
+				{Directive: release.GoModToolchain, Kind: release.GoModChanged, File: "go.mod", From: "go1.22.1", To: "go1.23.0"},
+			}},
+		},
+		{
+			name: "toolchain directive is opt-in",
+			cfg: func() Config {
+				c := allCfg()
+				c.GoMod.Toolchain = false
+				return c
+			}(),
+			since:      "v1",
+			sinceFiles: []git.FileBlob{{Path: "go.mod", Content: goModWith("")}},
+			untilFiles: []git.FileBlob{{Path: "go.mod", Content: goModWith("toolchain go1.23.0\n")}},
+			want:       nil,
+		},
+		{
+			name:  "modules are compared file by file and only when present at both refs",
+			cfg:   allCfg(),
+			since: "v1",
+			sinceFiles: []git.FileBlob{
+				{Path: "go.mod", Content: goModWith("")},
+				{Path: "tools/go.mod", Content: goModWith("")},
+				{Path: "testdata/go.mod", Content: goModWith("")},
+			},
+			untilFiles: []git.FileBlob{
+				{Path: "go.mod", Content: goModWith("")},
+				{Path: "tools/go.mod", Content: goModWith("replace example.com/foo => ../\n")},
+				{Path: "testdata/go.mod", Content: goModWith("replace example.com/foo => ../\n")},
+				{Path: "new/go.mod", Content: goModWith("replace example.com/foo => ../\n")},
+			},
+			want: &release.GoModData{Changes: []release.GoModChange{
+				{Directive: release.GoModReplace, Kind: release.GoModAdded, File: "tools/go.mod", Key: "example.com/foo", To: "../", LocalPath: true},
+			}},
+		},
+		{
+			name:       "unparseable file is skipped",
+			cfg:        allCfg(),
+			since:      "v1",
+			sinceFiles: []git.FileBlob{{Path: "go.mod", Content: goModWith("")}},
+			untilFiles: []git.FileBlob{{Path: "go.mod", Content: goModWith("replace example.com/a =>\n")}},
+			want:       nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gitter := git.MockInterface{
+				MockFilesAtRef: map[string][]git.FileBlob{
+					tt.since: tt.sinceFiles,
+					"v2":     tt.untilFiles,
+				},
+			}
+
+			got, err := DetectGoMod(gitter, tt.cfg, tt.since, "v2")
+			require.NoError(t, err)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
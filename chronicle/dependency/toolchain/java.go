@@ -0,0 +1,134 @@
+package toolchain
+
+import (
+	"bytes"
+	"encoding/xml"
+	"regexp"
+	"strings"
+
+	"github.com/anchore/chronicle/chronicle/dependency"
+	"github.com/anchore/chronicle/internal"
+)
+
+// javaDetector reads the Java release a build targets, from either build tool:
+//
+//   - pom.xml: the `maven.compiler.release` property or the maven-compiler-plugin `<release>`
+//     setting (falling back to `maven.compiler.target` for builds that predate --release).
+//   - build.gradle(.kts): the toolchain block's `JavaLanguageVersion.of(N)`, or kotlin's
+//     `jvmToolchain(N)` shorthand.
+type javaDetector struct{}
+
+var (
+	gradleLanguageVersionPattern = regexp.MustCompile(`JavaLanguageVersion\.of\(\s*["']?(\d+)["']?\s*\)`)
+	gradleJvmToolchainPattern    = regexp.MustCompile(`jvmToolchain\(\s*(\d+)\s*\)`)
+	mavenPropertyPattern         = regexp.MustCompile(`^\$\{([^}]+)\}$`)
+)
+
+type mavenPOM struct {
+	Properties struct {
+		Entries []struct {
+			XMLName xml.Name
+			Value   string `xml:",chardata"`
+		} `xml:",any"`
+	} `xml:"properties"`
+	Build struct {
+		Plugins          []mavenPlugin `xml:"plugins>plugin"`
+		PluginManagement struct {
+			Plugins []mavenPlugin `xml:"plugins>plugin"`
+		} `xml:"pluginManagement"`
+	} `xml:"build"`
+}
+
+type mavenPlugin struct {
+	ArtifactID    string `xml:"artifactId"`
+	Configuration struct {
+		Release string `xml:"release"`
+	} `xml:"configuration"`
+}
+
+func (javaDetector) Tool() dependency.Ecosystem { return dependency.EcosystemJava }
+
+func (javaDetector) DefaultPaths() []string {
+	return []string{"**/pom.xml", "**/build.gradle", "**/build.gradle.kts"}
+}
+
+func (d javaDetector) Requirement(p string, content []byte) ([]requirement, error) {
+	// dispatch on the build script suffix rather than the pom's name, so a path override such as
+	// "**/pom-*.xml" still reaches the maven parser.
+	if strings.HasSuffix(p, ".gradle") || strings.HasSuffix(p, ".gradle.kts") {
+		return d.gradle(content), nil
+	}
+	return d.maven(content)
+}
+
+func (javaDetector) maven(content []byte) ([]requirement, error) {
+	var pom mavenPOM
+	if err := xml.NewDecoder(bytes.NewReader(content)).Decode(&pom); err != nil {
+		return nil, err
+	}
+
+	props := map[string]string{}
+	for _, e := range pom.Properties.Entries {
+		props[e.XMLName.Local] = strings.TrimSpace(e.Value)
+	}
+	// resolve a single level of ${property} indirection, the usual way the release is shared
+	// (e.g. <release>${java.version}</release>); anything deeper is left out rather than guessed.
+	resolve := func(v string) string {
+		v = strings.TrimSpace(v)
+		if m := mavenPropertyPattern.FindStringSubmatch(v); m != nil {
+			return props[m[1]]
+		}
+		return v
+	}
+
+	var out []requirement
+	if v := resolve(props["maven.compiler.release"]); v != "" {
+		out = append(out, requirement{source: "maven.compiler.release", version: v})
+	} else if v := resolve(props["maven.compiler.target"]); v != "" {
+		out = append(out, requirement{source: "maven.compiler.target", version: v})
+	}
+
+	plugins := append(pom.Build.Plugins, pom.Build.PluginManagement.Plugins...)
+	for _, pl := range plugins {
+		if pl.ArtifactID != "maven-compiler-plugin" {
+			continue
+		}
+		if v := resolve(pl.Configuration.Release); v != "" {
+			out = append(out, requirement{source: "maven-compiler-plugin release", version: v})
+			break
+		}
+	}
+	return out, nil
+}
+
+func (javaDetector) gradle(content []byte) []requirement {
+	if m := gradleLanguageVersionPattern.FindSubmatch(content); m != nil {
+		return []requirement{{source: "gradle toolchain languageVersion", version: string(m[1])}}
+	}
+	if m := gradleJvmToolchainPattern.FindSubmatch(content); m != nil {
+		return []requirement{{source: "gradle jvmToolchain", version: string(m[1])}}
+	}
+	return nil
+}
+
+// Compare orders Java release numbers. The legacy "1.N" spelling (still common in
+// maven.compiler.target) is read as N, so moving from "1.8" to "11" is an upgrade.
+func (javaDetector) Compare(from, to string) (int, bool) {
+	fv, ok := javaRelease(from)
+	if !ok {
+		return 0, false
+	}
+	tv, ok := javaRelease(to)
+	if !ok {
+		return 0, false
+	}
+	return internal.CompareVersionSegments(tv, fv), true
+}
+
+func javaRelease(s string) ([]int, bool) {
+	v, ok := parseVersion(s)
+	if ok && len(v) > 1 && v[0] == 1 {
+		v = v[1:]
+	}
+	return v, ok
+}
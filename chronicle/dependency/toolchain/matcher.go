@@ -1,6 +1,10 @@
 package toolchain
 
-import "strings"
+import (
+	"strings"
+
+	"github.com/anchore/chronicle/chronicle/dependency/workspace"
+)
 
 // matcher routes file paths to the detector responsible for them, honoring per-ecosystem path
 // globs and the global ignore list. It is built once per detection run so each git ref is walked
@@ -9,6 +13,9 @@ type matcher struct {
 	ecos      []ecosystemPaths
 	ignore    []string
 	recursive bool
+	// members, when non-nil, are the workspace members declared at the ref being walked; see
+	// Config.Members.
+	members []string
 }
 
 type ecosystemPaths struct {
@@ -28,6 +35,21 @@ func newMatcher(detectors []Detector, cfg Config) *matcher {
 	return m
 }
 
+// at returns the matcher for walking ref: m itself, or a copy scoped to the ref's workspace
+// members when cfg declares some for it.
+func (m *matcher) at(cfg Config, ref string) *matcher {
+	members, ok := cfg.Members[ref]
+	if !ok {
+		return m
+	}
+	scoped := *m
+	scoped.members = members
+	if scoped.members == nil {
+		scoped.members = []string{}
+	}
+	return &scoped
+}
+
 // match reports whether a path is a discovery candidate for any configured ecosystem. It is the
 // predicate handed to the git tree walk.
 func (m *matcher) match(p string) bool {
@@ -38,9 +60,16 @@ func (m *matcher) match(p string) bool {
 // nil. An explicitly-listed path wins over an ignore glob only when it is not itself a glob match
 // against the ignore set; ignore is applied first to keep discovery quiet by default.
 func (m *matcher) detectorFor(p string) Detector {
-	// non-recursive discovery: a manifest in any subdirectory is out of scope, so reject anything
-	// below the root regardless of the per-ecosystem globs (which default to recursive "**/...").
-	if !m.recursive && strings.Contains(strings.TrimPrefix(p, "./"), "/") {
+	switch {
+	case m.members != nil:
+		// a workspace: only the root and the members' own manifests are the project's.
+		if !workspace.Contains(m.members, p) {
+			return nil
+		}
+	case !m.recursive && strings.Contains(strings.TrimPrefix(p, "./"), "/"):
+		// non-recursive discovery: a manifest in any subdirectory is out of scope, so reject
+		// anything below the root regardless of the per-ecosystem globs (which default to
+		// recursive "**/...").
 		return nil
 	}
 	if m.isIgnored(p) {
@@ -0,0 +1,40 @@
+package toolchain
+
+import (
+	"encoding/json"
+
+	"github.com/anchore/chronicle/chronicle/dependency"
+)
+
+// nodeDetector reads the `engines.node` constraint from a package.json. Other engines (npm, yarn)
+// describe the package manager rather than the runtime and are ignored.
+type nodeDetector struct{}
+
+func (nodeDetector) Tool() dependency.Ecosystem { return dependency.EcosystemJavaScript }
+
+func (nodeDetector) DefaultPaths() []string { return []string{"**/package.json"} }
+
+func (nodeDetector) Requirement(_ string, content []byte) ([]requirement, error) {
+	var pkg struct {
+		// engines is occasionally written as an array by very old packages; decode loosely.
+		Engines any `json:"engines"`
+	}
+	if err := json.Unmarshal(content, &pkg); err != nil {
+		return nil, err
+	}
+	engines, ok := pkg.Engines.(map[string]any)
+	if !ok {
+		return nil, nil
+	}
+	v, ok := engines["node"].(string)
+	if !ok || v == "" {
+		return nil, nil
+	}
+	return []requirement{{source: "engines.node", version: v}}, nil
+}
+
+// Compare orders npm semver ranges (e.g. ">=18", "^18 || ^20", ">=18.0.0 <21") by the lowest node
+// version each admits.
+func (nodeDetector) Compare(from, to string) (int, bool) {
+	return compareMinimums(from, to)
+}
@@ -0,0 +1,55 @@
+package toolchain
+
+import (
+	"github.com/BurntSushi/toml"
+
+	"github.com/anchore/chronicle/chronicle/dependency"
+)
+
+// pythonDetector reads the interpreter constraint from a pyproject.toml: the standard
+// `[project] requires-python` field, and the `python` entry poetry keeps under
+// `[tool.poetry.dependencies]` for projects that predate PEP 621.
+type pythonDetector struct{}
+
+type pyproject struct {
+	Project struct {
+		RequiresPython string `toml:"requires-python"`
+	} `toml:"project"`
+	Tool struct {
+		Poetry struct {
+			// poetry allows a table here too ({version = "^3.9"}), so decode loosely.
+			Dependencies map[string]any `toml:"dependencies"`
+		} `toml:"poetry"`
+	} `toml:"tool"`
+}
+
+func (pythonDetector) Tool() dependency.Ecosystem { return dependency.EcosystemPython }
+
+func (pythonDetector) DefaultPaths() []string { return []string{"**/pyproject.toml"} }
+
+func (pythonDetector) Requirement(_ string, content []byte) ([]requirement, error) {
+	var p pyproject
+	if err := toml.Unmarshal(content, &p); err != nil {
+		return nil, err
+	}
+
+	var out []requirement
+	if p.Project.RequiresPython != "" {
+		out = append(out, requirement{source: "requires-python", version: p.Project.RequiresPython})
+	}
+	switch v := p.Tool.Poetry.Dependencies["python"].(type) {
+	case string:
+		out = append(out, requirement{source: "poetry python dependency", version: v})
+	case map[string]any:
+		if s, ok := v["version"].(string); ok && s != "" {
+			out = append(out, requirement{source: "poetry python dependency", version: s})
+		}
+	}
+	return out, nil
+}
+
+// Compare orders PEP 440 specifiers (e.g. ">=3.9,<4", "~=3.10") and poetry constraints
+// ("^3.9") by the lowest interpreter version each admits.
+func (pythonDetector) Compare(from, to string) (int, bool) {
+	return compareMinimums(from, to)
+}
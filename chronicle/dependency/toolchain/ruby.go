@@ -0,0 +1,48 @@
+package toolchain
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/anchore/chronicle/chronicle/dependency"
+)
+
+// rubyDetector reads `required_ruby_version` from a gemspec. Gemspecs are Ruby code, so rather
+// than evaluate them we pick the quoted requirement strings off the assignment line, which covers
+// the common spellings:
+//
+//	spec.required_ruby_version = ">= 3.0"
+//	s.required_ruby_version = [">= 2.7", "< 4"]
+//	spec.required_ruby_version = Gem::Requirement.new(">= 3.1")
+type rubyDetector struct{}
+
+var (
+	rubyRequirementPattern = regexp.MustCompile(`(?m)^\s*\w+\.required_ruby_version\s*=\s*(.+)$`)
+	rubyQuotedPattern      = regexp.MustCompile(`["']([^"']+)["']`)
+)
+
+func (rubyDetector) Tool() dependency.Ecosystem { return dependency.EcosystemRuby }
+
+func (rubyDetector) DefaultPaths() []string { return []string{"**/*.gemspec"} }
+
+func (rubyDetector) Requirement(_ string, content []byte) ([]requirement, error) {
+	m := rubyRequirementPattern.FindSubmatch(content)
+	if m == nil {
+		return nil, nil
+	}
+	var parts []string
+	for _, q := range rubyQuotedPattern.FindAllSubmatch(m[1], -1) {
+		parts = append(parts, strings.TrimSpace(string(q[1])))
+	}
+	if len(parts) == 0 {
+		// assigned from a constant or method we can't resolve without evaluating the gemspec.
+		return nil, nil
+	}
+	return []requirement{{source: "required_ruby_version", version: strings.Join(parts, ", ")}}, nil
+}
+
+// Compare orders gem requirements (e.g. ">= 2.7, < 4", "~> 3.1") by the lowest ruby version each
+// admits.
+func (rubyDetector) Compare(from, to string) (int, bool) {
+	return compareMinimums(from, to)
+}
@@ -0,0 +1,53 @@
+package toolchain
+
+import (
+	"github.com/BurntSushi/toml"
+
+	"github.com/anchore/chronicle/chronicle/dependency"
+	"github.com/anchore/chronicle/internal"
+)
+
+// rustDetector reads the minimum supported Rust version from a Cargo.toml: `rust-version` under
+// `[package]`, or under `[workspace.package]` for a workspace root. A member that inherits it
+// (`rust-version.workspace = true`) declares nothing of its own and is skipped.
+type rustDetector struct{}
+
+type cargoManifest struct {
+	Package   map[string]any `toml:"package"`
+	Workspace struct {
+		Package map[string]any `toml:"package"`
+	} `toml:"workspace"`
+}
+
+func (rustDetector) Tool() dependency.Ecosystem { return dependency.EcosystemRust }
+
+func (rustDetector) DefaultPaths() []string { return []string{"**/Cargo.toml"} }
+
+func (rustDetector) Requirement(_ string, content []byte) ([]requirement, error) {
+	var m cargoManifest
+	if err := toml.Unmarshal(content, &m); err != nil {
+		return nil, err
+	}
+
+	var out []requirement
+	if v, ok := m.Package["rust-version"].(string); ok && v != "" {
+		out = append(out, requirement{source: "rust-version", version: v})
+	}
+	if v, ok := m.Workspace.Package["rust-version"].(string); ok && v != "" {
+		out = append(out, requirement{source: "workspace rust-version", version: v})
+	}
+	return out, nil
+}
+
+// Compare orders rust-version values, which cargo requires to be a bare "1.70" or "1.70.0".
+func (rustDetector) Compare(from, to string) (int, bool) {
+	fv, ok := parseVersion(from)
+	if !ok {
+		return 0, false
+	}
+	tv, ok := parseVersion(to)
+	if !ok {
+		return 0, false
+	}
+	return internal.CompareVersionSegments(tv, fv), true
+}
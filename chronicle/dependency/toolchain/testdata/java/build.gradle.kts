@@ -0,0 +1,7 @@
+plugins {
+    kotlin("jvm") version "1.9.22"
+}
+
+kotlin {
+    jvmToolchain(21)
+}
@@ -0,0 +1,278 @@
+// Package vex reads OpenVEX documents the project maintains about its own
+// vulnerability exposure and turns them into a dependency.Suppressor, so a
+// vulnerability the project has declared itself not affected by is kept out of
+// the changelog the same way a VEX-aware scanner keeps it out of its report.
+package vex
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/bmatcuk/doublestar/v4"
+	openvex "github.com/openvex/go-vex/pkg/vex"
+
+	"github.com/anchore/chronicle/chronicle/dependency"
+	"github.com/anchore/chronicle/internal/git"
+	"github.com/anchore/chronicle/internal/log"
+	"github.com/anchore/packageurl-go"
+)
+
+// DefaultPaths returns the globs that discover OpenVEX documents in the
+// repository: files named *.openvex.json anywhere, and any JSON file under a
+// top-level .openvex directory.
+func DefaultPaths() []string {
+	return []string{
+		"**/*.openvex.json",
+		".openvex/**/*.json",
+	}
+}
+
+// fileLister is the slice of git.Interface that discovery depends on: reading
+// file content at a ref without a working-tree checkout.
+type fileLister interface {
+	ListFilesAtRef(ref string, match func(path string) bool) ([]git.FileBlob, error)
+}
+
+// Load reads the OpenVEX documents at paths. Unlike discovered documents,
+// these were asked for by name, so one that can't be read or parsed is an
+// error.
+func Load(paths []string) (*Suppressor, error) {
+	s := &Suppressor{}
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read vex document: %w", err)
+		}
+		doc, err := parse(data)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse vex document %s: %w", p, err)
+		}
+		s.add(doc)
+	}
+	return s, nil
+}
+
+// Discover adds the OpenVEX documents matching globs at ref to s, and returns
+// how many it found. A file under a directory matching one of ignore (the
+// detectors' ignore list, e.g. "**/vendor/**" or "./third_party") is skipped:
+// a document shipped with vendored or test code is about that code, not this
+// project. Discovery is best-effort: a matching file that isn't an OpenVEX
+// document is logged and skipped, since a glob can't tell a VEX file from any
+// other JSON.
+func (s *Suppressor) Discover(gitter fileLister, ref string, globs, ignore []string) (int, error) {
+	files, err := gitter.ListFilesAtRef(ref, func(p string) bool {
+		if ignored(p, ignore) {
+			return false
+		}
+		for _, g := range globs {
+			if ok, _ := doublestar.Match(g, p); ok {
+				return true
+			}
+		}
+		return false
+	})
+	if err != nil {
+		return 0, fmt.Errorf("unable to list vex documents at %q: %w", ref, err)
+	}
+
+	var found int
+	for _, f := range files {
+		doc, err := parse(f.Content)
+		if err != nil {
+			log.WithFields("error", err, "file", f.Path).Debug("skipping file that is not an openvex document")
+			continue
+		}
+		s.add(doc)
+		found++
+	}
+	return found, nil
+}
+
+// ignored reports whether p, or a directory it sits in, matches one of the
+// ignore globs. Patterns are read relative to the repository root, with a
+// leading "./" or trailing "/" meaning nothing, as in syft's exclude list.
+func ignored(p string, ignore []string) bool {
+	for _, g := range ignore {
+		g = strings.TrimSuffix(strings.TrimPrefix(g, "./"), "/")
+		for dir := p; dir != "."; dir = path.Dir(dir) {
+			if ok, _ := doublestar.Match(g, dir); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parse decodes an OpenVEX document, rejecting JSON that doesn't declare the
+// OpenVEX context (openvex.Parse would accept any object as an empty document).
+func parse(data []byte) (*openvex.VEX, error) {
+	var head struct {
+		Context string `json:"@context"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(head.Context, openvex.Context) {
+		return nil, fmt.Errorf("not an openvex document (@context %q)", head.Context)
+	}
+	return openvex.Parse(data)
+}
+
+// Suppressor is the dependency.Suppressor for a set of OpenVEX documents. For
+// each vulnerability and package it follows the statements that apply, in time
+// order, and suppresses when the latest says the product is not_affected — so a
+// later "affected" statement lifts an earlier exemption.
+type Suppressor struct {
+	statements []*openvex.Statement
+	// project are the identifiers that name the project itself; see SetProject.
+	project []string
+}
+
+var _ dependency.Suppressor = (*Suppressor)(nil)
+
+func (s *Suppressor) add(doc *openvex.VEX) {
+	// statements inherit the document's timestamp when they carry none, which
+	// is what orders them against statements from other documents.
+	s.statements = append(s.statements, doc.ExtractStatements()...)
+}
+
+// SetProject records the identifiers that name the project the documents are
+// about, typically its pkg:github purl and any configured vex product. Only a
+// statement about one of these products speaks for the whole project; see
+// appliesTo.
+func (s *Suppressor) SetProject(ids ...string) {
+	s.project = nil
+	for _, id := range ids {
+		if id != "" {
+			s.project = append(s.project, id)
+		}
+	}
+}
+
+// Len reports how many statements were loaded.
+func (s *Suppressor) Len() int {
+	if s == nil {
+		return 0
+	}
+	return len(s.statements)
+}
+
+// Suppresses reports whether the latest statement about v (by its ID or any
+// alias) that applies to the package purl declares it not_affected.
+func (s *Suppressor) Suppresses(v dependency.Vulnerability, purl string) bool {
+	if s == nil {
+		return false
+	}
+	var latest *openvex.Statement
+	var latestAt time.Time
+	for _, stmt := range s.statements {
+		if !matchesVuln(stmt, v) || !s.appliesTo(stmt, purl) {
+			continue
+		}
+		at := statementTime(stmt)
+		if latest == nil || !at.Before(latestAt) {
+			latest, latestAt = stmt, at
+		}
+	}
+	return latest != nil && latest.Status == openvex.StatusNotAffected
+}
+
+func matchesVuln(stmt *openvex.Statement, v dependency.Vulnerability) bool {
+	for _, id := range v.IDs() {
+		if stmt.Vulnerability.Matches(id) {
+			return true
+		}
+	}
+	return false
+}
+
+// appliesTo reports whether a statement covers the package purl. A product
+// that is the project covers the packages its subcomponents match, or every
+// package when it lists none. A product that is a package URL (the common
+// "product is the vulnerable package" form) covers only that package. Any
+// other product, such as another vendor's image or repository, is about
+// something else and covers nothing. A package without a purl is only covered
+// by project-wide statements.
+func (s *Suppressor) appliesTo(stmt *openvex.Statement, purl string) bool {
+	for _, p := range stmt.Products {
+		if s.isProject(p.Component) {
+			if len(p.Subcomponents) == 0 {
+				return true
+			}
+			for _, sc := range p.Subcomponents {
+				if purl != "" && componentMatches(sc.Component, purl) {
+					return true
+				}
+			}
+			continue
+		}
+		if purl != "" && componentMatches(p.Component, purl) {
+			return true
+		}
+	}
+	return false
+}
+
+// isProject reports whether a product is one of the identifiers SetProject
+// recorded. A package URL matches a project package URL of the same type,
+// namespace and name, whatever version the statement is about; any other
+// identifier must match exactly.
+func (s *Suppressor) isProject(c openvex.Component) bool {
+	for _, id := range []string{c.ID, c.Identifiers[openvex.PURL]} {
+		if id == "" {
+			continue
+		}
+		for _, p := range s.project {
+			if sameIdentifier(id, p) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func sameIdentifier(id, project string) bool {
+	if !strings.HasPrefix(id, "pkg:") || !strings.HasPrefix(project, "pkg:") {
+		return id == project
+	}
+	a, err := packageurl.FromString(id)
+	if err != nil {
+		return false
+	}
+	b, err := packageurl.FromString(project)
+	if err != nil {
+		return false
+	}
+	if !strings.EqualFold(a.Type, b.Type) {
+		return false
+	}
+	if strings.EqualFold(a.Type, packageurl.TypeGithub) {
+		// GitHub owners and repositories are case-insensitive
+		return strings.EqualFold(a.Namespace, b.Namespace) && strings.EqualFold(a.Name, b.Name)
+	}
+	return a.Namespace == b.Namespace && a.Name == b.Name
+}
+
+func componentMatches(c openvex.Component, purl string) bool {
+	if c.ID != "" && openvex.PurlMatches(c.ID, purl) {
+		return true
+	}
+	if id := c.Identifiers[openvex.PURL]; id != "" && openvex.PurlMatches(id, purl) {
+		return true
+	}
+	return false
+}
+
+func statementTime(stmt *openvex.Statement) time.Time {
+	if stmt.LastUpdated != nil {
+		return *stmt.LastUpdated
+	}
+	if stmt.Timestamp != nil {
+		return *stmt.Timestamp
+	}
+	return time.Time{}
+}
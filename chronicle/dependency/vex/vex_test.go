@@ -0,0 +1,399 @@
+package vex
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/chronicle/chronicle/dependency"
+	"github.com/anchore/chronicle/internal/git"
+)
+
+// fakeLister serves fixed file sets per ref, filtered through the match
+// predicate like the real tree walk.
+type fakeLister struct {
+	files map[string][]git.FileBlob
+	err   error
+}
+
+func (f fakeLister) ListFilesAtRef(ref string, match func(path string) bool) ([]git.FileBlob, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	var out []git.FileBlob
+	for _, b := range f.files[ref] {
+		if match(b.Path) {
+			out = append(out, b)
+		}
+	}
+	return out, nil
+}
+
+// notAffectedDoc declares the product not affected by CVE-2026-0001 in the
+// x/net subcomponent only.
+const notAffectedDoc = `{
+  "@context": "https://openvex.dev/ns/v0.2.0",
+  "@id": "https://example.com/vex/1",
+  "author": "maintainers",
+  "timestamp": "2026-01-01T00:00:00Z",
+  "version": 1,
+  "statements": [
+    {
+      "vulnerability": {"name": "CVE-2026-0001", "aliases": ["GHSA-aaaa-bbbb-cccc"]},
+      "products": [
+        {
+          "@id": "pkg:github/acme/app@v1.0.0",
+          "subcomponents": [{"@id": "pkg:golang/golang.org/x/net@v0.2.0"}]
+        }
+      ],
+      "status": "not_affected",
+      "justification": "vulnerable_code_not_in_execute_path"
+    },
+    {
+      "vulnerability": {"name": "CVE-2026-0002"},
+      "products": [{"@id": "pkg:github/acme/app@v1.0.0"}],
+      "status": "not_affected",
+      "justification": "component_not_present"
+    },
+    {
+      "vulnerability": {"name": "CVE-2026-0003"},
+      "products": [{"@id": "pkg:github/acme/app@v1.0.0"}],
+      "status": "under_investigation"
+    }
+  ]
+}`
+
+// affectedLaterDoc reverses the CVE-2026-0002 exemption after the fact.
+const affectedLaterDoc = `{
+  "@context": "https://openvex.dev/ns/v0.2.0",
+  "@id": "https://example.com/vex/2",
+  "author": "maintainers",
+  "timestamp": "2026-02-01T00:00:00Z",
+  "version": 1,
+  "statements": [
+    {
+      "vulnerability": {"name": "CVE-2026-0002"},
+      "products": [{"@id": "pkg:github/acme/app@v1.0.0"}],
+      "status": "affected",
+      "action_statement": "upgrade"
+    }
+  ]
+}`
+
+// packageProductDoc names the vulnerable package itself as the product, with
+// no subcomponents.
+const packageProductDoc = `{
+  "@context": "https://openvex.dev/ns/v0.2.0",
+  "@id": "https://example.com/vex/3",
+  "author": "maintainers",
+  "timestamp": "2026-01-01T00:00:00Z",
+  "version": 1,
+  "statements": [
+    {
+      "vulnerability": {"name": "CVE-2026-0004"},
+      "products": [{"@id": "pkg:golang/golang.org/x/net@v0.1.0"}],
+      "status": "not_affected",
+      "justification": "vulnerable_code_not_in_execute_path"
+    }
+  ]
+}`
+
+// urlProductDoc identifies the product by its repository URL.
+const urlProductDoc = `{
+  "@context": "https://openvex.dev/ns/v0.2.0",
+  "@id": "https://example.com/vex/4",
+  "author": "maintainers",
+  "timestamp": "2026-01-01T00:00:00Z",
+  "version": 1,
+  "statements": [
+    {
+      "vulnerability": {"name": "CVE-2026-0005"},
+      "products": [{"@id": "https://github.com/acme/app"}],
+      "status": "not_affected",
+      "justification": "component_not_present"
+    }
+  ]
+}`
+
+// foreignProductsDoc makes statements about products that are not the project:
+// one sharing its name under another purl type, one under another owner, one
+// identified by an image reference, and one whose subcomponent is a package
+// the project also depends on.
+const foreignProductsDoc = `{
+  "@context": "https://openvex.dev/ns/v0.2.0",
+  "@id": "https://example.com/vex/5",
+  "author": "someone else",
+  "timestamp": "2026-01-01T00:00:00Z",
+  "version": 1,
+  "statements": [
+    {
+      "vulnerability": {"name": "CVE-2026-0006"},
+      "products": [{"@id": "pkg:oci/app"}],
+      "status": "not_affected",
+      "justification": "component_not_present"
+    },
+    {
+      "vulnerability": {"name": "CVE-2026-0007"},
+      "products": [{"@id": "pkg:github/other/app@v2.0.0"}],
+      "status": "not_affected",
+      "justification": "component_not_present"
+    },
+    {
+      "vulnerability": {"name": "CVE-2026-0008"},
+      "products": [{"@id": "ghcr.io/vendor/image:1.0"}],
+      "status": "not_affected",
+      "justification": "component_not_present"
+    },
+    {
+      "vulnerability": {"name": "CVE-2026-0009"},
+      "products": [
+        {
+          "@id": "pkg:oci/vendor-image",
+          "subcomponents": [{"@id": "pkg:golang/golang.org/x/net@v0.2.0"}]
+        }
+      ],
+      "status": "not_affected",
+      "justification": "vulnerable_code_not_in_execute_path"
+    },
+    {
+      "vulnerability": {"name": "CVE-2026-0010"},
+      "products": [{"@id": "pkg:github/ACME/App@v1.0.0"}],
+      "status": "not_affected",
+      "justification": "component_not_present"
+    }
+  ]
+}`
+
+func writeDoc(t *testing.T, content string) string {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), "doc.openvex.json")
+	require.NoError(t, os.WriteFile(p, []byte(content), 0o600))
+	return p
+}
+
+func TestSuppressor_Suppresses(t *testing.T) {
+	const netPURL = "pkg:golang/golang.org/x/net@v0.2.0"
+
+	tests := []struct {
+		name    string
+		docs    []string
+		project []string
+		vuln    dependency.Vulnerability
+		purl    string
+		want    bool
+	}{
+		{
+			name: "not_affected on the subcomponent",
+			docs: []string{notAffectedDoc},
+			vuln: dependency.Vulnerability{ID: "CVE-2026-0001"},
+			purl: netPURL,
+			want: true,
+		},
+		{
+			name: "subcomponent statement does not cover other packages",
+			docs: []string{notAffectedDoc},
+			vuln: dependency.Vulnerability{ID: "CVE-2026-0001"},
+			purl: "pkg:golang/golang.org/x/text@v0.3.0",
+			want: false,
+		},
+		{
+			name: "subcomponent statement does not cover a package without a purl",
+			docs: []string{notAffectedDoc},
+			vuln: dependency.Vulnerability{ID: "CVE-2026-0001"},
+			want: false,
+		},
+		{
+			name: "matched through the scanner's alias",
+			docs: []string{notAffectedDoc},
+			vuln: dependency.Vulnerability{ID: "GHSA-zzzz", Aliases: []string{"CVE-2026-0001"}},
+			purl: netPURL,
+			want: true,
+		},
+		{
+			name: "matched through the statement's alias",
+			docs: []string{notAffectedDoc},
+			vuln: dependency.Vulnerability{ID: "GHSA-aaaa-bbbb-cccc"},
+			purl: netPURL,
+			want: true,
+		},
+		{
+			name: "product-wide statement covers every package",
+			docs: []string{notAffectedDoc},
+			vuln: dependency.Vulnerability{ID: "CVE-2026-0002"},
+			purl: "pkg:npm/left-pad@1.3.0",
+			want: true,
+		},
+		{
+			name:    "a product-wide statement about another project covers nothing",
+			docs:    []string{notAffectedDoc},
+			project: []string{"pkg:github/acme/other"},
+			vuln:    dependency.Vulnerability{ID: "CVE-2026-0002"},
+			purl:    "pkg:npm/left-pad@1.3.0",
+			want:    false,
+		},
+		{
+			name: "a non-purl product is not the project unless configured as it",
+			docs: []string{urlProductDoc},
+			vuln: dependency.Vulnerability{ID: "CVE-2026-0005"},
+			purl: "pkg:npm/left-pad@1.3.0",
+			want: false,
+		},
+		{
+			name:    "a non-purl product configured as the project",
+			docs:    []string{urlProductDoc},
+			project: []string{"pkg:github/acme/app", "https://github.com/acme/app"},
+			vuln:    dependency.Vulnerability{ID: "CVE-2026-0005"},
+			purl:    "pkg:npm/left-pad@1.3.0",
+			want:    true,
+		},
+		{
+			name: "a product sharing the project's name under another purl type is not the project",
+			docs: []string{foreignProductsDoc},
+			vuln: dependency.Vulnerability{ID: "CVE-2026-0006"},
+			purl: "pkg:npm/left-pad@1.3.0",
+			want: false,
+		},
+		{
+			name: "a product sharing the project's name under another owner is not the project",
+			docs: []string{foreignProductsDoc},
+			vuln: dependency.Vulnerability{ID: "CVE-2026-0007"},
+			purl: "pkg:npm/left-pad@1.3.0",
+			want: false,
+		},
+		{
+			name: "an image product is not the project",
+			docs: []string{foreignProductsDoc},
+			vuln: dependency.Vulnerability{ID: "CVE-2026-0008"},
+			want: false,
+		},
+		{
+			name: "another product's subcomponent does not cover the project's package",
+			docs: []string{foreignProductsDoc},
+			vuln: dependency.Vulnerability{ID: "CVE-2026-0009"},
+			purl: netPURL,
+			want: false,
+		},
+		{
+			name: "a github project purl matches case-insensitively",
+			docs: []string{foreignProductsDoc},
+			vuln: dependency.Vulnerability{ID: "CVE-2026-0010"},
+			purl: "pkg:npm/left-pad@1.3.0",
+			want: true,
+		},
+		{
+			name: "a package product covers that package",
+			docs: []string{packageProductDoc},
+			vuln: dependency.Vulnerability{ID: "CVE-2026-0004"},
+			purl: "pkg:golang/golang.org/x/net@v0.1.0",
+			want: true,
+		},
+		{
+			name: "a package product does not cover other packages",
+			docs: []string{packageProductDoc},
+			vuln: dependency.Vulnerability{ID: "CVE-2026-0004"},
+			purl: "pkg:golang/golang.org/x/text@v0.3.0",
+			want: false,
+		},
+		{
+			name: "only not_affected suppresses",
+			docs: []string{notAffectedDoc},
+			vuln: dependency.Vulnerability{ID: "CVE-2026-0003"},
+			want: false,
+		},
+		{
+			name: "a later affected statement lifts the exemption",
+			docs: []string{affectedLaterDoc, notAffectedDoc},
+			vuln: dependency.Vulnerability{ID: "CVE-2026-0002"},
+			want: false,
+		},
+		{
+			name: "unrelated vulnerability",
+			docs: []string{notAffectedDoc},
+			vuln: dependency.Vulnerability{ID: "CVE-1999-0001"},
+			purl: netPURL,
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var paths []string
+			for _, d := range tt.docs {
+				paths = append(paths, writeDoc(t, d))
+			}
+			s, err := Load(paths)
+			require.NoError(t, err)
+			project := tt.project
+			if project == nil {
+				project = []string{"pkg:github/acme/app"}
+			}
+			s.SetProject(project...)
+			assert.Equal(t, tt.want, s.Suppresses(tt.vuln, tt.purl))
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	s, err := Load(nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, s.Len())
+
+	_, err = Load([]string{filepath.Join(t.TempDir(), "missing.json")})
+	require.Error(t, err)
+
+	// a named document that isn't OpenVEX is an error, not an empty document
+	_, err = Load([]string{writeDoc(t, `{"bomFormat": "CycloneDX"}`)})
+	require.ErrorContains(t, err, "not an openvex document")
+
+	s, err = Load([]string{writeDoc(t, notAffectedDoc)})
+	require.NoError(t, err)
+	assert.Equal(t, 3, s.Len())
+}
+
+func TestSuppressor_Discover(t *testing.T) {
+	lister := fakeLister{files: map[string][]git.FileBlob{
+		"v2": {
+			{Path: "security/app.openvex.json", Content: []byte(notAffectedDoc)},
+			{Path: ".openvex/later.json", Content: []byte(affectedLaterDoc)},
+			// matches a glob but isn't a vex document: skipped
+			{Path: ".openvex/notes.json", Content: []byte(`{"note": "hi"}`)},
+			// not matched by any glob
+			{Path: "vex.json", Content: []byte(notAffectedDoc)},
+			// under ignored directories: about that code, not the project
+			{Path: "vendor/github.com/acme/lib/lib.openvex.json", Content: []byte(foreignProductsDoc)},
+			{Path: "internal/testdata/fixture.openvex.json", Content: []byte(foreignProductsDoc)},
+			{Path: "third_party/lib/lib.openvex.json", Content: []byte(foreignProductsDoc)},
+		},
+	}}
+	ignore := []string{"**/vendor/**", "**/testdata/**", "./third_party"}
+
+	s := &Suppressor{}
+	n, err := s.Discover(lister, "v2", DefaultPaths(), ignore)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, 4, s.Len())
+
+	// without an ignore list the vendored and test documents are read too
+	s = &Suppressor{}
+	n, err = s.Discover(lister, "v2", DefaultPaths(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 5, n)
+
+	s = &Suppressor{}
+	n, err = s.Discover(lister, "v1", DefaultPaths(), ignore)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	_, err = s.Discover(fakeLister{err: errors.New("boom")}, "v2", DefaultPaths(), ignore)
+	require.Error(t, err)
+}
+
+func TestSuppressor_Nil(t *testing.T) {
+	var s *Suppressor
+	assert.Equal(t, 0, s.Len())
+	assert.False(t, s.Suppresses(dependency.Vulnerability{ID: "CVE-2026-0001"}, ""))
+}
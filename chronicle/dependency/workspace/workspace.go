@@ -0,0 +1,270 @@
+// Package workspace discovers the members a repository declares through its
+// workspace manifests (go.work, npm/yarn/pnpm workspaces and Cargo workspaces),
+// so scanning can cover exactly the project's modules: the nested ones a
+// root-only scan misses, without the tooling modules (a .make/go.mod, a
+// fixture's package.json) a recursive scan would pick up.
+package workspace
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/bmatcuk/doublestar/v4"
+	"golang.org/x/mod/modfile"
+	"gopkg.in/yaml.v3"
+
+	"github.com/anchore/chronicle/internal/git"
+	"github.com/anchore/chronicle/internal/log"
+)
+
+// Root is the member path of the repository root, which is always scanned
+// alongside the declared members since it is where the workspace manifests
+// (and usually the shared lockfile) live.
+const Root = "."
+
+// fileLister is the slice of git.Interface that discovery depends on: reading
+// file content at a ref without a working-tree checkout.
+type fileLister interface {
+	ListFilesAtRef(ref string, match func(path string) bool) ([]git.FileBlob, error)
+}
+
+// manifests are the root-level files that declare workspace members.
+var manifests = []string{"go.work", "package.json", "pnpm-workspace.yaml", "Cargo.toml"}
+
+// memberManifests are the files whose directory makes a glob-matched
+// directory a member: a JS workspace glob only matches packages, and a Cargo
+// one only crates.
+var memberManifests = []string{"package.json", "Cargo.toml"}
+
+// Discover returns the member directories declared at ref, relative to the
+// repository root, sorted and without duplicates. A repository that declares
+// no workspace returns nil, so callers fall back to their usual discovery. A
+// workspace manifest that can't be parsed is logged and skipped rather than
+// failing discovery, since the rest of the repository may still declare one.
+func Discover(gitter fileLister, ref string) ([]string, error) {
+	roots, err := gitter.ListFilesAtRef(ref, func(p string) bool {
+		return slices.Contains(manifests, p)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list workspace manifests at %q: %w", ref, err)
+	}
+
+	var decl declaration
+	for _, f := range roots {
+		d, err := parse(f.Path, f.Content)
+		if err != nil {
+			log.WithFields("error", err, "file", f.Path, "ref", ref).Debug("unable to parse workspace manifest; skipping")
+			continue
+		}
+		decl.merge(d)
+	}
+	if !decl.declared {
+		return nil, nil
+	}
+
+	members := map[string]bool{}
+	for _, dir := range decl.dirs {
+		members[clean(dir)] = true
+	}
+	if len(decl.globs) > 0 {
+		candidates, err := gitter.ListFilesAtRef(ref, func(p string) bool {
+			// a "**" glob would otherwise match every installed package too
+			return slices.Contains(memberManifests, path.Base(p)) && strings.Contains(p, "/") &&
+				!strings.Contains("/"+p, "/node_modules/")
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to list workspace member candidates at %q: %w", ref, err)
+		}
+		for _, f := range candidates {
+			dir := path.Dir(f.Path)
+			if matchAny(decl.globs, dir) && !matchAny(decl.exclude, dir) {
+				members[dir] = true
+			}
+		}
+	}
+
+	out := make([]string, 0, len(members))
+	for m := range members {
+		if m == Root || matchAny(decl.exclude, m) {
+			continue
+		}
+		out = append(out, m)
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+// declaration is what the workspace manifests at one ref declare: literal
+// member directories, member globs, and globs that take members back out.
+type declaration struct {
+	dirs    []string
+	globs   []string
+	exclude []string
+	// declared is set when any manifest declares a workspace, even an empty
+	// one, so a workspace with no members yet still restricts scanning to the
+	// root.
+	declared bool
+}
+
+func (d *declaration) merge(o declaration) {
+	d.dirs = append(d.dirs, o.dirs...)
+	d.globs = append(d.globs, o.globs...)
+	d.exclude = append(d.exclude, o.exclude...)
+	d.declared = d.declared || o.declared
+}
+
+// add sorts one member entry into a literal directory, a glob or (with a
+// leading "!", as pnpm and some npm setups write them) an exclusion.
+func (d *declaration) add(entry string) {
+	entry = strings.TrimSpace(entry)
+	switch {
+	case entry == "":
+	case strings.HasPrefix(entry, "!"):
+		d.exclude = append(d.exclude, clean(strings.TrimPrefix(entry, "!")))
+	case strings.ContainsAny(entry, "*?[{"):
+		d.globs = append(d.globs, clean(entry))
+	default:
+		d.dirs = append(d.dirs, entry)
+	}
+}
+
+func parse(name string, content []byte) (declaration, error) {
+	switch name {
+	case "go.work":
+		return parseGoWork(content)
+	case "package.json":
+		return parsePackageJSON(content)
+	case "pnpm-workspace.yaml":
+		return parsePnpmWorkspace(content)
+	case "Cargo.toml":
+		return parseCargoToml(content)
+	}
+	return declaration{}, nil
+}
+
+func parseGoWork(content []byte) (declaration, error) {
+	f, err := modfile.ParseWork("go.work", content, nil)
+	if err != nil {
+		return declaration{}, err
+	}
+	d := declaration{declared: true}
+	for _, u := range f.Use {
+		// go.work paths are directories, never globs.
+		d.dirs = append(d.dirs, u.Path)
+	}
+	return d, nil
+}
+
+func parsePackageJSON(content []byte) (declaration, error) {
+	var pkg struct {
+		Workspaces json.RawMessage `json:"workspaces"`
+	}
+	if err := json.Unmarshal(content, &pkg); err != nil {
+		return declaration{}, err
+	}
+	if len(pkg.Workspaces) == 0 {
+		return declaration{}, nil
+	}
+
+	// npm takes an array of globs; yarn classic also accepts an object whose
+	// packages field holds them (next to nohoist, which doesn't affect members).
+	var entries []string
+	if err := json.Unmarshal(pkg.Workspaces, &entries); err != nil {
+		var obj struct {
+			Packages []string `json:"packages"`
+		}
+		if err := json.Unmarshal(pkg.Workspaces, &obj); err != nil {
+			return declaration{}, fmt.Errorf("unable to read workspaces: %w", err)
+		}
+		entries = obj.Packages
+	}
+	d := declaration{declared: true}
+	for _, e := range entries {
+		d.add(e)
+	}
+	return d, nil
+}
+
+func parsePnpmWorkspace(content []byte) (declaration, error) {
+	var ws struct {
+		Packages []string `yaml:"packages"`
+	}
+	if err := yaml.Unmarshal(content, &ws); err != nil {
+		return declaration{}, err
+	}
+	d := declaration{declared: true}
+	for _, e := range ws.Packages {
+		d.add(e)
+	}
+	return d, nil
+}
+
+func parseCargoToml(content []byte) (declaration, error) {
+	var manifest struct {
+		Workspace *struct {
+			Members []string `toml:"members"`
+			Exclude []string `toml:"exclude"`
+		} `toml:"workspace"`
+	}
+	if _, err := toml.Decode(string(content), &manifest); err != nil {
+		return declaration{}, err
+	}
+	if manifest.Workspace == nil {
+		return declaration{}, nil
+	}
+	d := declaration{declared: true}
+	for _, m := range manifest.Workspace.Members {
+		d.add(m)
+	}
+	for _, e := range manifest.Workspace.Exclude {
+		d.exclude = append(d.exclude, clean(e))
+	}
+	return d, nil
+}
+
+// clean normalizes a declared path to the slash-separated, root-relative form
+// git lists files in, so "./packages/a/" and "packages/a" are the same member
+// (and a scanner's "/packages/a/package.json" sits in it).
+func clean(p string) string {
+	p = strings.TrimSpace(p)
+	p = strings.TrimPrefix(p, "./")
+	p = strings.TrimPrefix(p, "/")
+	return path.Clean(p)
+}
+
+func matchAny(globs []string, p string) bool {
+	for _, g := range globs {
+		if ok, _ := doublestar.Match(g, p); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains reports whether the file at p belongs to the root or one of
+// members. It is the path filter scanning and detection apply once a
+// workspace is declared.
+func Contains(members []string, p string) bool {
+	return Of(members, p) != ""
+}
+
+// Of returns the member the file at p belongs to: the member directory it sits
+// directly in, Root for a file directly in the repository root, or "" for
+// anything else. Only a member's own manifests count, so a fixture or tooling
+// module nested below a member (member/testdata/go.mod) stays out just like
+// one nested below the root does.
+func Of(members []string, p string) string {
+	dir := path.Dir(clean(p))
+	if dir == Root {
+		return Root
+	}
+	if slices.Contains(members, dir) {
+		return dir
+	}
+	return ""
+}
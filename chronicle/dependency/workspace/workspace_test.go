@@ -0,0 +1,117 @@
+package workspace
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/chronicle/internal/git"
+)
+
+func blob(p, content string) git.FileBlob {
+	return git.FileBlob{Path: p, Content: []byte(content)}
+}
+
+func TestDiscover(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []git.FileBlob
+		want  []string
+	}{
+		{
+			name: "no workspace declared",
+			files: []git.FileBlob{
+				blob("go.mod", "module example.com/foo\n"),
+				blob("package.json", `{"name": "foo"}`),
+				blob(".make/go.mod", "module example.com/make\n"),
+			},
+			want: nil,
+		},
+		{
+			name: "go.work",
+			files: []git.FileBlob{
+				blob("go.work", "go 1.22\n\nuse (\n\t.\n\t./api\n\t./cmd/tool/\n)\n"),
+				blob(".make/go.mod", "module example.com/make\n"),
+			},
+			want: []string{"api", "cmd/tool"},
+		},
+		{
+			name: "npm workspaces expand globs against packages",
+			files: []git.FileBlob{
+				blob("package.json", `{"workspaces": ["packages/*", "tools/cli"]}`),
+				blob("packages/a/package.json", `{}`),
+				blob("packages/b/package.json", `{}`),
+				blob("packages/README.md", ``),
+				blob("packages/a/node_modules/x/package.json", `{}`),
+				blob("fixtures/package.json", `{}`),
+			},
+			want: []string{"packages/a", "packages/b", "tools/cli"},
+		},
+		{
+			name: "yarn workspaces object",
+			files: []git.FileBlob{
+				blob("package.json", `{"workspaces": {"packages": ["apps/*"], "nohoist": ["**/x"]}}`),
+				blob("apps/web/package.json", `{}`),
+			},
+			want: []string{"apps/web"},
+		},
+		{
+			name: "pnpm negations",
+			files: []git.FileBlob{
+				blob("pnpm-workspace.yaml", "packages:\n  - 'packages/**'\n  - '!**/test/**'\n"),
+				blob("packages/a/package.json", `{}`),
+				blob("packages/a/test/fixture/package.json", `{}`),
+			},
+			want: []string{"packages/a"},
+		},
+		{
+			name: "cargo members and exclude",
+			files: []git.FileBlob{
+				blob("Cargo.toml", "[workspace]\nmembers = [\"crates/*\"]\nexclude = [\"crates/legacy\"]\n"),
+				blob("crates/core/Cargo.toml", ""),
+				blob("crates/legacy/Cargo.toml", ""),
+			},
+			want: []string{"crates/core"},
+		},
+		{
+			name: "empty workspace still restricts to the root",
+			files: []git.FileBlob{
+				blob("Cargo.toml", "[workspace]\n"),
+			},
+			want: []string{},
+		},
+		{
+			name: "unparseable manifest is skipped",
+			files: []git.FileBlob{
+				blob("go.work", "use (\n"),
+				blob("package.json", `{"workspaces": ["web"]}`),
+			},
+			want: []string{"web"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gitter := git.MockInterface{MockFilesAtRef: map[string][]git.FileBlob{"v1": tt.files}}
+			got, err := Discover(gitter, "v1")
+			require.NoError(t, err)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOf(t *testing.T) {
+	members := []string{"api", "packages/a"}
+	assert.Equal(t, Root, Of(members, "go.mod"))
+	assert.Equal(t, Root, Of(members, "./package-lock.json"))
+	assert.Equal(t, "api", Of(members, "api/go.mod"))
+	assert.Equal(t, "packages/a", Of(members, "/packages/a/package.json"))
+	assert.Equal(t, "", Of(members, "api/testdata/go.mod"))
+	assert.Equal(t, "", Of(members, ".make/go.mod"))
+	assert.False(t, Contains(members, ".make/go.mod"))
+	assert.True(t, Contains(members, "api/go.mod"))
+}
@@ -23,6 +23,25 @@ type Summary struct {
 	PreviousVersion string
 	NextVersion     string
 	BumpKind        change.SemVerKind
+
+	// GateViolations are the dependency changes that introduce a vulnerability
+	// the fail-on policy forbids; non-empty means the run is about to fail.
+	GateViolations []SummaryGateViolation
+}
+
+// SummaryGateViolation is one dependency change that tripped the vulnerability
+// gate, with just the vulnerabilities that tripped it.
+type SummaryGateViolation struct {
+	Package         string
+	Version         string
+	Vulnerabilities []SummaryGateVuln
+}
+
+// SummaryGateVuln is a forbidden vulnerability a change introduced.
+type SummaryGateVuln struct {
+	ID             string
+	Severity       string
+	KnownExploited bool
 }
 
 // SummaryChange is one change type's contribution to the recap: its name, its
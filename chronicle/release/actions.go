@@ -0,0 +1,73 @@
+package release
+
+import "regexp"
+
+// ActionsData carries changes to the GitHub Actions a repository's workflows use (the `uses:`
+// references in .github/workflows), detected between the since and until refs. It is populated
+// only when action detection is enabled and at least one reference changed. Supply-chain review
+// cares less about the version bump than about how an action is pinned, so changes are classified
+// by pinning and any newly unpinned reference is raised as a warning.
+type ActionsData struct {
+	Changes  []ActionChange  `json:",omitempty"`
+	Warnings []ActionWarning `json:",omitempty"` // newly unpinned references; surfaced to operators and JSON output, never the changelog body
+}
+
+// ActionChangeKind classifies how an action's references moved between refs.
+type ActionChangeKind string
+
+const (
+	ActionAdded    ActionChangeKind = "added"    // the action is used at the until ref but not the since ref
+	ActionRemoved  ActionChangeKind = "removed"  // ... and the reverse
+	ActionUpdated  ActionChangeKind = "updated"  // the reference changed without changing how it is pinned
+	ActionPinned   ActionChangeKind = "pinned"   // moved from a tag or branch to a full commit SHA
+	ActionUnpinned ActionChangeKind = "unpinned" // moved from a full commit SHA to a tag or branch
+)
+
+// ActionChange is one action whose references changed across all workflow files. An action used
+// at several references (e.g. different tags in different workflows) lists each of them.
+type ActionChange struct {
+	Action     string // e.g. "actions/checkout", "github/codeql-action/init", or "docker://alpine"
+	Kind       ActionChangeKind
+	From       []string `json:",omitempty"` // the references at the since ref, sorted
+	To         []string `json:",omitempty"` // the references at the until ref, sorted
+	Files      []string `json:",omitempty"` // the workflow files using the action (at the until ref, or the since ref when removed)
+	ThirdParty bool     // maintained outside GitHub's own actions/ and github/ organizations
+}
+
+// ActionWarning flags a reference at the until ref that is not pinned to a commit SHA and was not
+// already used unpinned at the since ref.
+type ActionWarning struct {
+	Action  string
+	Ref     string
+	Message string
+	Files   []string
+}
+
+// HasChanges reports whether there is at least one action change to render. It is nil-safe so
+// callers can gate rendering without a separate nil check.
+func (d *ActionsData) HasChanges() bool {
+	return d != nil && len(d.Changes) > 0
+}
+
+var (
+	commitSHAPattern   = regexp.MustCompile(`^[0-9a-f]{40}$`)
+	imageDigestPattern = regexp.MustCompile(`^sha256:[0-9a-f]{64}$`)
+)
+
+// IsPinnedActionRef reports whether an action reference is immutable: a full 40-character commit
+// SHA, or an image digest for a docker:// action. Tags and branches can be moved after review.
+func IsPinnedActionRef(ref string) bool {
+	return commitSHAPattern.MatchString(ref) || imageDigestPattern.MatchString(ref)
+}
+
+// ShortActionRef abbreviates a pinned reference for display (a commit SHA to 12 characters, as
+// with image digests); tags and branches are returned unchanged.
+func ShortActionRef(ref string) string {
+	switch {
+	case commitSHAPattern.MatchString(ref):
+		return ref[:12]
+	case imageDigestPattern.MatchString(ref):
+		return ImageReference{Digest: ref}.ShortDigest()
+	}
+	return ref
+}
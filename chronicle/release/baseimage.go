@@ -0,0 +1,143 @@
+package release
+
+import (
+	"slices"
+	"strings"
+)
+
+// BaseImageData carries container base-image changes detected between the since and until refs
+// (e.g. a Dockerfile moving from golang:1.22-alpine to golang:1.23-alpine, or re-pinning a digest).
+// It is populated only when base-image detection is enabled and at least one FROM line changed.
+// A base-image bump is often what clears OS-level CVEs, which the source dependency scan cannot
+// see, so it renders as a rollup within the Dependencies section.
+type BaseImageData struct {
+	Updates []BaseImageUpdate `json:",omitempty"`
+}
+
+// BaseImageDirection indicates whether an image tag moved up or down between refs. It is empty
+// when the tags are not comparable (e.g. "latest", a different image, or a digest-only change).
+type BaseImageDirection string
+
+const (
+	BaseImageDirectionUnknown BaseImageDirection = ""
+	BaseImageUpgrade          BaseImageDirection = "upgrade"
+	BaseImageDowngrade        BaseImageDirection = "downgrade"
+)
+
+// ImageReference is a parsed container image reference: name[:tag][@digest].
+type ImageReference struct {
+	Name   string // repository, including any registry host, e.g. "golang" or "ghcr.io/org/app"
+	Tag    string `json:",omitempty"` // e.g. "1.23-alpine"; empty when only pinned by digest (or implicitly latest)
+	Digest string `json:",omitempty"` // e.g. "sha256:..."
+}
+
+// String renders the reference the way it would be written in a FROM line.
+func (r ImageReference) String() string {
+	var sb strings.Builder
+	sb.WriteString(r.Name)
+	if r.Tag != "" {
+		sb.WriteString(":" + r.Tag)
+	}
+	if r.Digest != "" {
+		sb.WriteString("@" + r.Digest)
+	}
+	return sb.String()
+}
+
+// ShortDigest abbreviates the digest to its algorithm and first 12 hex characters (as `docker images`
+// does), which is plenty to tell two pins apart in a changelog.
+func (r ImageReference) ShortDigest() string {
+	algo, hex, ok := strings.Cut(r.Digest, ":")
+	if !ok || len(hex) <= 12 {
+		return r.Digest
+	}
+	return algo + ":" + hex[:12]
+}
+
+// Short renders the reference like String, but with the digest abbreviated.
+func (r ImageReference) Short() string {
+	r.Digest = r.ShortDigest()
+	return r.String()
+}
+
+// BaseImageUpdate is a single FROM line whose resolved image reference changed.
+type BaseImageUpdate struct {
+	File      string             // Dockerfile path relative to the repo root
+	Stage     string             // the stage name ("builder"), or its 0-based position ("#1") when unnamed
+	From      ImageReference     // the image at the since ref
+	To        ImageReference     // ... and at the until ref
+	Direction BaseImageDirection // whether the tag moved up or down (empty if not comparable)
+}
+
+// DigestOnly reports whether the image and tag are unchanged and only the pinned digest moved —
+// a rebuild of the same tag, typically picking up OS patches.
+func (u BaseImageUpdate) DigestOnly() bool {
+	return digestOnly(u.From, u.To)
+}
+
+// BaseImageDisplay is a single base-image line ready for rendering, with identical transitions
+// collapsed across files and stages. Files is populated only when disambiguation is needed (i.e.
+// the same image has more than one distinct transition).
+type BaseImageDisplay struct {
+	From      ImageReference
+	To        ImageReference
+	Direction BaseImageDirection
+	Files     []string
+}
+
+// DigestOnly reports whether the line is a re-pin of the same image and tag.
+func (l BaseImageDisplay) DigestOnly() bool {
+	return digestOnly(l.From, l.To)
+}
+
+func digestOnly(from, to ImageReference) bool {
+	return from.Name == to.Name && from.Tag == to.Tag && from.Digest != to.Digest
+}
+
+// HasUpdates reports whether there is at least one base-image change to render. It is nil-safe
+// so callers can gate rendering without a separate nil check.
+func (d *BaseImageData) HasUpdates() bool {
+	return d != nil && len(d.Updates) > 0
+}
+
+// DisplayLines groups the updates for rendering: updates with the same from/to references
+// collapse onto one line (a multi-stage build often uses one base image in several stages). When
+// an image has multiple distinct transitions, each line carries its contributing files.
+func (d *BaseImageData) DisplayLines() []BaseImageDisplay {
+	if d == nil {
+		return nil
+	}
+
+	type group struct {
+		line  BaseImageDisplay
+		files []string
+	}
+
+	var order []string
+	groups := make(map[string]*group)
+	transitions := make(map[string]int) // image name -> distinct transitions
+
+	for _, u := range d.Updates {
+		key := u.From.String() + "\x00" + u.To.String()
+		g, ok := groups[key]
+		if !ok {
+			g = &group{line: BaseImageDisplay{From: u.From, To: u.To, Direction: u.Direction}}
+			groups[key] = g
+			order = append(order, key)
+			transitions[u.To.Name]++
+		}
+		if u.File != "" && !slices.Contains(g.files, u.File) {
+			g.files = append(g.files, u.File)
+		}
+	}
+
+	var out []BaseImageDisplay
+	for _, key := range order {
+		g := groups[key]
+		if transitions[g.line.To.Name] > 1 {
+			g.line.Files = g.files
+		}
+		out = append(out, g.line)
+	}
+	return out
+}
@@ -0,0 +1,64 @@
+package release
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBaseImageData_DisplayLines(t *testing.T) {
+	golang := func(tag string) ImageReference { return ImageReference{Name: "golang", Tag: tag} }
+
+	tests := []struct {
+		name string
+		data *BaseImageData
+		want []BaseImageDisplay
+	}{
+		{
+			name: "nil data",
+			data: nil,
+			want: nil,
+		},
+		{
+			name: "same transition in several stages and files collapses to one line",
+			data: &BaseImageData{Updates: []BaseImageUpdate{
+				{File: "Dockerfile", Stage: "build", From: golang("1.22"), To: golang("1.23"), Direction: BaseImageUpgrade},
+				{File: "Dockerfile", Stage: "test", From: golang("1.22"), To: golang("1.23"), Direction: BaseImageUpgrade},
+				{File: "tools/Dockerfile", Stage: "#0", From: golang("1.22"), To: golang("1.23"), Direction: BaseImageUpgrade},
+			}},
+			want: []BaseImageDisplay{
+				{From: golang("1.22"), To: golang("1.23"), Direction: BaseImageUpgrade},
+			},
+		},
+		{
+			name: "divergent transitions of one image disambiguate with files",
+			data: &BaseImageData{Updates: []BaseImageUpdate{
+				{File: "Dockerfile", Stage: "#0", From: golang("1.22"), To: golang("1.23"), Direction: BaseImageUpgrade},
+				{File: "tools/Dockerfile", Stage: "#0", From: golang("1.21"), To: golang("1.22"), Direction: BaseImageUpgrade},
+				{File: "Dockerfile", Stage: "#1", From: ImageReference{Name: "alpine", Tag: "3.19"}, To: ImageReference{Name: "alpine", Tag: "3.20"}, Direction: BaseImageUpgrade},
+			}},
+			want: []BaseImageDisplay{
+				{From: golang("1.22"), To: golang("1.23"), Direction: BaseImageUpgrade, Files: []string{"Dockerfile"}},
+				{From: golang("1.21"), To: golang("1.22"), Direction: BaseImageUpgrade, Files: []string{"tools/Dockerfile"}},
+				{From: ImageReference{Name: "alpine", Tag: "3.19"}, To: ImageReference{Name: "alpine", Tag: "3.20"}, Direction: BaseImageUpgrade},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.data.DisplayLines()
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestImageReference_Short(t *testing.T) {
+	ref := ImageReference{Name: "alpine", Tag: "3.20", Digest: "sha256:0123456789abcdef0123456789abcdef"}
+	assert.Equal(t, "alpine:3.20@sha256:0123456789ab", ref.Short())
+	assert.Equal(t, "alpine:3.20@sha256:0123456789abcdef0123456789abcdef", ref.String())
+	assert.Equal(t, "alpine", ImageReference{Name: "alpine"}.Short())
+}
@@ -17,6 +17,12 @@ type Change struct {
 	References  []Reference // any URLs that relate to the change
 	EntryType   string      // a free-form helper string that indicates where the change came from (e.g. a "github-issue"). This can be useful for parsing the `Entry` field.
 	Entry       interface{} // the original data entry from the source that represents the change. The `EntryType` field should be used to help indicate how the shape should be interpreted.
+
+	// PullRequest and Issue are the typed counterparts of Entry: at most one is
+	// set, depending on where the change came from. Prefer these over
+	// type-asserting Entry, whose concrete type is private to the releaser.
+	PullRequest *PullRequest
+	Issue       *Issue
 }
 
 // Reference indicates where you can find additional information about a particular change.
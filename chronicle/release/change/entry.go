@@ -0,0 +1,31 @@
+package change
+
+import "time"
+
+// PullRequest is the typed, source-agnostic payload for a change that came from
+// a merged pull (or merge) request. Releasers populate it alongside their own
+// opaque Entry so that consumers (notably the JSON document) don't need to know
+// the releaser's internal types to get at the PR number, author, or labels.
+type PullRequest struct {
+	Number       int
+	Title        string
+	Author       string
+	URL          string
+	MergedAt     time.Time
+	Labels       []string
+	MergeCommit  string
+	LinkedIssues []int  // numbers of the issues this PR closes (same repository)
+	Body         string // the PR description as written (markdown)
+}
+
+// Issue is the typed, source-agnostic payload for a change that came from a
+// closed issue.
+type Issue struct {
+	Number     int
+	Title      string
+	Author     string
+	URL        string
+	ClosedAt   time.Time
+	Labels     []string
+	NotPlanned bool // closed as "not planned" rather than completed
+}
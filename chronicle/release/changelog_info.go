@@ -20,6 +20,10 @@ type ChangelogInfoConfig struct {
 	UntilTag         string
 	ChangeTypeTitles []change.TypeTitle
 
+	// optional source of the release's notice (the highlight paragraph shown
+	// above the changes). Nil leaves the notice empty.
+	NoticeResolver NoticeResolver
+
 	// optional UI evidence leaves. When set, summarizers may update them with
 	// live page-fetch progress (P4) and resolve them once each fetch completes.
 	// Nil-safe — leaf method calls are no-ops when nil.
@@ -28,6 +32,16 @@ type ChangelogInfoConfig struct {
 	PRsLeaf     *event.Leaf
 }
 
+// NoticeResolver finds the notice maintainers wrote for a release. version is
+// the release version ("" when unreleased and not speculated), untilTag the tag
+// the changelog ends at ("" for an untagged HEAD), and trunk the release's
+// commits and every PR merged through them, whether or not the PR made it into
+// the changes (nil when the summarizer has no trunk data). It returns "" when
+// no notice was written.
+type NoticeResolver interface {
+	Notice(version, untilTag string, trunk *TrunkData) string
+}
+
 // EvidenceLeafReceiver is implemented by summarizers that accept UI evidence
 // leaves for live progress reporting. P3 plumbs the leaves through; P4 uses
 // them to publish per-page fetch progress.
@@ -75,6 +89,11 @@ func ChangelogInfo(summer Summarizer, config ChangelogInfoConfig) (*Release, *De
 
 	logChanges(changes)
 
+	var notice string
+	if config.NoticeResolver != nil {
+		notice = config.NoticeResolver.Notice(releaseVersion, config.UntilTag, trunkData)
+	}
+
 	return startRelease, &Description{
 		Release: Release{
 			Version: releaseDisplayVersion,
@@ -84,7 +103,7 @@ func ChangelogInfo(summer Summarizer, config ChangelogInfoConfig) (*Release, *De
 		VCSChangesURL:    summer.ChangesURL(startReleaseVersion, releaseVersion),
 		Changes:          changes,
 		SupportedChanges: config.ChangeTypeTitles,
-		Notice:           "", // TODO...
+		Notice:           notice,
 		PreviousRelease:  startRelease,
 		Speculated:       speculated,
 		Trunk:            trunkData,
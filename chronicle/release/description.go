@@ -42,6 +42,24 @@ type Description struct {
 	// Dependencies section, so it travels alongside DependencyDiff.
 	Toolchain *ToolchainData `json:",omitempty"`
 
+	// BaseImages carries container base-image changes read from Dockerfile FROM
+	// lines. Optional, populated by the worker when base-image detection is
+	// enabled and an image changed. Rendered as a rollup within the Dependencies
+	// section, like Toolchain.
+	BaseImages *BaseImageData `json:",omitempty"`
+
+	// Actions carries changes to the GitHub Actions referenced by the repo's
+	// workflows, classified by how each is pinned. Optional, populated by the
+	// worker when action detection is enabled. Rendered as a rollup within the
+	// Dependencies section.
+	Actions *ActionsData `json:",omitempty"`
+
+	// GoMod carries changes to the replace, retract, godebug and toolchain
+	// directives of the repo's go.mod files. Optional, populated by the worker
+	// when go.mod insights are enabled. Rendered as a rollup within the
+	// Dependencies section.
+	GoMod *GoModData `json:",omitempty"`
+
 	// raw evidence totals (pre-filter), surfaced for the summary report so it
 	// can show "N (M kept)" trailers. Populated by the worker after the
 	// summarizer runs; zero when not provided.
@@ -51,9 +69,9 @@ type Description struct {
 }
 
 // HasDependencyContent reports whether the Dependencies section has anything to
-// render: either a non-empty package diff or at least one toolchain-requirement
-// change. Encoders gate the section on this so a lone toolchain bump (no package
-// changes) still surfaces.
+// render: a non-empty package diff, or a toolchain, base-image, workflow
+// action or go.mod directive change. Encoders gate the section on this so a lone rollup (no
+// package changes) still surfaces.
 func (d Description) HasDependencyContent() bool {
-	return (d.DependencyDiff != nil && d.DependencyDiff.Totals.Total() > 0) || d.Toolchain.HasUpdates()
+	return (d.DependencyDiff != nil && d.DependencyDiff.Totals.Total() > 0) || d.Toolchain.HasUpdates() || d.BaseImages.HasUpdates() || d.Actions.HasChanges() || d.GoMod.HasChanges()
 }
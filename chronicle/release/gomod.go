@@ -0,0 +1,72 @@
+package release
+
+// GoModData carries changes to the go.mod directives reviewers of a Go module care about beyond
+// its requirements: replace, retract, godebug and (optionally) toolchain. They are detected between
+// the since and until refs and populated only when go.mod insights are enabled and at least one
+// directive changed.
+type GoModData struct {
+	Changes []GoModChange `json:",omitempty"`
+}
+
+// GoModDirective names the go.mod directive a change was made to.
+type GoModDirective string
+
+const (
+	GoModReplace   GoModDirective = "replace"
+	GoModRetract   GoModDirective = "retract"
+	GoModGodebug   GoModDirective = "godebug"
+	GoModToolchain GoModDirective = "toolchain"
+)
+
+// GoModChangeKind classifies how a directive moved between refs.
+type GoModChangeKind string
+
+const (
+	GoModAdded   GoModChangeKind = "added"   // the directive is present at the until ref but not the since ref
+	GoModRemoved GoModChangeKind = "removed" // ... and the reverse
+	GoModChanged GoModChangeKind = "changed" // present at both, with a different value
+)
+
+// GoModChange is one directive change in one go.mod file.
+type GoModChange struct {
+	Directive GoModDirective
+	Kind      GoModChangeKind
+	File      string // path relative to the repo root (disambiguates multi-module repos)
+	// Key identifies the directive within the file: the replaced module path (with its version
+	// when only that version is replaced), the godebug setting, or the retracted version or
+	// "[low, high]" range. Empty for toolchain, which appears at most once.
+	Key       string `json:",omitempty"`
+	From      string `json:",omitempty"` // the value at the since ref: the replacement module, godebug value or toolchain name
+	To        string `json:",omitempty"` // ... and at the until ref
+	LocalPath bool   `json:",omitempty"` // a replace pointing at a directory, which only resolves inside this checkout
+	Rationale string `json:",omitempty"` // the comment explaining a retraction
+}
+
+// HasChanges reports whether there is at least one directive change to render. It is nil-safe so
+// callers can gate rendering without a separate nil check.
+func (d *GoModData) HasChanges() bool {
+	return d != nil && len(d.Changes) > 0
+}
+
+// Subject names the directive without its value, e.g. "replace example.com/a" or "toolchain".
+func (c GoModChange) Subject() string {
+	if c.Key == "" {
+		return string(c.Directive)
+	}
+	return string(c.Directive) + " " + c.Key
+}
+
+// Statement renders the directive as it reads in go.mod with the given value, e.g.
+// "replace example.com/a => ../a" or "godebug panicnil=1". Retractions carry no value and render
+// as their Subject.
+func (c GoModChange) Statement(value string) string {
+	switch c.Directive {
+	case GoModReplace:
+		return c.Subject() + " => " + value
+	case GoModGodebug:
+		return c.Subject() + "=" + value
+	case GoModToolchain:
+		return c.Subject() + " " + value
+	}
+	return c.Subject()
+}
@@ -0,0 +1,28 @@
+package release
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoModChange_Subject(t *testing.T) {
+	require.Equal(t, "replace example.com/a v1.0.0", GoModChange{Directive: GoModReplace, Key: "example.com/a v1.0.0"}.Subject())
+	require.Equal(t, "toolchain", GoModChange{Directive: GoModToolchain}.Subject())
+}
+
+func TestGoModChange_Statement(t *testing.T) {
+	tests := []struct {
+		change GoModChange
+		value  string
+		want   string
+	}{
+		{GoModChange{Directive: GoModReplace, Key: "example.com/a v1.0.0"}, "../a", "replace example.com/a v1.0.0 => ../a"},
+		{GoModChange{Directive: GoModRetract, Key: "[v1.0.0, v1.0.2]"}, "", "retract [v1.0.0, v1.0.2]"},
+		{GoModChange{Directive: GoModGodebug, Key: "panicnil"}, "1", "godebug panicnil=1"},
+		{GoModChange{Directive: GoModToolchain}, "go1.23.0", "toolchain go1.23.0"},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.want, tt.change.Statement(tt.value))
+	}
+}
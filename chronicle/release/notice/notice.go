@@ -0,0 +1,197 @@
+// Package notice finds the highlight paragraph maintainers write for a release
+// (the changelog's notice) in the places they already write it: the annotated
+// tag message, a notes file committed with the release, or a "Release notes"
+// block in the body of a pull request labelled for it.
+package notice
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/anchore/chronicle/chronicle/release"
+	"github.com/anchore/chronicle/chronicle/release/releasenote"
+	"github.com/anchore/chronicle/internal/git"
+	"github.com/anchore/chronicle/internal/log"
+)
+
+// Source is one place a notice can be written.
+type Source string
+
+const (
+	// SourceTag is the message of the annotated tag the release ends at.
+	SourceTag Source = "tag"
+	// SourceFile is <NotesDir>/<version>.md at the release's ref.
+	SourceFile Source = "file"
+	// SourcePR is a "Release notes" section in the body of a pull request
+	// merged in the release that carries PRLabel, whether or not the PR is
+	// listed among the changes.
+	SourcePR Source = "pr"
+)
+
+// Sources lists every known source.
+var Sources = []Source{SourceTag, SourceFile, SourcePR}
+
+type Config struct {
+	// Sources are checked in order; the first that has a notice wins.
+	Sources  []Source
+	NotesDir string
+	PRLabel  string
+}
+
+// gitReader is the slice of git.Interface the resolver depends on.
+type gitReader interface {
+	SearchForTag(tagRef string) (*git.Tag, error)
+	ListFilesAtRef(ref string, match func(path string) bool) ([]git.FileBlob, error)
+}
+
+// Resolver implements release.NoticeResolver over the configured sources.
+type Resolver struct {
+	git gitReader
+	cfg Config
+}
+
+func NewResolver(gitter gitReader, cfg Config) *Resolver {
+	return &Resolver{git: gitter, cfg: cfg}
+}
+
+// Notice returns the first notice found among the configured sources, or ""
+// when none has one. A source that can't be read is logged and skipped: a
+// missing notice never fails a changelog.
+func (r *Resolver) Notice(version, untilTag string, trunk *release.TrunkData) string {
+	for _, src := range r.cfg.Sources {
+		var (
+			text string
+			err  error
+		)
+		switch src {
+		case SourceTag:
+			text, err = r.fromTag(version, untilTag)
+		case SourceFile:
+			text, err = r.fromFile(version, untilTag)
+		case SourcePR:
+			text = r.fromPRs(trunk)
+		default:
+			err = fmt.Errorf("unknown notice source %q", src)
+		}
+		if err != nil {
+			log.WithFields("source", src, "error", err).Debug("unable to read release notice; skipping")
+			continue
+		}
+		if text != "" {
+			log.WithFields("source", src).Debug("found release notice")
+			return text
+		}
+	}
+	return ""
+}
+
+func (r *Resolver) fromTag(version, untilTag string) (string, error) {
+	if untilTag == "" {
+		return "", nil
+	}
+	tag, err := r.git.SearchForTag(untilTag)
+	if err != nil || tag == nil {
+		return "", err
+	}
+	msg := clean(tag.Message)
+	// `git tag -a v1.2.0 -m v1.2.0` (or "Release v1.2.0") is what most tags
+	// say, and repeating the version above the changes isn't a notice.
+	if isBoilerplate(msg, untilTag, version) {
+		return "", nil
+	}
+	return msg, nil
+}
+
+func isBoilerplate(msg string, names ...string) bool {
+	msg = strings.ToLower(strings.TrimSuffix(msg, "."))
+	for _, n := range names {
+		if n == "" {
+			continue
+		}
+		n = strings.ToLower(n)
+		for _, v := range []string{n, strings.TrimPrefix(n, "v")} {
+			if msg == v || msg == "release "+v || msg == "version "+v {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (r *Resolver) fromFile(version, untilTag string) (string, error) {
+	if version == "" || r.cfg.NotesDir == "" {
+		return "", nil
+	}
+	ref := untilTag
+	if ref == "" {
+		ref = "HEAD"
+	}
+	// maintainers name the file after the tag or the bare version, whichever
+	// they're used to: v1.2.0.md and 1.2.0.md both count.
+	want := map[string]int{}
+	for i, name := range []string{version, strings.TrimPrefix(version, "v"), "v" + strings.TrimPrefix(version, "v")} {
+		p := path.Join(strings.Trim(r.cfg.NotesDir, "/"), name+".md")
+		if _, ok := want[p]; !ok {
+			want[p] = i
+		}
+	}
+	files, err := r.git.ListFilesAtRef(ref, func(p string) bool {
+		_, ok := want[p]
+		return ok
+	})
+	if err != nil {
+		return "", err
+	}
+	var best *git.FileBlob
+	for i := range files {
+		if best == nil || want[files[i].Path] < want[best.Path] {
+			best = &files[i]
+		}
+	}
+	if best == nil {
+		return "", nil
+	}
+	return clean(string(best.Content)), nil
+}
+
+// fromPRs reads the notice from the release's merged PRs rather than its
+// changes: a PR opened only to carry the notice is typically labelled for it
+// alone, so the changelog filters it out for having no change type.
+func (r *Resolver) fromPRs(trunk *release.TrunkData) string {
+	if r.cfg.PRLabel == "" || trunk == nil {
+		return ""
+	}
+	var notes []string
+	seen := map[int]bool{}
+	// commits are newest-first; join the notes in the order they were merged
+	for i := len(trunk.Commits) - 1; i >= 0; i-- {
+		pr := trunk.Commits[i].PR
+		if pr == nil || seen[pr.Number] || !hasLabel(pr.Labels, r.cfg.PRLabel) {
+			continue
+		}
+		seen[pr.Number] = true
+		if text := releasenote.Section(pr.Body, "release notes", "release note"); text != "" {
+			notes = append(notes, text)
+		}
+	}
+	return strings.Join(notes, "\n\n")
+}
+
+func hasLabel(labels []string, want string) bool {
+	for _, l := range labels {
+		if strings.EqualFold(l, want) {
+			return true
+		}
+	}
+	return false
+}
+
+var htmlCommentPattern = regexp.MustCompile(`(?s)<!--.*?-->`)
+
+// clean drops HTML comments (PR templates are full of them) and surrounding
+// whitespace.
+func clean(s string) string {
+	return strings.TrimSpace(htmlCommentPattern.ReplaceAllString(s, ""))
+}
@@ -0,0 +1,135 @@
+package notice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/chronicle/chronicle/release"
+	"github.com/anchore/chronicle/internal/git"
+)
+
+// tagGit serves one tag (by name) and the files at each ref.
+type tagGit struct {
+	git.MockInterface
+	tag *git.Tag
+}
+
+func (g tagGit) SearchForTag(tagRef string) (*git.Tag, error) {
+	if g.tag != nil && g.tag.Name == tagRef {
+		return g.tag, nil
+	}
+	return nil, nil
+}
+
+func prCommit(number int, body string, labels ...string) release.TrunkCommit {
+	return release.TrunkCommit{PR: &release.TrunkPR{Number: number, Body: body, Labels: labels}}
+}
+
+// trunk lists commits newest-first, as the summarizer does.
+func trunk(commits ...release.TrunkCommit) *release.TrunkData {
+	return &release.TrunkData{Commits: commits}
+}
+
+func TestResolver_Notice(t *testing.T) {
+	all := Config{Sources: []Source{SourceTag, SourceFile, SourcePR}, NotesDir: ".chronicle/notes", PRLabel: "release-notice"}
+	notesFile := func(ref, name, content string) map[string][]git.FileBlob {
+		return map[string][]git.FileBlob{ref: {{Path: ".chronicle/notes/" + name, Content: []byte(content)}}}
+	}
+
+	tests := []struct {
+		name     string
+		cfg      Config
+		tag      *git.Tag
+		files    map[string][]git.FileBlob
+		version  string
+		untilTag string
+		trunk    *release.TrunkData
+		want     string
+	}{
+		{
+			name:     "tag message",
+			cfg:      all,
+			tag:      &git.Tag{Name: "v1.2.0", Annotated: true, Message: "Adds workspace support.\n"},
+			files:    notesFile("v1.2.0", "v1.2.0.md", "from the file"),
+			version:  "v1.2.0",
+			untilTag: "v1.2.0",
+			want:     "Adds workspace support.",
+		},
+		{
+			name:     "a tag message that only names the release falls through",
+			cfg:      all,
+			tag:      &git.Tag{Name: "v1.2.0", Annotated: true, Message: "Release 1.2.0\n"},
+			files:    notesFile("v1.2.0", "v1.2.0.md", "\nfrom the file\n"),
+			version:  "v1.2.0",
+			untilTag: "v1.2.0",
+			want:     "from the file",
+		},
+		{
+			name:     "order is configurable",
+			cfg:      Config{Sources: []Source{SourceFile, SourceTag}, NotesDir: ".chronicle/notes"},
+			tag:      &git.Tag{Name: "v1.2.0", Annotated: true, Message: "from the tag"},
+			files:    notesFile("v1.2.0", "1.2.0.md", "from the file"),
+			version:  "v1.2.0",
+			untilTag: "v1.2.0",
+			want:     "from the file",
+		},
+		{
+			name:    "notes file at HEAD for an untagged, speculated release",
+			cfg:     all,
+			files:   notesFile("HEAD", "v1.3.0.md", "from the file"),
+			version: "v1.3.0",
+			want:    "from the file",
+		},
+		{
+			name:    "release notes section of a labelled PR",
+			cfg:     all,
+			version: "v1.3.0",
+			trunk: trunk(
+				prCommit(2, "## Summary\n\nbig refactor\n\n## Release notes\n<!-- one paragraph for users -->\nThe **config** format changed.\n\n## Checklist\n- [x] tests", "enhancement", "Release-Notice"),
+				prCommit(1, "## Release notes\n\nnot labelled"),
+			),
+			want: "The **config** format changed.",
+		},
+		{
+			name:    "a notice PR with no change-type label, filtered from the changes",
+			cfg:     all,
+			version: "v1.3.0",
+			trunk: trunk(
+				release.TrunkCommit{PR: &release.TrunkPR{Number: 3, Body: "## Release notes\nThis release needs Go 1.23.", Labels: []string{"release-notice"}, Filtered: true, Reason: "no change-type"}},
+				release.TrunkCommit{Subject: "direct push"},
+			),
+			want: "This release needs Go 1.23.",
+		},
+		{
+			name:    "several labelled PRs are joined in merge order",
+			cfg:     all,
+			version: "v1.3.0",
+			trunk: trunk(
+				prCommit(5, "## Release notes\nsecond", "release-notice"),
+				prCommit(4, "## Release notes\nfirst", "release-notice"),
+			),
+			want: "first\n\nsecond",
+		},
+		{
+			name:  "PRs are opt-in",
+			cfg:   Config{Sources: []Source{SourceTag, SourceFile}, PRLabel: "release-notice"},
+			trunk: trunk(prCommit(2, "## Release notes\nhello", "release-notice")),
+			want:  "",
+		},
+		{
+			name:    "nothing written",
+			cfg:     all,
+			tag:     &git.Tag{Name: "v1.2.0"},
+			version: "v1.2.0",
+			want:    "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := tagGit{MockInterface: git.MockInterface{MockFilesAtRef: tt.files}, tag: tt.tag}
+			got := NewResolver(g, tt.cfg).Notice(tt.version, tt.untilTag, tt.trunk)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,330 @@
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/anchore/chronicle/chronicle/dependency"
+	"github.com/anchore/chronicle/chronicle/release"
+	"github.com/anchore/chronicle/chronicle/release/change"
+)
+
+// Decode reads a document previously written by this encoder back into a
+// release.Description, so it can be re-rendered in any other format without
+// re-running the (network-bound) release worker.
+//
+// Documents from any schema version with the same major component are accepted:
+// minor versions only add optional fields, which simply decode as empty when
+// missing. Anything that isn't part of the document — the releaser's opaque
+// Entry, the full per-ref scans behind the dependency diff, and presentation
+// settings — is not recovered.
+func Decode(r io.Reader) (*release.Description, error) {
+	var doc Document
+	dec := json.NewDecoder(r)
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("unable to parse changelog document: %w", err)
+	}
+	if err := checkSchemaVersion(doc.SchemaVersion); err != nil {
+		return nil, err
+	}
+	d := doc.description()
+	return &d, nil
+}
+
+func checkSchemaVersion(v string) error {
+	if v == "" {
+		return fmt.Errorf("changelog document has no schemaVersion (written by a chronicle release that predates the versioned json output?)")
+	}
+	if major(v) != major(SchemaVersion) {
+		return fmt.Errorf("unsupported changelog document schemaVersion %q (this chronicle reads %s.x)", v, major(SchemaVersion))
+	}
+	return nil
+}
+
+func major(v string) string {
+	m, _, _ := strings.Cut(strings.TrimPrefix(v, "v"), ".")
+	return m
+}
+
+// description is the inverse of newDocument.
+func (doc Document) description() release.Description {
+	d := release.Description{
+		Release:                 release.Release{Version: doc.Release.Version, Date: doc.Release.Date},
+		VCSReferenceURL:         doc.ReferenceURL,
+		VCSChangesURL:           doc.ChangesURL,
+		Notice:                  doc.Notice,
+		Speculated:              doc.Speculated,
+		ConventionalCommitTypes: doc.ConventionalCommitTypes,
+		DependencyDiff:          doc.Dependencies.diff(),
+		Toolchain:               doc.Toolchain.data(),
+		BaseImages:              doc.BaseImages.data(),
+		Actions:                 doc.Actions.data(),
+		GoMod:                   doc.GoMod.data(),
+		Trunk:                   doc.Trunk.data(),
+	}
+	if doc.PreviousRelease != nil {
+		d.PreviousRelease = &release.Release{Version: doc.PreviousRelease.Version, Date: doc.PreviousRelease.Date}
+	}
+	for _, s := range doc.Sections {
+		d.SupportedChanges = append(d.SupportedChanges, change.TypeTitle{ChangeType: s.Type.changeType(), Title: s.Title})
+	}
+	for _, c := range doc.Changes {
+		d.Changes = append(d.Changes, c.change())
+	}
+	return d
+}
+
+func (t ChangeType) changeType() change.Type {
+	return change.NewType(t.Name, change.ParseSemVerKind(t.Bump))
+}
+
+func changeTypes(ts []ChangeType) []change.Type {
+	var out []change.Type
+	for _, t := range ts {
+		out = append(out, t.changeType())
+	}
+	return out
+}
+
+func (c Change) change() change.Change {
+	out := change.Change{
+		Text:        c.Text,
+		ChangeTypes: changeTypes(c.Types),
+		Timestamp:   c.Timestamp,
+		EntryType:   c.Source,
+	}
+	for _, r := range c.References {
+		out.References = append(out.References, change.Reference{Text: r.Text, URL: r.URL})
+	}
+	if pr := c.PullRequest; pr != nil {
+		out.PullRequest = &change.PullRequest{
+			Number:       pr.Number,
+			Title:        pr.Title,
+			Author:       pr.Author,
+			URL:          pr.URL,
+			MergedAt:     pr.MergedAt,
+			Labels:       pr.Labels,
+			MergeCommit:  pr.MergeCommit,
+			LinkedIssues: pr.LinkedIssues,
+		}
+	}
+	if is := c.Issue; is != nil {
+		out.Issue = &change.Issue{
+			Number:     is.Number,
+			Title:      is.Title,
+			Author:     is.Author,
+			URL:        is.URL,
+			ClosedAt:   is.ClosedAt,
+			Labels:     is.Labels,
+			NotPlanned: is.NotPlanned,
+		}
+	}
+	return out
+}
+
+func (deps *Dependencies) diff() *dependency.Diff {
+	if deps == nil {
+		return nil
+	}
+	var changes []dependency.PackageChange
+	for _, c := range deps.Changes {
+		pc := dependency.PackageChange{
+			Name:         c.Name,
+			Type:         c.Type,
+			FromVersion:  c.FromVersion,
+			ToVersion:    c.ToVersion,
+			Kind:         dependency.ChangeKind(c.Kind),
+			PURL:         c.PURL,
+			Relationship: dependency.Relationship(c.Relationship),
+			Scope:        dependency.Scope(c.Scope),
+			FromLicenses: c.FromLicenses,
+			ToLicenses:   c.ToLicenses,
+			Member:       c.Member,
+		}
+		if v := c.Vulnerabilities; v != nil {
+			pc.Vuln = &dependency.VulnDelta{
+				Remediated: vulnerabilities(v.Remediated),
+				Introduced: vulnerabilities(v.Introduced),
+			}
+		}
+		for _, pr := range c.PullRequests {
+			pc.PullRequests = append(pc.PullRequests, dependency.PullRequest(pr))
+		}
+		changes = append(changes, pc)
+	}
+
+	// NewDiff recomputes the totals and the remediated/introduced counts from
+	// the changes, so they can't disagree with what is rendered.
+	diff := dependency.NewDiff(changes)
+	if deps.Vulnerabilities != nil {
+		// the full per-ref scans aren't part of the document, but an empty
+		// (non-nil) Vulns map is how a Diff says "this ref was vuln-matched";
+		// restore that signal so the diff reads as annotated downstream.
+		diff.Since.Vulns = map[dependency.VulnKey][]dependency.Vulnerability{}
+		diff.Until.Vulns = map[dependency.VulnKey][]dependency.Vulnerability{}
+		diff.RemainingCount = deps.Vulnerabilities.Remaining
+		diff.SuppressedCount = deps.Vulnerabilities.Suppressed
+	}
+	for _, pv := range deps.Remaining {
+		diff.Remaining = append(diff.Remaining, dependency.PackageVulns{
+			Package: dependency.Package{Name: pv.Name, Type: pv.Type, Version: pv.Version, PURL: pv.PURL, Member: pv.Member},
+			Vulns:   vulnerabilities(pv.Vulnerabilities),
+		})
+	}
+	for _, v := range deps.LicenseViolations {
+		diff.LicenseViolations = append(diff.LicenseViolations, dependency.LicenseViolation(v))
+	}
+	if deps.VulnerabilityDB != nil {
+		db := dependency.VulnerabilityDB(*deps.VulnerabilityDB)
+		diff.VulnerabilityDB = &db
+	}
+	return &diff
+}
+
+func vulnerabilities(vs []Vulnerability) []dependency.Vulnerability {
+	var out []dependency.Vulnerability
+	for _, v := range vs {
+		out = append(out, dependency.Vulnerability{
+			ID:             v.ID,
+			Severity:       v.Severity,
+			FixState:       v.FixState,
+			DataSource:     v.DataSource,
+			KnownExploited: v.KnownExploited,
+			EPSS:           v.EPSS,
+			EPSSPercentile: v.EPSSPercentile,
+			FixedIn:        v.FixedIn,
+		})
+	}
+	return out
+}
+
+func (tc *Toolchain) data() *release.ToolchainData {
+	if tc == nil {
+		return nil
+	}
+	out := &release.ToolchainData{}
+	for _, u := range tc.Updates {
+		out.Updates = append(out.Updates, release.ToolchainUpdate{
+			Tool:      dependency.Ecosystem(u.Ecosystem),
+			Source:    u.Source,
+			File:      u.File,
+			From:      u.From,
+			To:        u.To,
+			Direction: release.ToolchainDirection(u.Direction),
+		})
+	}
+	for _, w := range tc.Warnings {
+		out.Warnings = append(out.Warnings, release.ToolchainWarning{Tool: dependency.Ecosystem(w.Ecosystem), Message: w.Message, Files: w.Files})
+	}
+	return out
+}
+
+func (bi *BaseImages) data() *release.BaseImageData {
+	if bi == nil {
+		return nil
+	}
+	out := &release.BaseImageData{}
+	for _, u := range bi.Updates {
+		out.Updates = append(out.Updates, release.BaseImageUpdate{
+			File:      u.File,
+			Stage:     u.Stage,
+			From:      release.ImageReference(u.From),
+			To:        release.ImageReference(u.To),
+			Direction: release.BaseImageDirection(u.Direction),
+		})
+	}
+	return out
+}
+
+func (ac *Actions) data() *release.ActionsData {
+	if ac == nil {
+		return nil
+	}
+	out := &release.ActionsData{}
+	for _, c := range ac.Changes {
+		out.Changes = append(out.Changes, release.ActionChange{
+			Action:     c.Action,
+			Kind:       release.ActionChangeKind(c.Kind),
+			From:       c.From,
+			To:         c.To,
+			Files:      c.Files,
+			ThirdParty: c.ThirdParty,
+		})
+	}
+	for _, w := range ac.Warnings {
+		out.Warnings = append(out.Warnings, release.ActionWarning(w))
+	}
+	return out
+}
+
+func (gm *GoMod) data() *release.GoModData {
+	if gm == nil {
+		return nil
+	}
+	out := &release.GoModData{}
+	for _, c := range gm.Changes {
+		out.Changes = append(out.Changes, release.GoModChange{
+			Directive: release.GoModDirective(c.Directive),
+			Kind:      release.GoModChangeKind(c.Kind),
+			File:      c.File,
+			Key:       c.Key,
+			From:      c.From,
+			To:        c.To,
+			LocalPath: c.LocalPath,
+			Rationale: c.Rationale,
+		})
+	}
+	return out
+}
+
+func (t *Trunk) data() *release.TrunkData {
+	if t == nil {
+		return nil
+	}
+	out := &release.TrunkData{}
+	for _, c := range t.Commits {
+		tc := release.TrunkCommit{
+			Hash:      c.Hash,
+			URL:       c.URL,
+			Subject:   c.Subject,
+			Author:    c.Author,
+			Timestamp: c.Timestamp,
+		}
+		if pr := c.PullRequest; pr != nil {
+			tc.PR = &release.TrunkPR{
+				Number:      pr.Number,
+				Title:       pr.Title,
+				URL:         pr.URL,
+				Author:      pr.Author,
+				Labels:      pr.Labels,
+				ChangeTypes: changeTypes(pr.Types),
+				Filtered:    pr.Filtered,
+				Reason:      pr.Reason,
+			}
+			for _, is := range pr.Issues {
+				tc.PR.Issues = append(tc.PR.Issues, release.TrunkIssue{
+					Number:      is.Number,
+					Title:       is.Title,
+					URL:         is.URL,
+					Labels:      is.Labels,
+					ChangeTypes: changeTypes(is.Types),
+					Filtered:    is.Filtered,
+					Reason:      is.Reason,
+				})
+			}
+		}
+		for _, dc := range c.Dependencies {
+			tc.Dependencies = append(tc.Dependencies, dependency.PackageChange{
+				Name:        dc.Name,
+				Type:        dc.Type,
+				FromVersion: dc.FromVersion,
+				ToVersion:   dc.ToVersion,
+				Kind:        dependency.ChangeKind(dc.Kind),
+			})
+		}
+		out.Commits = append(out.Commits, tc)
+	}
+	return out
+}
@@ -0,0 +1,214 @@
+package json
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/chronicle/chronicle/dependency"
+	"github.com/anchore/chronicle/chronicle/release"
+	"github.com/anchore/chronicle/chronicle/release/change"
+	"github.com/anchore/chronicle/chronicle/release/output/encoders/markdown"
+	"github.com/anchore/chronicle/chronicle/release/output/encoders/markdownpretty"
+	"github.com/anchore/chronicle/chronicle/release/output/encoders/slack"
+	"github.com/anchore/chronicle/chronicle/release/output/encoders/trunk"
+	"github.com/anchore/chronicle/chronicle/release/output/encoders/version"
+	"github.com/anchore/chronicle/chronicle/release/output/encoders/vex"
+	"github.com/anchore/chronicle/chronicle/release/render"
+)
+
+// annotatedDescription extends sampleDescription with everything the prose
+// encoders can render: a notice, a vuln-annotated diff whose changes carry
+// relationships, scopes, licenses and workspace members, remaining vulns,
+// base-image, action and go.mod rollups, trunk data, and an unknown-kind
+// change type.
+func annotatedDescription() release.Description {
+	d := sampleDescription()
+	d.Notice = "This release drops support for **Go 1.21**."
+
+	diff := dependency.NewDiff([]dependency.PackageChange{
+		{
+			Name: "golang.org/x/net", Type: "go-module", FromVersion: "v0.1.0", ToVersion: "v0.2.0", Kind: dependency.Updated, PURL: "pkg:golang/golang.org/x/net@v0.2.0",
+			Relationship: dependency.Direct, Scope: dependency.ScopeRuntime, Member: "api",
+			FromLicenses: []string{"BSD-3-Clause"}, ToLicenses: []string{"Apache-2.0", "BSD-3-Clause"},
+			Vuln:         &dependency.VulnDelta{Remediated: []dependency.Vulnerability{{ID: "CVE-2026-0001", Severity: "High", FixState: "fixed", DataSource: "https://nvd.nist.gov/vuln/detail/CVE-2026-0001"}}},
+			PullRequests: []dependency.PullRequest{{Number: 42, Title: "bump x/net", URL: "https://github.com/anchore/chronicle/pull/42", Author: "dependabot"}},
+		},
+		{Name: "left-pad", Type: "npm", ToVersion: "1.3.0", Kind: dependency.Added, Relationship: dependency.Direct, Scope: dependency.ScopeDev, ToLicenses: []string{"WTFPL"}, Member: "web", Vuln: &dependency.VulnDelta{}},
+		{Name: "lodash", Type: "npm", FromVersion: "4.17.21", Kind: dependency.Removed, Relationship: dependency.Transitive, FromLicenses: []string{"MIT"}, Member: "web", Vuln: &dependency.VulnDelta{}},
+		{
+			Name: "requests", Type: "python", FromVersion: "2.32.0", ToVersion: "2.31.0", Kind: dependency.Downgraded,
+			Relationship: dependency.Transitive, Scope: dependency.ScopeRuntime, FromLicenses: []string{"Apache-2.0"}, ToLicenses: []string{"Apache-2.0"},
+			Vuln: &dependency.VulnDelta{Introduced: []dependency.Vulnerability{{ID: "GHSA-aaaa-bbbb-cccc", Severity: "Medium", FixState: "fixed", KnownExploited: true, EPSS: 0.42, EPSSPercentile: 0.97, FixedIn: []string{"1.2.4"}}}},
+		},
+	})
+	diff.Since.Vulns = map[dependency.VulnKey][]dependency.Vulnerability{}
+	diff.Until.Vulns = map[dependency.VulnKey][]dependency.Vulnerability{}
+	diff.Remaining = []dependency.PackageVulns{
+		{Package: dependency.Package{Name: "openssl", Version: "3.0.0", Type: "binary", PURL: "pkg:generic/openssl@3.0.0", Member: "."}, Vulns: []dependency.Vulnerability{{ID: "CVE-2025-9999", Severity: "Critical"}}},
+	}
+	diff.RemainingCount = 1
+	diff.SuppressedCount = 2
+	diff.LicenseViolations = []dependency.LicenseViolation{{Name: "left-pad", Type: "npm", Version: "1.3.0", License: "WTFPL"}}
+	diff.VulnerabilityDB = &dependency.VulnerabilityDB{Built: time.Date(2026, 10, 1, 4, 12, 0, 0, time.UTC), SchemaVersion: "v6.1.0"}
+	d.DependencyDiff = &diff
+
+	d.BaseImages = &release.BaseImageData{Updates: []release.BaseImageUpdate{
+		{
+			File: "Dockerfile", Stage: "build",
+			From:      release.ImageReference{Name: "golang", Tag: "1.22-alpine"},
+			To:        release.ImageReference{Name: "golang", Tag: "1.23-alpine", Digest: "sha256:abc"},
+			Direction: release.BaseImageUpgrade,
+		},
+		{
+			File: "Dockerfile", Stage: "#1",
+			From:      release.ImageReference{Name: "alpine", Tag: "3.20"},
+			To:        release.ImageReference{Name: "alpine", Tag: "3.19"},
+			Direction: release.BaseImageDowngrade,
+		},
+		{
+			File: "deploy/Dockerfile", Stage: "#0",
+			From: release.ImageReference{Name: "gcr.io/distroless/static", Tag: "nonroot", Digest: "sha256:1111111111111111111111111111111111111111111111111111111111111111"},
+			To:   release.ImageReference{Name: "gcr.io/distroless/static", Tag: "nonroot", Digest: "sha256:2222222222222222222222222222222222222222222222222222222222222222"},
+		},
+	}}
+
+	d.Actions = &release.ActionsData{
+		Changes: []release.ActionChange{
+			{Action: "actions/checkout", Kind: release.ActionPinned, From: []string{"v4"}, To: []string{"11bd71901bbe5b1630ceea73d27597364c9af683"}, Files: []string{".github/workflows/ci.yml", ".github/workflows/release.yml"}},
+			{Action: "actions/setup-go", Kind: release.ActionUpdated, From: []string{"v4", "v5"}, To: []string{"v5"}, Files: []string{".github/workflows/ci.yml"}},
+			{Action: "docker://alpine", Kind: release.ActionAdded, To: []string{"3.20"}, Files: []string{".github/workflows/ci.yml"}, ThirdParty: true},
+			{Action: "tj-actions/changed-files", Kind: release.ActionUnpinned, From: []string{"0c52d547c9bc32b1aa3301fd7a9cb496313a4491"}, To: []string{"v45"}, Files: []string{".github/workflows/ci.yml"}, ThirdParty: true},
+			{Action: "peter-evans/create-pull-request", Kind: release.ActionRemoved, From: []string{"v6"}, Files: []string{".github/workflows/bump.yml"}, ThirdParty: true},
+		},
+		Warnings: []release.ActionWarning{{
+			Action: "tj-actions/changed-files", Ref: "v45",
+			Message: "tj-actions/changed-files@v45 is not pinned to a commit SHA (.github/workflows/ci.yml)",
+			Files:   []string{".github/workflows/ci.yml"},
+		}},
+	}
+
+	d.GoMod = &release.GoModData{Changes: []release.GoModChange{
+		{Directive: release.GoModReplace, Kind: release.GoModAdded, File: "go.mod", Key: "example.com/lib", To: "../lib", LocalPath: true},
+		{Directive: release.GoModRetract, Kind: release.GoModAdded, File: "go.mod", Key: "[v0.1.0, v0.1.2]", Rationale: "broken build"},
+	}}
+
+	d.Changes = append(d.Changes, change.Change{
+		Text:        "misc cleanup",
+		ChangeTypes: change.UnknownTypes,
+		Timestamp:   time.Date(2026, 1, 1, 13, 0, 0, 0, time.UTC),
+	})
+	d.SupportedChanges = append(d.SupportedChanges, change.TypeTitle{ChangeType: change.UnknownType, Title: "Additional Changes"})
+
+	d.Trunk = &release.TrunkData{Commits: []release.TrunkCommit{
+		{
+			Hash: "abc1234def", Subject: "fix something (#1)", Author: "alice", Timestamp: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC),
+			PR: &release.TrunkPR{
+				Number: 1, Title: "fix something", URL: "https://example.com/pull/1", Author: "alice", Labels: []string{"bug"},
+				ChangeTypes: []change.Type{change.NewType("bug", change.SemVerPatch)},
+				Issues:      []release.TrunkIssue{{Number: 7, Title: "crash on start", URL: "https://example.com/issues/7"}},
+			},
+			Dependencies: []dependency.PackageChange{{Name: "golang.org/x/net", Type: "go-module", FromVersion: "v0.1.0", ToVersion: "v0.2.0", Kind: dependency.Updated}},
+		},
+		{Hash: "0011223344", Subject: "chore: bump ci", Author: "bob", Timestamp: time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)},
+	}}
+
+	cfg := render.DefaultConfig()
+	cfg.ShowRemaining = true
+	cfg.Transitive = map[dependency.ChangeKind][]render.Mode{
+		dependency.Removed:    {render.ModeSummary},
+		dependency.Downgraded: {render.ModeList},
+	}
+	d.DependencyRender = &cfg
+	return d
+}
+
+// encoder is output.Encoder, restated so this test doesn't import the output
+// package just for the interface.
+type encoder interface {
+	ID() string
+	Encode(w io.Writer, title string, d release.Description) error
+}
+
+// TestDecode_RoundTripEveryEncoder asserts that rendering a description
+// directly and rendering it after a trip through the json document produce
+// byte-identical output, for every encoder — i.e. `chronicle render --from`
+// loses nothing any encoder displays.
+func TestDecode_RoundTripEveryEncoder(t *testing.T) {
+	in := annotatedDescription()
+
+	var doc bytes.Buffer
+	require.NoError(t, (&Encoder{}).Encode(&doc, "", in))
+	decoded, err := Decode(bytes.NewReader(doc.Bytes()))
+	require.NoError(t, err)
+	// presentation isn't part of the document; the render command supplies it
+	decoded.DependencyRender = in.DependencyRender
+
+	for _, enc := range []encoder{
+		&Encoder{},
+		&markdown.Encoder{},
+		&markdown.Encoder{NoCollapse: true},
+		&markdownpretty.Encoder{IsTTY: false},
+		&slack.Encoder{},
+		&trunk.Encoder{Condensed: true, ShowFiltered: true},
+		&trunk.Encoder{Condensed: false, ShowFiltered: true},
+		&version.Encoder{},
+		&vex.Encoder{},
+		&vex.CycloneDXEncoder{},
+	} {
+		t.Run(enc.ID(), func(t *testing.T) {
+			var want, got bytes.Buffer
+			require.NoError(t, enc.Encode(&want, "{{ .Version }}", in))
+			require.NoError(t, enc.Encode(&got, "{{ .Version }}", *decoded))
+			require.NotEmpty(t, want.String())
+			require.Equal(t, want.String(), got.String())
+		})
+	}
+}
+
+func TestDecode_SchemaVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		wantErr string
+	}{
+		{name: "current", doc: `{"schemaVersion":"` + SchemaVersion + `","release":{"version":"v1.0.0"}}`},
+		{name: "newer minor", doc: `{"schemaVersion":"1.99.0","release":{"version":"v1.0.0"}}`},
+		{name: "missing", doc: `{"Version":"v1.0.0"}`, wantErr: "no schemaVersion"},
+		{name: "other major", doc: `{"schemaVersion":"2.0.0"}`, wantErr: "unsupported"},
+		{name: "not json", doc: `# v1.0.0`, wantErr: "unable to parse"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := Decode(strings.NewReader(tt.doc))
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "v1.0.0", d.Version)
+		})
+	}
+}
+
+func TestDecode_PreservesAnnotationSignal(t *testing.T) {
+	for name, annotated := range map[string]bool{"annotated": true, "unannotated": false} {
+		t.Run(name, func(t *testing.T) {
+			diff := dependency.NewDiff([]dependency.PackageChange{{Name: "a", Type: "npm", ToVersion: "1", Kind: dependency.Added}})
+			if annotated {
+				diff.Until.Vulns = map[dependency.VulnKey][]dependency.Vulnerability{}
+			}
+			var doc bytes.Buffer
+			require.NoError(t, (&Encoder{}).Encode(&doc, "", release.Description{DependencyDiff: &diff}))
+
+			d, err := Decode(&doc)
+			require.NoError(t, err)
+			require.Equal(t, annotated, d.DependencyDiff.Until.Vulns != nil)
+			require.Equal(t, diff.Totals, d.DependencyDiff.Totals)
+		})
+	}
+}
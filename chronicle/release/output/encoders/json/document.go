@@ -0,0 +1,605 @@
+package json
+
+import (
+	"time"
+
+	"github.com/anchore/chronicle/chronicle/dependency"
+	"github.com/anchore/chronicle/chronicle/release"
+	"github.com/anchore/chronicle/chronicle/release/change"
+)
+
+// SchemaVersion is the version of the JSON document this encoder produces. It
+// follows semver: a new optional field is a minor bump, a rename or removal is
+// a major bump. A chronicle release that changes the types in this file bumps
+// it once, however many changes it carries, and publishes the regenerated
+// schema (see schema/json in the repo root).
+const SchemaVersion = "1.0.0"
+
+// SchemaURL is where the schema for SchemaVersion is published.
+const SchemaURL = "https://raw.githubusercontent.com/anchore/chronicle/main/schema/json/schema-" + SchemaVersion + ".json"
+
+// Document is the stable, versioned shape of the `json` output. It is
+// deliberately a separate set of types from release.Description: internal
+// fields can be renamed or restructured freely, and only the mapping in
+// newDocument has to follow — downstream tooling keeps parsing the same
+// document until SchemaVersion says otherwise.
+type Document struct {
+	Schema                  string        `json:"$schema"`
+	SchemaVersion           string        `json:"schemaVersion" jsonschema_description:"semver of this document's shape; consumers should check the major component"`
+	Release                 Release       `json:"release" jsonschema_description:"the release being described"`
+	PreviousRelease         *Release      `json:"previousRelease,omitempty" jsonschema_description:"the release this changelog starts from; absent when starting from the beginning of history"`
+	Speculated              bool          `json:"speculated" jsonschema_description:"true when the version was inferred from the changes rather than read from a tag"`
+	ReferenceURL            string        `json:"referenceUrl,omitempty" jsonschema_description:"where to find more information about this release"`
+	ChangesURL              string        `json:"changesUrl,omitempty" jsonschema_description:"where to find the source changes that make up this release"`
+	Notice                  string        `json:"notice,omitempty"`
+	Sections                []Section     `json:"sections" jsonschema_description:"the changelog sections, in display order"`
+	ConventionalCommitTypes []string      `json:"conventionalCommitTypes,omitempty"`
+	Changes                 []Change      `json:"changes"`
+	Dependencies            *Dependencies `json:"dependencies,omitempty" jsonschema_description:"the dependency diff between the two refs; absent when dependency scanning is disabled"`
+	Toolchain               *Toolchain    `json:"toolchain,omitempty"`
+	BaseImages              *BaseImages   `json:"baseImages,omitempty" jsonschema_description:"Dockerfile base images whose reference changed between the two refs"`
+	Actions                 *Actions      `json:"actions,omitempty" jsonschema_description:"GitHub Actions whose workflow uses: references changed between the two refs"`
+	GoMod                   *GoMod        `json:"goMod,omitempty" jsonschema_description:"go.mod replace, retract, godebug and toolchain directives that changed between the two refs"`
+	Trunk                   *Trunk        `json:"trunk,omitempty"`
+}
+
+type Release struct {
+	Version string    `json:"version"`
+	Date    time.Time `json:"date"`
+}
+
+// Section is one changelog section: the change type it collects and its title.
+type Section struct {
+	Type  ChangeType `json:"type"`
+	Title string     `json:"title"`
+}
+
+// ChangeType is a kind of change and the semver field it bumps.
+type ChangeType struct {
+	Name string `json:"name"`
+	Bump string `json:"bump,omitempty" jsonschema:"enum=major,enum=minor,enum=patch"`
+}
+
+type Change struct {
+	Text        string       `json:"text"`
+	Types       []ChangeType `json:"types"`
+	Timestamp   time.Time    `json:"timestamp"`
+	References  []Reference  `json:"references,omitempty"`
+	Source      string       `json:"source,omitempty" jsonschema_description:"where the change came from, e.g. githubPR or githubIssue"`
+	PullRequest *PullRequest `json:"pullRequest,omitempty"`
+	Issue       *Issue       `json:"issue,omitempty"`
+}
+
+type Reference struct {
+	Text string `json:"text"`
+	URL  string `json:"url,omitempty"`
+}
+
+type PullRequest struct {
+	Number       int       `json:"number"`
+	Title        string    `json:"title"`
+	Author       string    `json:"author,omitempty"`
+	URL          string    `json:"url,omitempty"`
+	MergedAt     time.Time `json:"mergedAt"`
+	Labels       []string  `json:"labels,omitempty"`
+	MergeCommit  string    `json:"mergeCommit,omitempty"`
+	LinkedIssues []int     `json:"linkedIssues,omitempty"`
+}
+
+type Issue struct {
+	Number     int       `json:"number"`
+	Title      string    `json:"title"`
+	Author     string    `json:"author,omitempty"`
+	URL        string    `json:"url,omitempty"`
+	ClosedAt   time.Time `json:"closedAt"`
+	Labels     []string  `json:"labels,omitempty"`
+	NotPlanned bool      `json:"notPlanned,omitempty"`
+}
+
+type Dependencies struct {
+	Totals            DependencyTotals     `json:"totals"`
+	Vulnerabilities   *VulnerabilityTotals `json:"vulnerabilities,omitempty" jsonschema_description:"unique vulnerability counts; absent when vulnerability annotation is disabled"`
+	Changes           []PackageChange      `json:"changes"`
+	Remaining         []PackageVulns       `json:"remaining,omitempty" jsonschema_description:"vulnerabilities present at both refs, per package in the latest scan"`
+	LicenseViolations []LicenseViolation   `json:"licenseViolations,omitempty" jsonschema_description:"changes that brought in a license the configured policy denies"`
+	VulnerabilityDB   *VulnerabilityDB     `json:"vulnerabilityDB,omitempty" jsonschema_description:"the vulnerability DB build the changes were matched against; absent when vulnerability annotation is disabled"`
+}
+
+// VulnerabilityDB identifies the DB build vulnerability annotations came from,
+// so two documents can be told apart (or confirmed comparable) by it.
+type VulnerabilityDB struct {
+	Built         time.Time `json:"built" jsonschema_description:"when the DB was built"`
+	SchemaVersion string    `json:"schemaVersion,omitempty" jsonschema_description:"the DB schema version, e.g. v6.0.2"`
+}
+
+// LicenseViolation is a package whose change introduced a denied license.
+type LicenseViolation struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Version string `json:"version,omitempty"`
+	License string `json:"license" jsonschema_description:"the denied license ID, as the package declares it"`
+}
+
+// PackageVulns is a package in the latest scan and the vulnerabilities it
+// carried over from the previous release.
+type PackageVulns struct {
+	Name            string          `json:"name"`
+	Type            string          `json:"type"`
+	Version         string          `json:"version,omitempty"`
+	PURL            string          `json:"purl,omitempty" jsonschema_description:"the package URL, when the scanner reported one"`
+	Member          string          `json:"member,omitempty" jsonschema_description:"the workspace member the package was cataloged in, as a directory relative to the repository root ('.' for the root); absent when the repository declares no workspace"`
+	Vulnerabilities []Vulnerability `json:"vulnerabilities"`
+}
+
+type DependencyTotals struct {
+	Updated    int `json:"updated"`
+	Downgraded int `json:"downgraded"`
+	Added      int `json:"added"`
+	Removed    int `json:"removed"`
+}
+
+type VulnerabilityTotals struct {
+	Remediated int `json:"remediated"`
+	Introduced int `json:"introduced"`
+	Remaining  int `json:"remaining"`
+	Suppressed int `json:"suppressed,omitempty" jsonschema_description:"unique vulnerability IDs that VEX statements declared not_affected, and so are left out of introduced and remaining"`
+}
+
+type PackageChange struct {
+	Name            string              `json:"name"`
+	Type            string              `json:"type" jsonschema_description:"the syft package type, e.g. go-module or npm"`
+	FromVersion     string              `json:"fromVersion,omitempty"`
+	ToVersion       string              `json:"toVersion,omitempty"`
+	Kind            string              `json:"kind" jsonschema:"enum=added,enum=removed,enum=updated,enum=downgraded"`
+	PURL            string              `json:"purl,omitempty" jsonschema_description:"the package URL after the change (before it, for removed packages), when the scanner reported one"`
+	Relationship    string              `json:"relationship,omitempty" jsonschema:"enum=direct,enum=transitive" jsonschema_description:"whether the project depends on the package itself or only through another dependency; absent when the scanner could not tell"`
+	Scope           string              `json:"scope,omitempty" jsonschema:"enum=runtime,enum=dev" jsonschema_description:"whether the package is needed at runtime or only for development; absent when the scanner could not tell"`
+	FromLicenses    []string            `json:"fromLicenses,omitempty" jsonschema_description:"the package's licenses before the change, when cataloged"`
+	ToLicenses      []string            `json:"toLicenses,omitempty" jsonschema_description:"the package's licenses after the change, when cataloged"`
+	Member          string              `json:"member,omitempty" jsonschema_description:"the workspace member the change was made in, as a directory relative to the repository root ('.' for the root); absent when the repository declares no workspace"`
+	Vulnerabilities *VulnerabilityDelta `json:"vulnerabilities,omitempty"`
+	PullRequests    []PullRequestRef    `json:"pullRequests,omitempty" jsonschema_description:"the merged pull requests whose manifest or lockfile edits put the package at its final version (or removed it), oldest first"`
+}
+
+// PullRequestRef is the short form of a pull request a dependency change is
+// attributed to; if the PR made the changelog, its full record is in the
+// document's top-level changes.
+type PullRequestRef struct {
+	Number int    `json:"number"`
+	Title  string `json:"title,omitempty"`
+	URL    string `json:"url,omitempty"`
+	Author string `json:"author,omitempty"`
+}
+
+type VulnerabilityDelta struct {
+	Remediated []Vulnerability `json:"remediated,omitempty"`
+	Introduced []Vulnerability `json:"introduced,omitempty"`
+}
+
+type Vulnerability struct {
+	ID             string   `json:"id"`
+	Severity       string   `json:"severity,omitempty"`
+	FixState       string   `json:"fixState,omitempty"`
+	DataSource     string   `json:"dataSource,omitempty"`
+	KnownExploited bool     `json:"knownExploited,omitempty" jsonschema_description:"true when CISA lists the vulnerability as known exploited (KEV)"`
+	EPSS           float64  `json:"epss,omitempty" jsonschema_description:"EPSS score: the probability (0-1) of exploitation in the next 30 days; absent when unscored"`
+	EPSSPercentile float64  `json:"epssPercentile,omitempty" jsonschema_description:"the EPSS score's percentile (0-1) among all scored vulnerabilities"`
+	FixedIn        []string `json:"fixedIn,omitempty" jsonschema_description:"package versions that fix the vulnerability, when known"`
+}
+
+type Toolchain struct {
+	Updates  []ToolchainUpdate  `json:"updates,omitempty"`
+	Warnings []ToolchainWarning `json:"warnings,omitempty"`
+}
+
+type ToolchainUpdate struct {
+	Ecosystem string `json:"ecosystem"`
+	Source    string `json:"source"`
+	File      string `json:"file,omitempty"`
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Direction string `json:"direction,omitempty" jsonschema:"enum=upgrade,enum=downgrade"`
+}
+
+type ToolchainWarning struct {
+	Ecosystem string   `json:"ecosystem"`
+	Message   string   `json:"message"`
+	Files     []string `json:"files,omitempty"`
+}
+
+type BaseImages struct {
+	Updates []BaseImageUpdate `json:"updates"`
+}
+
+type BaseImageUpdate struct {
+	File      string         `json:"file"`
+	Stage     string         `json:"stage" jsonschema_description:"the stage name, or #N (0-based position) for an unnamed stage"`
+	From      ImageReference `json:"from"`
+	To        ImageReference `json:"to"`
+	Direction string         `json:"direction,omitempty" jsonschema:"enum=upgrade,enum=downgrade"`
+}
+
+type ImageReference struct {
+	Name   string `json:"name"`
+	Tag    string `json:"tag,omitempty"`
+	Digest string `json:"digest,omitempty"`
+}
+
+type Actions struct {
+	Changes  []ActionChange  `json:"changes"`
+	Warnings []ActionWarning `json:"warnings,omitempty" jsonschema_description:"references newly left unpinned (not a full commit SHA or image digest)"`
+}
+
+type ActionChange struct {
+	Action     string   `json:"action"`
+	Kind       string   `json:"kind" jsonschema:"enum=added,enum=removed,enum=updated,enum=pinned,enum=unpinned"`
+	From       []string `json:"from,omitempty"`
+	To         []string `json:"to,omitempty"`
+	Files      []string `json:"files,omitempty"`
+	ThirdParty bool     `json:"thirdParty" jsonschema_description:"true when the action is maintained outside GitHub's actions and github organizations"`
+}
+
+type ActionWarning struct {
+	Action  string   `json:"action"`
+	Ref     string   `json:"ref"`
+	Message string   `json:"message"`
+	Files   []string `json:"files,omitempty"`
+}
+
+type GoMod struct {
+	Changes []GoModChange `json:"changes"`
+}
+
+type GoModChange struct {
+	Directive string `json:"directive" jsonschema:"enum=replace,enum=retract,enum=godebug,enum=toolchain"`
+	Kind      string `json:"kind" jsonschema:"enum=added,enum=removed,enum=changed"`
+	File      string `json:"file"`
+	Key       string `json:"key,omitempty" jsonschema_description:"the replaced module (with its version when only that version is replaced), the godebug setting, or the retracted version or range; absent for toolchain"`
+	From      string `json:"from,omitempty"`
+	To        string `json:"to,omitempty"`
+	LocalPath bool   `json:"localPath,omitempty" jsonschema_description:"true when a replace points at a local directory, which consumers of the module cannot resolve"`
+	Rationale string `json:"rationale,omitempty" jsonschema_description:"the comment explaining a retraction"`
+}
+
+type Trunk struct {
+	Commits []TrunkCommit `json:"commits" jsonschema_description:"commits in the range, newest first"`
+}
+
+type TrunkCommit struct {
+	Hash         string            `json:"hash"`
+	URL          string            `json:"url,omitempty"`
+	Subject      string            `json:"subject"`
+	Author       string            `json:"author,omitempty"`
+	Timestamp    time.Time         `json:"timestamp"`
+	PullRequest  *TrunkPullRequest `json:"pullRequest,omitempty"`
+	Dependencies []TrunkDependency `json:"dependencies,omitempty" jsonschema_description:"the package changes this commit's manifest and lockfile edits made, when the dependency timeline is enabled"`
+}
+
+// TrunkDependency is one dependency event on a commit. Unlike the release's
+// package changes it carries no vulnerability or license detail: the timeline
+// reads lockfiles, it doesn't scan.
+type TrunkDependency struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	FromVersion string `json:"fromVersion,omitempty"`
+	ToVersion   string `json:"toVersion,omitempty"`
+	Kind        string `json:"kind" jsonschema:"enum=added,enum=removed,enum=updated,enum=downgraded"`
+}
+
+type TrunkPullRequest struct {
+	Number   int          `json:"number"`
+	Title    string       `json:"title"`
+	URL      string       `json:"url,omitempty"`
+	Author   string       `json:"author,omitempty"`
+	Labels   []string     `json:"labels,omitempty"`
+	Types    []ChangeType `json:"types,omitempty"`
+	Issues   []TrunkIssue `json:"issues,omitempty"`
+	Filtered bool         `json:"filtered,omitempty"`
+	Reason   string       `json:"reason,omitempty" jsonschema_description:"why the PR was filtered out of the changelog, e.g. label:chore"`
+}
+
+type TrunkIssue struct {
+	Number   int          `json:"number"`
+	Title    string       `json:"title"`
+	URL      string       `json:"url,omitempty"`
+	Labels   []string     `json:"labels,omitempty"`
+	Types    []ChangeType `json:"types,omitempty"`
+	Filtered bool         `json:"filtered,omitempty"`
+	Reason   string       `json:"reason,omitempty"`
+}
+
+// newDocument maps a Description onto the versioned document. Empty slices are
+// kept as [] rather than null for the always-present collections so consumers
+// can iterate without a nil check.
+func newDocument(d release.Description) Document {
+	doc := Document{
+		Schema:                  SchemaURL,
+		SchemaVersion:           SchemaVersion,
+		Release:                 Release{Version: d.Version, Date: d.Date},
+		Speculated:              d.Speculated,
+		ReferenceURL:            d.VCSReferenceURL,
+		ChangesURL:              d.VCSChangesURL,
+		Notice:                  d.Notice,
+		Sections:                []Section{},
+		ConventionalCommitTypes: d.ConventionalCommitTypes,
+		Changes:                 []Change{},
+		Dependencies:            newDependencies(d.DependencyDiff),
+		Toolchain:               newToolchain(d.Toolchain),
+		BaseImages:              newBaseImages(d.BaseImages),
+		Actions:                 newActions(d.Actions),
+		GoMod:                   newGoMod(d.GoMod),
+		Trunk:                   newTrunk(d.Trunk),
+	}
+	if d.PreviousRelease != nil {
+		doc.PreviousRelease = &Release{Version: d.PreviousRelease.Version, Date: d.PreviousRelease.Date}
+	}
+	for _, s := range d.SupportedChanges {
+		doc.Sections = append(doc.Sections, Section{Type: newChangeType(s.ChangeType), Title: s.Title})
+	}
+	for _, c := range d.Changes {
+		doc.Changes = append(doc.Changes, newChange(c))
+	}
+	return doc
+}
+
+func newChangeType(t change.Type) ChangeType {
+	return ChangeType{Name: t.Name, Bump: t.Kind.String()}
+}
+
+func newChangeTypes(ts []change.Type) []ChangeType {
+	out := make([]ChangeType, 0, len(ts))
+	for _, t := range ts {
+		out = append(out, newChangeType(t))
+	}
+	return out
+}
+
+func newChange(c change.Change) Change {
+	out := Change{
+		Text:      c.Text,
+		Types:     newChangeTypes(c.ChangeTypes),
+		Timestamp: c.Timestamp,
+		Source:    c.EntryType,
+	}
+	for _, r := range c.References {
+		out.References = append(out.References, Reference{Text: r.Text, URL: r.URL})
+	}
+	if pr := c.PullRequest; pr != nil {
+		out.PullRequest = &PullRequest{
+			Number:       pr.Number,
+			Title:        pr.Title,
+			Author:       pr.Author,
+			URL:          pr.URL,
+			MergedAt:     pr.MergedAt,
+			Labels:       pr.Labels,
+			MergeCommit:  pr.MergeCommit,
+			LinkedIssues: pr.LinkedIssues,
+		}
+	}
+	if is := c.Issue; is != nil {
+		out.Issue = &Issue{
+			Number:     is.Number,
+			Title:      is.Title,
+			Author:     is.Author,
+			URL:        is.URL,
+			ClosedAt:   is.ClosedAt,
+			Labels:     is.Labels,
+			NotPlanned: is.NotPlanned,
+		}
+	}
+	return out
+}
+
+func newDependencies(diff *dependency.Diff) *Dependencies {
+	if diff == nil {
+		return nil
+	}
+	out := &Dependencies{
+		Totals: DependencyTotals{
+			Updated:    diff.Totals.Updated,
+			Downgraded: diff.Totals.Downgraded,
+			Added:      diff.Totals.Added,
+			Removed:    diff.Totals.Removed,
+		},
+		Changes: []PackageChange{},
+	}
+	// a vuln-matched scan always carries a non-nil Vulns map, so its presence is
+	// what distinguishes "annotated, found nothing" from "not annotated".
+	if diff.Since.Vulns != nil || diff.Until.Vulns != nil {
+		out.Vulnerabilities = &VulnerabilityTotals{
+			Remediated: diff.RemediatedCount,
+			Introduced: diff.IntroducedCount,
+			Remaining:  diff.RemainingCount,
+			Suppressed: diff.SuppressedCount,
+		}
+	}
+	for _, c := range diff.Changes {
+		pc := PackageChange{
+			Name:         c.Name,
+			Type:         c.Type,
+			FromVersion:  c.FromVersion,
+			ToVersion:    c.ToVersion,
+			Kind:         string(c.Kind),
+			PURL:         c.PURL,
+			Relationship: string(c.Relationship),
+			Scope:        string(c.Scope),
+			FromLicenses: c.FromLicenses,
+			ToLicenses:   c.ToLicenses,
+			Member:       c.Member,
+		}
+		if c.Vuln != nil {
+			pc.Vulnerabilities = &VulnerabilityDelta{
+				Remediated: newVulnerabilities(c.Vuln.Remediated),
+				Introduced: newVulnerabilities(c.Vuln.Introduced),
+			}
+		}
+		for _, pr := range c.PullRequests {
+			pc.PullRequests = append(pc.PullRequests, PullRequestRef(pr))
+		}
+		out.Changes = append(out.Changes, pc)
+	}
+	for _, pv := range diff.Remaining {
+		out.Remaining = append(out.Remaining, PackageVulns{
+			Name:            pv.Package.Name,
+			Type:            pv.Package.Type,
+			Version:         pv.Package.Version,
+			PURL:            pv.Package.PURL,
+			Member:          pv.Package.Member,
+			Vulnerabilities: newVulnerabilities(pv.Vulns),
+		})
+	}
+	for _, v := range diff.LicenseViolations {
+		out.LicenseViolations = append(out.LicenseViolations, LicenseViolation(v))
+	}
+	if diff.VulnerabilityDB != nil {
+		db := VulnerabilityDB(*diff.VulnerabilityDB)
+		out.VulnerabilityDB = &db
+	}
+	return out
+}
+
+func newVulnerabilities(vs []dependency.Vulnerability) []Vulnerability {
+	var out []Vulnerability
+	for _, v := range vs {
+		out = append(out, Vulnerability{
+			ID:             v.ID,
+			Severity:       v.Severity,
+			FixState:       v.FixState,
+			DataSource:     v.DataSource,
+			KnownExploited: v.KnownExploited,
+			EPSS:           v.EPSS,
+			EPSSPercentile: v.EPSSPercentile,
+			FixedIn:        v.FixedIn,
+		})
+	}
+	return out
+}
+
+func newToolchain(tc *release.ToolchainData) *Toolchain {
+	if tc == nil {
+		return nil
+	}
+	out := &Toolchain{}
+	for _, u := range tc.Updates {
+		out.Updates = append(out.Updates, ToolchainUpdate{
+			Ecosystem: string(u.Tool),
+			Source:    u.Source,
+			File:      u.File,
+			From:      u.From,
+			To:        u.To,
+			Direction: string(u.Direction),
+		})
+	}
+	for _, w := range tc.Warnings {
+		out.Warnings = append(out.Warnings, ToolchainWarning{Ecosystem: string(w.Tool), Message: w.Message, Files: w.Files})
+	}
+	return out
+}
+
+func newBaseImages(bi *release.BaseImageData) *BaseImages {
+	if bi == nil {
+		return nil
+	}
+	out := &BaseImages{Updates: []BaseImageUpdate{}}
+	for _, u := range bi.Updates {
+		out.Updates = append(out.Updates, BaseImageUpdate{
+			File:      u.File,
+			Stage:     u.Stage,
+			From:      ImageReference(u.From),
+			To:        ImageReference(u.To),
+			Direction: string(u.Direction),
+		})
+	}
+	return out
+}
+
+func newActions(ac *release.ActionsData) *Actions {
+	if ac == nil {
+		return nil
+	}
+	out := &Actions{Changes: []ActionChange{}}
+	for _, c := range ac.Changes {
+		out.Changes = append(out.Changes, ActionChange{
+			Action:     c.Action,
+			Kind:       string(c.Kind),
+			From:       c.From,
+			To:         c.To,
+			Files:      c.Files,
+			ThirdParty: c.ThirdParty,
+		})
+	}
+	for _, w := range ac.Warnings {
+		out.Warnings = append(out.Warnings, ActionWarning(w))
+	}
+	return out
+}
+
+func newGoMod(gm *release.GoModData) *GoMod {
+	if gm == nil {
+		return nil
+	}
+	out := &GoMod{Changes: []GoModChange{}}
+	for _, c := range gm.Changes {
+		out.Changes = append(out.Changes, GoModChange{
+			Directive: string(c.Directive),
+			Kind:      string(c.Kind),
+			File:      c.File,
+			Key:       c.Key,
+			From:      c.From,
+			To:        c.To,
+			LocalPath: c.LocalPath,
+			Rationale: c.Rationale,
+		})
+	}
+	return out
+}
+
+func newTrunk(t *release.TrunkData) *Trunk {
+	if t == nil {
+		return nil
+	}
+	out := &Trunk{Commits: []TrunkCommit{}}
+	for _, c := range t.Commits {
+		tc := TrunkCommit{
+			Hash:      c.Hash,
+			URL:       c.URL,
+			Subject:   c.Subject,
+			Author:    c.Author,
+			Timestamp: c.Timestamp,
+		}
+		if pr := c.PR; pr != nil {
+			tc.PullRequest = &TrunkPullRequest{
+				Number:   pr.Number,
+				Title:    pr.Title,
+				URL:      pr.URL,
+				Author:   pr.Author,
+				Labels:   pr.Labels,
+				Types:    newChangeTypes(pr.ChangeTypes),
+				Filtered: pr.Filtered,
+				Reason:   pr.Reason,
+			}
+			for _, is := range pr.Issues {
+				tc.PullRequest.Issues = append(tc.PullRequest.Issues, TrunkIssue{
+					Number:   is.Number,
+					Title:    is.Title,
+					URL:      is.URL,
+					Labels:   is.Labels,
+					Types:    newChangeTypes(is.ChangeTypes),
+					Filtered: is.Filtered,
+					Reason:   is.Reason,
+				})
+			}
+		}
+		for _, dc := range c.Dependencies {
+			tc.Dependencies = append(tc.Dependencies, TrunkDependency{
+				Name:        dc.Name,
+				Type:        dc.Type,
+				FromVersion: dc.FromVersion,
+				ToVersion:   dc.ToVersion,
+				Kind:        string(dc.Kind),
+			})
+		}
+		out.Commits = append(out.Commits, tc)
+	}
+	return out
+}
@@ -1,3 +1,7 @@
+// Package json encodes a release description as a versioned JSON document
+// (see Document). The document shape is decoupled from the internal release
+// types and described by a published JSON Schema (see Schema), so downstream
+// tooling can depend on it across chronicle releases.
 package json
 
 import (
@@ -18,5 +22,11 @@ func (e *Encoder) Encode(w io.Writer, _ string, d release.Description) error {
 	enc := json.NewEncoder(w)
 	enc.SetEscapeHTML(false)
 	enc.SetIndent("", "  ")
-	return enc.Encode(d)
+	return enc.Encode(newDocument(d))
+}
+
+// WebhookPayload posts the document as-is; it only exists to label the body
+// as JSON for the receiving endpoint.
+func (e *Encoder) WebhookPayload(encoded []byte) ([]byte, string, error) {
+	return encoded, "application/json", nil
 }
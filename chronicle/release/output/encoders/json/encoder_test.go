@@ -3,32 +3,98 @@ package json
 import (
 	"bytes"
 	"encoding/json"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/santhosh-tekuri/jsonschema/v6"
 	"github.com/stretchr/testify/require"
 
+	"github.com/anchore/chronicle/chronicle/dependency"
 	"github.com/anchore/chronicle/chronicle/release"
 	"github.com/anchore/chronicle/chronicle/release/change"
 )
 
-func TestEncoder_RoundTrip(t *testing.T) {
-	in := release.Description{
+func sampleDescription() release.Description {
+	diff := dependency.NewDiff([]dependency.PackageChange{
+		{
+			Name: "golang.org/x/net", Type: "go-module", FromVersion: "v0.1.0", ToVersion: "v0.2.0", Kind: dependency.Updated,
+			Relationship: dependency.Transitive, Scope: dependency.ScopeRuntime,
+			FromLicenses: []string{"BSD-3-Clause"}, ToLicenses: []string{"BSD-3-Clause"},
+			Vuln: &dependency.VulnDelta{Remediated: []dependency.Vulnerability{{ID: "CVE-2026-0001", Severity: "High", FixState: "fixed"}}},
+		},
+	})
+	merged := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	return release.Description{
 		Release: release.Release{
 			Version: "v1.2.3",
 			Date:    time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
 		},
+		PreviousRelease: &release.Release{Version: "v1.2.2", Date: time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)},
 		VCSReferenceURL: "https://example.com/tree/v1.2.3",
 		VCSChangesURL:   "https://example.com/compare/v1.2.2...v1.2.3",
+		SupportedChanges: []change.TypeTitle{
+			{ChangeType: change.NewType("bug", change.SemVerPatch), Title: "Bug Fixes"},
+		},
 		Changes: []change.Change{
 			{
 				ChangeTypes: []change.Type{change.NewType("bug", change.SemVerPatch)},
 				Text:        "fix something <important> & sharp",
+				Timestamp:   merged,
 				References:  []change.Reference{{Text: "#1", URL: "https://example.com/pull/1"}},
+				EntryType:   "githubPR",
+				Entry:       struct{ internal string }{"not serialized"},
+				PullRequest: &change.PullRequest{
+					Number:       1,
+					Title:        "fix something <important> & sharp",
+					Author:       "alice",
+					URL:          "https://example.com/pull/1",
+					MergedAt:     merged,
+					Labels:       []string{"bug"},
+					MergeCommit:  "abc123",
+					LinkedIssues: []int{7},
+				},
+			},
+			{
+				ChangeTypes: []change.Type{change.NewType("bug", change.SemVerPatch)},
+				Text:        "crash on start",
+				Timestamp:   merged,
+				EntryType:   "githubIssue",
+				Issue:       &change.Issue{Number: 7, Title: "crash on start", URL: "https://example.com/issues/7", ClosedAt: merged},
 			},
 		},
+		DependencyDiff: &diff,
+		Toolchain: &release.ToolchainData{
+			Updates: []release.ToolchainUpdate{{Tool: dependency.EcosystemGo, Source: "go directive", File: "go.mod", From: "1.21", To: "1.22", Direction: release.ToolchainUpgrade}},
+		},
+		BaseImages: &release.BaseImageData{
+			Updates: []release.BaseImageUpdate{{
+				File: "Dockerfile", Stage: "build",
+				From:      release.ImageReference{Name: "golang", Tag: "1.22-alpine"},
+				To:        release.ImageReference{Name: "golang", Tag: "1.23-alpine", Digest: "sha256:abc"},
+				Direction: release.BaseImageUpgrade,
+			}},
+		},
+		Actions: &release.ActionsData{
+			Changes: []release.ActionChange{{
+				Action: "tj-actions/changed-files", Kind: release.ActionUnpinned,
+				From:  []string{"0c52d547c9bc32b1aa3301fd7a9cb496313a4491"},
+				To:    []string{"v45"},
+				Files: []string{".github/workflows/ci.yml"}, ThirdParty: true,
+			}},
+			Warnings: []release.ActionWarning{{
+				Action: "tj-actions/changed-files", Ref: "v45",
+				Message: "tj-actions/changed-files@v45 is not pinned to a commit SHA (.github/workflows/ci.yml)",
+				Files:   []string{".github/workflows/ci.yml"},
+			}},
+		},
 	}
+}
+
+func TestEncoder_Document(t *testing.T) {
+	in := sampleDescription()
 
 	var buf bytes.Buffer
 	require.NoError(t, (&Encoder{}).Encode(&buf, "ignored", in))
@@ -41,14 +107,115 @@ func TestEncoder_RoundTrip(t *testing.T) {
 	// indentation is two-space.
 	require.True(t, strings.Contains(buf.String(), "\n  \""), "expected pretty-printed output")
 
-	var out release.Description
+	var out Document
 	require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
 
-	require.Equal(t, in.Version, out.Version)
-	require.True(t, in.Date.Equal(out.Date))
-	require.Equal(t, in.VCSReferenceURL, out.VCSReferenceURL)
-	require.Equal(t, in.VCSChangesURL, out.VCSChangesURL)
-	require.Len(t, out.Changes, 1)
-	require.Equal(t, in.Changes[0].Text, out.Changes[0].Text)
-	require.Equal(t, in.Changes[0].References, out.Changes[0].References)
+	require.Equal(t, SchemaVersion, out.SchemaVersion)
+	require.Equal(t, SchemaURL, out.Schema)
+	require.Equal(t, in.Version, out.Release.Version)
+	require.True(t, in.Date.Equal(out.Release.Date))
+	require.Equal(t, "v1.2.2", out.PreviousRelease.Version)
+	require.Equal(t, in.VCSReferenceURL, out.ReferenceURL)
+	require.Equal(t, in.VCSChangesURL, out.ChangesURL)
+	require.Equal(t, []Section{{Type: ChangeType{Name: "bug", Bump: "patch"}, Title: "Bug Fixes"}}, out.Sections)
+
+	require.Len(t, out.Changes, 2)
+	pr := out.Changes[0]
+	require.Equal(t, in.Changes[0].Text, pr.Text)
+	require.Equal(t, "githubPR", pr.Source)
+	require.Equal(t, []Reference{{Text: "#1", URL: "https://example.com/pull/1"}}, pr.References)
+	require.NotNil(t, pr.PullRequest)
+	require.Equal(t, 1, pr.PullRequest.Number)
+	require.Equal(t, "alice", pr.PullRequest.Author)
+	require.Equal(t, []int{7}, pr.PullRequest.LinkedIssues)
+	require.Nil(t, pr.Issue)
+
+	issue := out.Changes[1]
+	require.Equal(t, "githubIssue", issue.Source)
+	require.NotNil(t, issue.Issue)
+	require.Equal(t, 7, issue.Issue.Number)
+	require.Nil(t, issue.PullRequest)
+
+	require.NotNil(t, out.Dependencies)
+	require.Equal(t, DependencyTotals{Updated: 1}, out.Dependencies.Totals)
+	require.Nil(t, out.Dependencies.Vulnerabilities, "unannotated scans carry no vulnerability totals")
+	require.Equal(t, "updated", out.Dependencies.Changes[0].Kind)
+	require.Equal(t, "transitive", out.Dependencies.Changes[0].Relationship)
+	require.Equal(t, "runtime", out.Dependencies.Changes[0].Scope)
+	require.Equal(t, []string{"BSD-3-Clause"}, out.Dependencies.Changes[0].ToLicenses)
+	require.Equal(t, "CVE-2026-0001", out.Dependencies.Changes[0].Vulnerabilities.Remediated[0].ID)
+
+	require.Equal(t, "go", out.Toolchain.Updates[0].Ecosystem)
+	require.Equal(t, "upgrade", out.Toolchain.Updates[0].Direction)
+
+	require.Equal(t, ImageReference{Name: "golang", Tag: "1.23-alpine", Digest: "sha256:abc"}, out.BaseImages.Updates[0].To)
+	require.Equal(t, "upgrade", out.BaseImages.Updates[0].Direction)
+
+	require.Equal(t, "unpinned", out.Actions.Changes[0].Kind)
+	require.True(t, out.Actions.Changes[0].ThirdParty)
+	require.Equal(t, "v45", out.Actions.Warnings[0].Ref)
+
+	// the releaser's opaque entry never leaks into the document
+	require.NotContains(t, buf.String(), "not serialized")
+}
+
+func TestEncoder_EmptyCollectionsAreArrays(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, (&Encoder{}).Encode(&buf, "", release.Description{}))
+
+	var raw map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &raw))
+	require.Equal(t, []any{}, raw["changes"])
+	require.Equal(t, []any{}, raw["sections"])
+	require.NotContains(t, raw, "dependencies")
+	require.NotContains(t, raw, "toolchain")
+	require.NotContains(t, raw, "trunk")
+}
+
+func TestEncoder_ValidatesAgainstSchema(t *testing.T) {
+	sch := compileSchema(t)
+
+	for name, d := range map[string]release.Description{
+		"empty":  {},
+		"sample": sampleDescription(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, (&Encoder{}).Encode(&buf, "", d))
+
+			inst, err := jsonschema.UnmarshalJSON(&buf)
+			require.NoError(t, err)
+			require.NoError(t, sch.Validate(inst))
+		})
+	}
+}
+
+// TestSchema_PublishedCopyIsCurrent guards the published schema: any change to
+// the Document types must come with a SchemaVersion bump and a regenerated
+// file (`chronicle schema > schema/json/schema-<version>.json`).
+func TestSchema_PublishedCopyIsCurrent(t *testing.T) {
+	want, err := Schema()
+	require.NoError(t, err)
+
+	published, err := os.ReadFile(publishedSchemaPath())
+	require.NoError(t, err, "no published schema for version %s; generate it with `chronicle schema`", SchemaVersion)
+	require.Equal(t, string(want), string(published), "published schema is stale; regenerate it with `chronicle schema` (and bump SchemaVersion if the shape changed)")
+}
+
+func publishedSchemaPath() string {
+	return filepath.Join("..", "..", "..", "..", "..", "schema", "json", "schema-"+SchemaVersion+".json")
+}
+
+func compileSchema(t *testing.T) *jsonschema.Schema {
+	t.Helper()
+	raw, err := Schema()
+	require.NoError(t, err)
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(raw))
+	require.NoError(t, err)
+
+	c := jsonschema.NewCompiler()
+	require.NoError(t, c.AddResource(SchemaURL, doc))
+	sch, err := c.Compile(SchemaURL)
+	require.NoError(t, err)
+	return sch
 }
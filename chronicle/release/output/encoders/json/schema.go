@@ -0,0 +1,31 @@
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/invopop/jsonschema"
+)
+
+// Schema returns the JSON Schema for Document, generated from the Go types so
+// the two cannot drift. The published copy under schema/json is checked
+// against this output by the package tests.
+func Schema() ([]byte, error) {
+	r := &jsonschema.Reflector{
+		// every field without `omitempty` is always emitted, so mark it required
+		RequiredFromJSONSchemaTags: false,
+		// the document is the contract; unknown fields would be a bug
+		AllowAdditionalProperties: false,
+		ExpandedStruct:            true,
+	}
+	s := r.Reflect(&Document{})
+	s.ID = jsonschema.ID(SchemaURL)
+	s.Title = "chronicle release description"
+	s.Description = "A changelog for one release, as produced by `chronicle -o json` (schema version " + SchemaVersion + ")."
+
+	out, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal JSON schema: %w", err)
+	}
+	return append(out, '\n'), nil
+}
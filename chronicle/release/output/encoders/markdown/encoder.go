@@ -18,6 +18,8 @@ const ID = "md"
 
 const headerTemplate = `{{if .Title }}# {{.Title}}
 
+{{ end }}{{if .Notice }}{{ .Notice }}
+
 {{ end }}{{if .Changes }}{{ formatChangeSections .Changes }}
 
 {{ end }}{{if .HasDependencyContent }}{{ formatDependencies }}
@@ -56,7 +58,7 @@ func (e *Encoder) Encode(w io.Writer, title string, d release.Description) error
 			return formatChangeSections(d.SupportedChanges, changes, d.ConventionalCommitTypes)
 		},
 		"formatDependencies": func() string {
-			return formatDependencies(d.DependencyDiff, d.DependencyRender, d.Toolchain, !e.NoCollapse)
+			return formatDependencies(d.DependencyDiff, d.DependencyRender, d.Toolchain, d.BaseImages, d.Actions, d.GoMod, !e.NoCollapse)
 		},
 	}
 
@@ -111,10 +113,9 @@ func formatSummary(summary change.Change, recognizedTypes []string) string {
 // formatDependencies renders the ### Dependencies section from a Diff. It is
 // gated by the caller (template) so it is only invoked when DependencyDiff is
 // non-nil; we guard against an empty diff for safety.
-func formatDependencies(diff *dependency.Diff, rc *render.Config, tc *release.ToolchainData, supportsCollapsed bool) string {
+func formatDependencies(diff *dependency.Diff, rc *render.Config, tc *release.ToolchainData, bi *release.BaseImageData, ac *release.ActionsData, gm *release.GoModData, supportsCollapsed bool) string {
 	hasDiff := diff != nil && diff.Totals.Total() > 0
-	hasToolchain := tc.HasUpdates()
-	if !hasDiff && !hasToolchain {
+	if !hasDiff && !tc.HasUpdates() && !bi.HasUpdates() && !ac.HasChanges() && !gm.HasChanges() {
 		return ""
 	}
 	if rc == nil {
@@ -138,6 +139,11 @@ func formatDependencies(diff *dependency.Diff, rc *render.Config, tc *release.To
 		// them, so they are omitted. The remaining group has no inline home anywhere,
 		// so it renders whenever opted in (ShowRemaining), collapsed or not.
 		sb.WriteString(vulnerabilitySection(*diff, usesCollapse(rc, supportsCollapsed), rc.ShowsRemaining()))
+
+		// licenses follow the vulnerabilities: first anything the license policy
+		// denies (the part legal must act on), then every transition on an updated
+		// package, which they want to see regardless of policy.
+		sb.WriteString(licenseSection(*diff))
 	}
 
 	// the toolchains rollup is a peer of the vulnerabilities rollup: a flat bold
@@ -146,17 +152,49 @@ func formatDependencies(diff *dependency.Diff, rc *render.Config, tc *release.To
 	// package diff, so a lone toolchain bump still surfaces. The leading blank line
 	// only separates it from preceding content (summary/vuln rollup); when it is
 	// the first thing under the heading there is nothing to separate from.
+	wroteRollup := hasDiff
 	if rollup := toolchainRollup(tc); rollup != "" {
-		if hasDiff {
+		if wroteRollup {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(rollup)
+		wroteRollup = true
+	}
+
+	// base images are another peer rollup: the Dockerfile FROM lines that moved,
+	// which is where OS-level fixes come from (the source scan can't see those).
+	if rollup := baseImageRollup(bi); rollup != "" {
+		if wroteRollup {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(rollup)
+		wroteRollup = true
+	}
+
+	// workflow actions come next; how each one is pinned matters more to a
+	// reviewer than the version it moved to.
+	if rollup := actionsRollup(ac); rollup != "" {
+		if wroteRollup {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(rollup)
+		wroteRollup = true
+	}
+
+	// the go.mod directives close out the rollups: replaces and retractions
+	// change what consumers of the module get without touching a requirement.
+	if rollup := goModRollup(gm); rollup != "" {
+		if wroteRollup {
 			sb.WriteString("\n")
 		}
 		sb.WriteString(rollup)
 	}
 
 	if hasDiff {
-		// group the visible changes by ecosystem; with a single ecosystem render flat
-		// (no subsection header), otherwise emit a #### subsection per ecosystem.
-		groups := render.GroupByEcosystem(rc.VisibleChanges(diff.Changes))
+		// group the visible changes by ecosystem (or workspace member); with a single
+		// group render flat (no subsection header), otherwise emit a #### subsection
+		// per group.
+		groups := render.GroupChanges(rc.VisibleChanges(diff.Changes))
 		multi := len(groups) > 1
 		for _, g := range groups {
 			if multi {
@@ -193,33 +231,122 @@ func toolchainRollup(tc *release.ToolchainData) string {
 	return sb.String()
 }
 
-// formatEcosystemActions renders the per-change-kind blocks for one ecosystem's
-// changes, honoring each kind's display mode (hide/summary/list/collapsed).
-func formatEcosystemActions(changes []dependency.PackageChange, rc *render.Config, supportsCollapsed bool) string {
+// baseImageRollup renders the "Base images" rollup: one line per distinct image
+// transition, with a re-pinned digest of the same tag shown as a digest change.
+// Returns "" when there is nothing to show.
+func baseImageRollup(bi *release.BaseImageData) string {
+	lines := bi.DisplayLines()
+	if len(lines) == 0 {
+		return ""
+	}
 	var sb strings.Builder
-	for _, a := range render.ActionOrder {
-		mode := rc.ResolveDisplay(a.Kind, supportsCollapsed)
-		if mode == render.ModeHide {
-			continue
+	fmt.Fprintf(&sb, "**Base images (%d)**\n\n", len(lines))
+	for _, l := range lines {
+		if l.DigestOnly() {
+			image := release.ImageReference{Name: l.To.Name, Tag: l.To.Tag}
+			fmt.Fprintf(&sb, "- `%s` digest: `%s` → `%s`", image, l.From.ShortDigest(), l.To.ShortDigest())
+		} else {
+			fmt.Fprintf(&sb, "- `%s` → `%s`", l.From.Short(), l.To.Short())
+		}
+		if l.Direction != release.BaseImageDirectionUnknown {
+			fmt.Fprintf(&sb, " (%s)", l.Direction)
 		}
+		if len(l.Files) > 0 {
+			fmt.Fprintf(&sb, " (%s)", strings.Join(l.Files, ", "))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
 
-		subset := render.ChangesOfKind(changes, a.Kind)
-		if len(subset) == 0 {
-			continue
+// actionsRollup renders the "GitHub Actions" rollup: one line per action whose
+// `uses:` references changed, marking moves to and from commit-SHA pins. Unpinned
+// warnings are operator/JSON-facing only, like the toolchain warnings. Returns ""
+// when there is nothing to show.
+func actionsRollup(ac *release.ActionsData) string {
+	if !ac.HasChanges() {
+		return ""
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "**GitHub Actions (%d)**\n\n", len(ac.Changes))
+	for _, c := range ac.Changes {
+		switch c.Kind {
+		case release.ActionAdded:
+			fmt.Fprintf(&sb, "- `%s` added at %s", c.Action, actionRefs(c.To))
+		case release.ActionRemoved:
+			fmt.Fprintf(&sb, "- `%s` removed (was %s)", c.Action, actionRefs(c.From))
+		default:
+			fmt.Fprintf(&sb, "- `%s`: %s → %s", c.Action, actionRefs(c.From), actionRefs(c.To))
+		}
+		switch {
+		case c.Kind == release.ActionPinned || c.Kind == release.ActionUnpinned:
+			fmt.Fprintf(&sb, " (%s)", c.Kind)
+		case c.Kind == release.ActionAdded && c.ThirdParty:
+			sb.WriteString(" (third-party)")
 		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// goModRollup renders the "Go modules" rollup: one line per go.mod directive
+// that was added, removed or changed, calling out replaces that point at a local
+// directory (they resolve only inside this checkout). Returns "" when there is
+// nothing to show.
+func goModRollup(gm *release.GoModData) string {
+	if !gm.HasChanges() {
+		return ""
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "**Go modules (%d)**\n\n", len(gm.Changes))
+	for _, c := range gm.Changes {
+		switch c.Kind {
+		case release.GoModAdded:
+			fmt.Fprintf(&sb, "- `%s` added", c.Statement(c.To))
+		case release.GoModRemoved:
+			fmt.Fprintf(&sb, "- `%s` removed", c.Statement(c.From))
+		default:
+			fmt.Fprintf(&sb, "- `%s`: `%s` → `%s`", c.Subject(), c.From, c.To)
+		}
+		if c.LocalPath {
+			sb.WriteString(" (local path)")
+		}
+		if c.Rationale != "" {
+			fmt.Fprintf(&sb, ": %s", c.Rationale)
+		}
+		if c.File != "go.mod" {
+			fmt.Fprintf(&sb, " (%s)", c.File)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func actionRefs(refs []string) string {
+	out := make([]string, len(refs))
+	for i, r := range refs {
+		out[i] = "`" + release.ShortActionRef(r) + "`"
+	}
+	return strings.Join(out, ", ")
+}
 
-		header := fmt.Sprintf("%s (%s)", a.Label, rc.PackageCountLabel(len(subset)))
+// formatEcosystemActions renders the per-change-kind blocks for one ecosystem's
+// changes, honoring each block's display mode (summary/list/collapsed).
+func formatEcosystemActions(changes []dependency.PackageChange, rc *render.Config, supportsCollapsed bool) string {
+	var sb strings.Builder
+	for _, b := range rc.Blocks(changes, supportsCollapsed) {
+		header := fmt.Sprintf("%s (%s)", b.Label, rc.PackageCountLabel(len(b.Changes)))
 
-		switch mode {
+		switch b.Mode {
 		case render.ModeSummary:
 			fmt.Fprintf(&sb, "\n**%s**\n", header)
 		case render.ModeCollapsed:
 			fmt.Fprintf(&sb, "\n<details>\n<summary>%s</summary>\n\n", header)
-			sb.WriteString(dependencyList(subset))
+			sb.WriteString(dependencyList(b.Changes))
 			sb.WriteString("</details>\n")
 		default: // ModeList
 			fmt.Fprintf(&sb, "\n**%s**\n\n", header)
-			sb.WriteString(dependencyList(subset))
+			sb.WriteString(dependencyList(b.Changes))
 		}
 	}
 	return sb.String()
@@ -227,13 +354,20 @@ func formatEcosystemActions(changes []dependency.PackageChange, rc *render.Confi
 
 // dependencyList renders changes as a compact bullet list rather than a table
 // (whose columns pad to the widest cell, wasting space on variable-width version
-// strings). Each line is the package name, the version transition in backticks,
-// and any vulnerability note in bold parentheses (bolded so the vuln impact
-// stands out against the plain package name).
+// strings). Each line is the package name, the version transition in backticks
+// followed by the pull requests that made it, and any vulnerability note in bold parentheses (bolded so the vuln impact
+// stands out against the plain package name). An added package also lists its
+// licenses, since that is the first a reader sees of them.
 func dependencyList(changes []dependency.PackageChange) string {
 	var sb strings.Builder
 	for _, c := range changes {
 		fmt.Fprintf(&sb, "- %s %s", c.Name, render.VersionTransitionWith(c, render.Backtick))
+		if prs := render.PullRequestsWith(c, prLink); prs != "" {
+			sb.WriteString(" (" + prs + ")")
+		}
+		if c.Kind == dependency.Added && len(c.ToLicenses) > 0 {
+			sb.WriteString(" (" + render.LicenseListWith(c.ToLicenses, nil) + ")")
+		}
 		if note := render.VulnNoteWith(c, vulnLink); note != "" {
 			sb.WriteString(" **(" + note + ")**")
 		}
@@ -242,6 +376,26 @@ func dependencyList(changes []dependency.PackageChange) string {
 	return sb.String()
 }
 
+// licenseSection renders the license policy warnings and the license
+// transitions of updated packages as flat bold groups, like the vulnerability
+// rollup above them. Returns "" when neither has anything to show.
+func licenseSection(d dependency.Diff) string {
+	var sb strings.Builder
+	if len(d.LicenseViolations) > 0 {
+		fmt.Fprintf(&sb, "\n**⚠️ License policy (%d)**\n\n", len(d.LicenseViolations))
+		for _, v := range d.LicenseViolations {
+			fmt.Fprintf(&sb, "- %s %s is licensed `%s`, which the license policy denies\n", v.Name, render.Backtick(render.ShortenVersion(v.Version)), v.License)
+		}
+	}
+	if changes := render.LicenseChanges(d); len(changes) > 0 {
+		fmt.Fprintf(&sb, "\n**License changes (%d)**\n\n", len(changes))
+		for _, c := range changes {
+			fmt.Fprintf(&sb, "- %s: %s\n", c.Name, render.LicenseTransitionWith(c, render.Backtick))
+		}
+	}
+	return sb.String()
+}
+
 // usesCollapse reports whether any change kind actually resolves to collapsed
 // display — i.e. the format supports it and at least one section is hidden
 // behind a <details>. It gates the vulnerabilities rollup, which is only useful
@@ -255,6 +409,9 @@ func usesCollapse(rc *render.Config, supportsCollapsed bool) bool {
 		if rc.ResolveDisplay(a.Kind, supportsCollapsed) == render.ModeCollapsed {
 			return true
 		}
+		if rc.SplitsTransitive(a.Kind) && rc.ResolveTransitiveDisplay(a.Kind, supportsCollapsed) == render.ModeCollapsed {
+			return true
+		}
 	}
 	return false
 }
@@ -286,17 +443,20 @@ func vulnerabilitySection(d dependency.Diff, includeDeltas, includeRemaining boo
 
 // writeVulnGroup writes one labeled vulnerability group as a bullet list (or
 // nothing when empty). Each bullet is the linked ID, its severity in
-// parentheses, and the affected packages.
+// parentheses, its KEV/EPSS markers in brackets, and the affected packages.
 func writeVulnGroup(sb *strings.Builder, label string, vulns []render.VulnListing) {
 	if len(vulns) == 0 {
 		return
 	}
 	fmt.Fprintf(sb, "\n**%s (%d)**\n\n", label, len(vulns))
 	for _, v := range vulns {
-		fmt.Fprintf(sb, "- %s", vulnLink(dependency.Vulnerability{ID: v.ID, DataSource: v.DataSource}))
+		fmt.Fprintf(sb, "- %s", vulnLink(v.Vulnerability()))
 		if v.Severity != "" {
 			fmt.Fprintf(sb, " (%s)", v.Severity)
 		}
+		if m := render.VulnMarkers(v.Vulnerability(), false); len(m) > 0 {
+			fmt.Fprintf(sb, " [%s]", strings.Join(m, ", "))
+		}
 		if len(v.Packages) > 0 {
 			fmt.Fprintf(sb, " — %s", strings.Join(v.Packages, ", "))
 		}
@@ -304,6 +464,15 @@ func writeVulnGroup(sb *strings.Builder, label string, vulns []render.VulnListin
 	}
 }
 
+// prLink renders a pull request reference as a markdown link to it, or the bare
+// "#N" when the URL is unknown.
+func prLink(pr dependency.PullRequest) string {
+	if pr.URL == "" {
+		return fmt.Sprintf("#%d", pr.Number)
+	}
+	return fmt.Sprintf("[#%d](%s)", pr.Number, pr.URL)
+}
+
 // vulnLink renders a vulnerability ID as a markdown link to its data source
 // (grype's primary reference URL), falling back to the bare ID when grype
 // supplied no URL. The md-pretty encoder turns these into clickable terminal
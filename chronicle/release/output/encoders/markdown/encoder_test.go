@@ -240,6 +240,32 @@ func TestMarkdownPresenter_Present_DependencyDiff_MultiEcosystem(t *testing.T) {
 	)
 }
 
+func TestMarkdownPresenter_Present_DependencyDiff_Workspace(t *testing.T) {
+	// changes spanning several workspace members render a #### subsection per
+	// member, split by ecosystem since more than one is involved.
+	allList := render.Config{
+		Actions: map[dependency.ChangeKind][]render.Mode{
+			dependency.Updated: {render.ModeList},
+			dependency.Added:   {render.ModeList},
+		},
+	}
+	diff := dependency.NewDiff([]dependency.PackageChange{
+		{Name: "golang.org/x/net", Type: "go-module", FromVersion: "v0.17.0", ToVersion: "v0.23.0", Kind: dependency.Updated, Member: "api"},
+		{Name: "golang.org/x/net", Type: "go-module", FromVersion: "v0.17.0", ToVersion: "v0.23.0", Kind: dependency.Updated, Member: "."},
+		{Name: "left-pad", Type: "npm", FromVersion: "1.2.0", ToVersion: "1.3.0", Kind: dependency.Updated, Member: "."},
+		{Name: "github.com/new/dep", Type: "go-module", ToVersion: "v0.4.0", Kind: dependency.Added, Member: "api"},
+	})
+	assertEncoderAgainstGoldenSnapshot(t,
+		`{{ .Version }}`,
+		release.Description{
+			Release:          release.Release{Version: "v0.20.0"},
+			VCSChangesURL:    "https://example.com/compare",
+			DependencyDiff:   &diff,
+			DependencyRender: &allList,
+		},
+	)
+}
+
 func TestMarkdownPresenter_Present_DependencyDiff_CollapsedWithVulns(t *testing.T) {
 	// collapsed wraps the table in <details>; the vulnerability note appears
 	// because the diff carries vulnerability data (2 unique remediated).
@@ -354,6 +380,123 @@ func TestMarkdownPresenter_Present_DependencyDiff_ShowRemaining(t *testing.T) {
 	)
 }
 
+func TestMarkdownPresenter_Present_DependencyDiff_TransitiveSplit(t *testing.T) {
+	// with transitive updates split out, the one intentional direct upgrade is
+	// listed on its own and the transitive bumps it pulled in shrink to a count.
+	diff := dependency.NewDiff([]dependency.PackageChange{
+		{Name: "github.com/spf13/cobra", Type: "go-module", FromVersion: "v1.7.0", ToVersion: "v1.8.0", Kind: dependency.Updated, Relationship: dependency.Direct},
+		{Name: "golang.org/x/sys", Type: "go-module", FromVersion: "v0.19.0", ToVersion: "v0.20.0", Kind: dependency.Updated, Relationship: dependency.Transitive},
+		{Name: "golang.org/x/text", Type: "go-module", FromVersion: "v0.14.0", ToVersion: "v0.15.0", Kind: dependency.Updated, Relationship: dependency.Transitive},
+		{Name: "github.com/inconshreveable/mousetrap", Type: "go-module", ToVersion: "v1.1.0", Kind: dependency.Added, Relationship: dependency.Transitive},
+	})
+	rc := render.Config{
+		Actions: map[dependency.ChangeKind][]render.Mode{
+			dependency.Updated: {render.ModeList},
+			dependency.Added:   {render.ModeList},
+		},
+		Transitive: map[dependency.ChangeKind][]render.Mode{
+			dependency.Updated: {render.ModeSummary},
+		},
+	}
+
+	assertEncoderAgainstGoldenSnapshot(t,
+		"Changelog",
+		release.Description{
+			Release:          release.Release{Version: "v0.20.0"},
+			VCSChangesURL:    "https://github.com/anchore/syft/compare/v0.19.0...v0.20.0",
+			DependencyDiff:   &diff,
+			DependencyRender: &rc,
+		},
+	)
+}
+
+func TestMarkdownPresenter_Present_DependencyDiff_Licenses(t *testing.T) {
+	// the denied license is called out above the lists, the relicensed update is
+	// rolled up for legal, and the added package shows its licenses inline.
+	diff := dependency.NewDiff([]dependency.PackageChange{
+		{Name: "left-pad", Type: "npm", FromVersion: "1.2.0", ToVersion: "1.3.0", Kind: dependency.Updated, FromLicenses: []string{"MIT"}, ToLicenses: []string{"BUSL-1.1"}},
+		{Name: "lodash", Type: "npm", FromVersion: "4.17.20", ToVersion: "4.17.21", Kind: dependency.Updated, FromLicenses: []string{"MIT"}, ToLicenses: []string{"MIT"}},
+		{Name: "copyleft", Type: "npm", ToVersion: "2.0.0", Kind: dependency.Added, ToLicenses: []string{"AGPL-3.0-only"}},
+		{Name: "dual", Type: "npm", ToVersion: "1.0.0", Kind: dependency.Added, ToLicenses: []string{"Apache-2.0", "MIT"}},
+	})
+	diff.LicenseViolations = dependency.LicensePolicy{Deny: []string{"AGPL"}}.Violations(diff.Changes)
+	rc := render.Config{
+		Actions: map[dependency.ChangeKind][]render.Mode{
+			dependency.Updated: {render.ModeList},
+			dependency.Added:   {render.ModeList},
+		},
+	}
+
+	assertEncoderAgainstGoldenSnapshot(t,
+		"Changelog",
+		release.Description{
+			Release:          release.Release{Version: "v0.20.0"},
+			VCSChangesURL:    "https://github.com/anchore/syft/compare/v0.19.0...v0.20.0",
+			DependencyDiff:   &diff,
+			DependencyRender: &rc,
+		},
+	)
+}
+
+func TestMarkdownPresenter_Present_DependencyDiff_VulnMarkers(t *testing.T) {
+	// known-exploited and likely-exploited vulnerabilities are flagged in the
+	// rollup and inline, and an introduced one points at its fixed version.
+	diff := dependency.NewDiff([]dependency.PackageChange{
+		{Name: "xz", Type: "apk", FromVersion: "5.6.0", ToVersion: "5.6.2", Kind: dependency.Updated, Vuln: &dependency.VulnDelta{
+			Remediated: []dependency.Vulnerability{{ID: "CVE-2024-3094", Severity: "Critical", KnownExploited: true, EPSS: 0.853, DataSource: "https://nvd.nist.gov/vuln/detail/CVE-2024-3094"}},
+		}},
+		{Name: "golang.org/x/net", Type: "go-module", FromVersion: "v0.22.0", ToVersion: "v0.23.0", Kind: dependency.Updated, Vuln: &dependency.VulnDelta{
+			Introduced: []dependency.Vulnerability{{ID: "GHSA-4v7x-pqxf-cx7m", Severity: "Medium", EPSS: 0.002, FixedIn: []string{"0.23.1"}}},
+		}},
+	})
+	rc := render.Config{
+		Actions: map[dependency.ChangeKind][]render.Mode{
+			dependency.Updated: {render.ModeCollapsed},
+		},
+	}
+
+	assertEncoderAgainstGoldenSnapshot(t,
+		"Changelog",
+		release.Description{
+			Release:          release.Release{Version: "v0.20.0"},
+			VCSChangesURL:    "https://github.com/anchore/syft/compare/v0.19.0...v0.20.0",
+			DependencyDiff:   &diff,
+			DependencyRender: &rc,
+		},
+	)
+}
+
+func TestMarkdownPresenter_Present_DependencyDiff_PullRequests(t *testing.T) {
+	// attributed changes link the pull requests that made them after the
+	// version transition; an unattributed change renders as before.
+	diff := dependency.NewDiff([]dependency.PackageChange{
+		{Name: "golang.org/x/net", Type: "go-module", FromVersion: "v0.22.0", ToVersion: "v0.23.0", Kind: dependency.Updated, PullRequests: []dependency.PullRequest{
+			{Number: 101, Title: "bump x/net", URL: "https://github.com/anchore/syft/pull/101", Author: "dependabot"},
+			{Number: 107, Title: "bump x/net again", URL: "https://github.com/anchore/syft/pull/107", Author: "dependabot"},
+		}},
+		{Name: "golang.org/x/text", Type: "go-module", FromVersion: "v0.14.0", ToVersion: "v0.15.0", Kind: dependency.Updated},
+		{Name: "github.com/google/uuid", Type: "go-module", ToVersion: "v1.6.0", Kind: dependency.Added, PullRequests: []dependency.PullRequest{
+			{Number: 104, Title: "add uuid"},
+		}},
+	})
+	rc := render.Config{
+		Actions: map[dependency.ChangeKind][]render.Mode{
+			dependency.Updated: {render.ModeList},
+			dependency.Added:   {render.ModeList},
+		},
+	}
+
+	assertEncoderAgainstGoldenSnapshot(t,
+		"Changelog",
+		release.Description{
+			Release:          release.Release{Version: "v0.20.0"},
+			VCSChangesURL:    "https://github.com/anchore/syft/compare/v0.19.0...v0.20.0",
+			DependencyDiff:   &diff,
+			DependencyRender: &rc,
+		},
+	)
+}
+
 func TestMarkdownPresenter_Present_Toolchain(t *testing.T) {
 	assertEncoderAgainstGoldenSnapshot(t,
 		"Changelog",
@@ -403,6 +546,101 @@ func TestMarkdownPresenter_Present_ToolchainDowngrade(t *testing.T) {
 	)
 }
 
+func TestMarkdownPresenter_Present_BaseImages(t *testing.T) {
+	// a lone base-image change still renders the Dependencies section; it sits
+	// after the toolchains rollup, and a digest-only re-pin reads as such.
+	assertEncoderAgainstGoldenSnapshot(t,
+		"Changelog",
+		release.Description{
+			SupportedChanges: []change.TypeTitle{},
+			Release:          release.Release{Version: "v0.19.1"},
+			VCSChangesURL:    "https://github.com/anchore/syft/compare/v0.19.0...v0.19.1",
+			Toolchain: &release.ToolchainData{
+				Updates: []release.ToolchainUpdate{
+					{Tool: "go", Source: "go directive", File: "go.mod", From: "1.22", To: "1.23", Direction: release.ToolchainUpgrade},
+				},
+			},
+			BaseImages: &release.BaseImageData{
+				Updates: []release.BaseImageUpdate{
+					{
+						File: "Dockerfile", Stage: "build",
+						From:      release.ImageReference{Name: "golang", Tag: "1.22-alpine"},
+						To:        release.ImageReference{Name: "golang", Tag: "1.23-alpine"},
+						Direction: release.BaseImageUpgrade,
+					},
+					{
+						File: "Dockerfile", Stage: "#1",
+						From: release.ImageReference{Name: "alpine", Tag: "3.20", Digest: "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaa"},
+						To:   release.ImageReference{Name: "alpine", Tag: "3.20", Digest: "sha256:bbbbbbbbbbbbbbbbbbbbbbbbbbbb"},
+					},
+					{
+						File: "tools/Dockerfile", Stage: "#0",
+						From:      release.ImageReference{Name: "node", Tag: "22"},
+						To:        release.ImageReference{Name: "node", Tag: "20"},
+						Direction: release.BaseImageDowngrade,
+					},
+				},
+			},
+		},
+	)
+}
+
+func TestMarkdownPresenter_Present_Actions(t *testing.T) {
+	// workflow action changes are the last rollup; pins are abbreviated and
+	// moves to/from a commit SHA are called out.
+	assertEncoderAgainstGoldenSnapshot(t,
+		"Changelog",
+		release.Description{
+			SupportedChanges: []change.TypeTitle{},
+			Release:          release.Release{Version: "v0.19.1"},
+			VCSChangesURL:    "https://github.com/anchore/syft/compare/v0.19.0...v0.19.1",
+			Actions:          sampleActions(),
+		},
+	)
+}
+
+func sampleActions() *release.ActionsData {
+	return &release.ActionsData{
+		Changes: []release.ActionChange{
+			{Action: "actions/checkout", Kind: release.ActionPinned, From: []string{"v4"}, To: []string{"b4ffde65f46336ab88eb53be808477a3936bae11"}},
+			{Action: "actions/setup-go", Kind: release.ActionUnpinned, From: []string{"0c52d547c9bc32b1aa3301fd7a9cb496313a4491"}, To: []string{"v5"}},
+			{Action: "golangci/golangci-lint-action", Kind: release.ActionUpdated, From: []string{"v3"}, To: []string{"v3", "v6"}, ThirdParty: true},
+			{Action: "new/action", Kind: release.ActionAdded, To: []string{"main"}, ThirdParty: true},
+			{Action: "old/action", Kind: release.ActionRemoved, From: []string{"v1"}, ThirdParty: true},
+		},
+		Warnings: []release.ActionWarning{
+			{Action: "new/action", Ref: "main", Message: "new/action@main is not pinned to a commit SHA (.github/workflows/ci.yml)"},
+		},
+	}
+}
+
+func TestMarkdownPresenter_Present_GoMod(t *testing.T) {
+	// go.mod directive changes are the last rollup; a replace pointing at a
+	// local directory is called out, and modules other than the root are named.
+	assertEncoderAgainstGoldenSnapshot(t,
+		"Changelog",
+		release.Description{
+			SupportedChanges: []change.TypeTitle{},
+			Release:          release.Release{Version: "v0.19.1"},
+			VCSChangesURL:    "https://github.com/anchore/syft/compare/v0.19.0...v0.19.1",
+			GoMod:            sampleGoMod(),
+		},
+	)
+}
+
+func sampleGoMod() *release.GoModData {
+	return &release.GoModData{
+		Changes: []release.GoModChange{
+			{Directive: release.GoModReplace, Kind: release.GoModAdded, File: "go.mod", Key: "github.com/anchore/stereoscope", To: "../stereoscope", LocalPath: true},
+			{Directive: release.GoModReplace, Kind: release.GoModChanged, File: "go.mod", Key: "github.com/docker/docker", From: "github.com/docker/docker v24.0.0+incompatible", To: "github.com/docker/docker v25.0.0+incompatible"},
+			{Directive: release.GoModReplace, Kind: release.GoModRemoved, File: "tools/go.mod", Key: "github.com/anchore/syft", From: "../"},
+			{Directive: release.GoModRetract, Kind: release.GoModAdded, File: "go.mod", Key: "v0.19.0", Rationale: "panics on empty images"},
+			{Directive: release.GoModGodebug, Kind: release.GoModChanged, File: "go.mod", Key: "x509negativeserial", From: "0", To: "1"},
+			{Directive: release.GoModToolchain, Kind: release.GoModChanged, File: "go.mod", From: "go1.22.1", To: "go1.23.0"},
+		},
+	}
+}
+
 func assertEncoderAgainstGoldenSnapshot(t *testing.T, title string, d release.Description) {
 	t.Helper()
 	var buf bytes.Buffer
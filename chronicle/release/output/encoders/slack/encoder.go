@@ -6,8 +6,10 @@ package slack
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
+	"regexp"
 	"strings"
 	"text/template"
 
@@ -37,12 +39,17 @@ func (e *Encoder) Encode(w io.Writer, title string, d release.Description) error
 		fmt.Fprintf(&out, "*%s*\n\n", escapeMrkdwn(resolvedTitle))
 	}
 
+	if notice := formatNotice(d.Notice); notice != "" {
+		out.WriteString(notice)
+		out.WriteString("\n\n")
+	}
+
 	if sections := formatChangeSections(d.SupportedChanges, d.Changes, d.ConventionalCommitTypes); sections != "" {
 		out.WriteString(sections)
 		out.WriteString("\n\n")
 	}
 
-	if deps := formatDependencies(d.DependencyDiff, d.DependencyRender, d.Toolchain); deps != "" {
+	if deps := formatDependencies(d.DependencyDiff, d.DependencyRender, d.Toolchain, d.BaseImages, d.Actions, d.GoMod); deps != "" {
 		out.WriteString(deps)
 		out.WriteString("\n\n")
 	}
@@ -53,6 +60,23 @@ func (e *Encoder) Encode(w io.Writer, title string, d release.Description) error
 	return err
 }
 
+// WebhookPayload wraps the encoded mrkdwn as the JSON body a Slack incoming
+// webhook expects, so `-o slack=https://hooks.slack.com/...` can post it
+// directly without a hand-built curl payload.
+func (e *Encoder) WebhookPayload(encoded []byte) ([]byte, string, error) {
+	// mrkdwn links are `<url|text>`; keep them literal (rather than \u003c...)
+	// so a --webhook-dry-run shows the same text Slack will.
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(struct {
+		Text string `json:"text"`
+	}{Text: string(encoded)}); err != nil {
+		return nil, "", err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), "application/json", nil
+}
+
 func renderTitle(raw string, d release.Description) (string, error) {
 	t, err := template.New("title").Parse(raw)
 	if err != nil {
@@ -161,6 +185,15 @@ func renderRef(ref change.Reference) string {
 	}
 }
 
+// prLink renders a pull request reference as a Slack link to it, or the bare
+// "#N" when the URL is unknown.
+func prLink(pr dependency.PullRequest) string {
+	if pr.URL == "" {
+		return fmt.Sprintf("#%d", pr.Number)
+	}
+	return fmt.Sprintf("<%s|#%d>", pr.URL, pr.Number)
+}
+
 // vulnLink renders a vulnerability ID as a Slack link to its data source
 // (grype's primary reference URL), falling back to the escaped bare ID when
 // grype supplied no URL.
@@ -183,13 +216,26 @@ func escapeMrkdwn(s string) string {
 	return mrkdwnEscaper.Replace(s)
 }
 
+var (
+	markdownLinkPattern = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	markdownBoldPattern = regexp.MustCompile(`\*\*(.+?)\*\*`)
+)
+
+// formatNotice carries the release notice over from the markdown it is written
+// in: links become `<url|text>` and `**bold**` becomes `*bold*`, and the rest
+// is escaped like any other display text.
+func formatNotice(notice string) string {
+	s := escapeMrkdwn(strings.TrimSpace(notice))
+	s = markdownLinkPattern.ReplaceAllString(s, "<$2|$1>")
+	return markdownBoldPattern.ReplaceAllString(s, "*$1*")
+}
+
 // formatDependencies renders the dependency diff as a Slack mrkdwn block,
 // mirroring the markdown encoder's section but with `*bold*` labels and `•`
 // bullets. Returns "" when there is nothing to show.
-func formatDependencies(diff *dependency.Diff, rc *render.Config, tc *release.ToolchainData) string {
+func formatDependencies(diff *dependency.Diff, rc *render.Config, tc *release.ToolchainData, bi *release.BaseImageData, ac *release.ActionsData, gm *release.GoModData) string {
 	hasDiff := diff != nil && diff.Totals.Total() > 0
-	hasToolchain := tc.HasUpdates()
-	if !hasDiff && !hasToolchain {
+	if !hasDiff && !tc.HasUpdates() && !bi.HasUpdates() && !ac.HasChanges() && !gm.HasChanges() {
 		return ""
 	}
 	if rc == nil {
@@ -211,6 +257,7 @@ func formatDependencies(diff *dependency.Diff, rc *render.Config, tc *release.To
 		if rc.ShowsRemaining() {
 			writeVulnGroup(&sb, "🟡 Remaining", render.RemainingVulns(*diff))
 		}
+		sb.WriteString(licenseSection(*diff))
 	}
 
 	// the toolchains rollup is a peer of the vulnerabilities rollup, rendered as a
@@ -218,19 +265,44 @@ func formatDependencies(diff *dependency.Diff, rc *render.Config, tc *release.To
 	// renders even when there is no package diff, so a lone toolchain bump surfaces.
 	// The leading blank line only separates it from preceding content (summary /
 	// remaining rollup); when it is first under the label there is nothing to space.
+	wroteRollup := hasDiff
 	if rollup := toolchainRollup(tc); rollup != "" {
-		if hasDiff {
+		if wroteRollup {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(rollup)
+		wroteRollup = true
+	}
+
+	// base images, workflow actions and go.mod directives follow as further
+	// peer rollups.
+	if rollup := baseImageRollup(bi); rollup != "" {
+		if wroteRollup {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(rollup)
+		wroteRollup = true
+	}
+	if rollup := actionsRollup(ac); rollup != "" {
+		if wroteRollup {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(rollup)
+		wroteRollup = true
+	}
+	if rollup := goModRollup(gm); rollup != "" {
+		if wroteRollup {
 			sb.WriteString("\n")
 		}
 		sb.WriteString(rollup)
 	}
 
 	if hasDiff {
-		// group the visible changes by ecosystem; flat for a single ecosystem,
-		// otherwise a bold ecosystem label per group (slack has no header levels).
+		// group the visible changes by ecosystem (or workspace member); flat for a
+		// single group, otherwise a bold label per group (slack has no header levels).
 		// The change kinds render as a subordinate bullet list under the
 		// *Dependencies* header.
-		groups := render.GroupByEcosystem(rc.VisibleChanges(diff.Changes))
+		groups := render.GroupChanges(rc.VisibleChanges(diff.Changes))
 		multi := len(groups) > 1
 		for _, g := range groups {
 			if multi {
@@ -269,29 +341,117 @@ func toolchainRollup(tc *release.ToolchainData) string {
 	return sb.String()
 }
 
-// formatEcosystemActions renders the per-change-kind bullets for one ecosystem's
-// changes. Slack has no <details> or tables, so "collapsed" falls through to the
-// configured fallback (list or summary). Each kind is a bullet; in list mode its
-// packages render as indented sub-bullets.
-func formatEcosystemActions(changes []dependency.PackageChange, rc *render.Config) string {
+// baseImageRollup renders the "Base images" rollup in Slack mrkdwn, one bullet
+// per distinct image transition. Returns "" when there is nothing to show.
+func baseImageRollup(bi *release.BaseImageData) string {
+	lines := bi.DisplayLines()
+	if len(lines) == 0 {
+		return ""
+	}
 	var sb strings.Builder
-	for _, a := range render.ActionOrder {
-		mode := rc.ResolveDisplay(a.Kind, false) // slack cannot collapse
-		if mode == render.ModeHide {
-			continue
+	fmt.Fprintf(&sb, "*Base images (%d)*\n", len(lines))
+	for _, l := range lines {
+		if l.DigestOnly() {
+			image := release.ImageReference{Name: l.To.Name, Tag: l.To.Tag}
+			fmt.Fprintf(&sb, "• `%s` digest: `%s` → `%s`", escapeMrkdwn(image.String()), escapeMrkdwn(l.From.ShortDigest()), escapeMrkdwn(l.To.ShortDigest()))
+		} else {
+			fmt.Fprintf(&sb, "• `%s` → `%s`", escapeMrkdwn(l.From.Short()), escapeMrkdwn(l.To.Short()))
+		}
+		if l.Direction != release.BaseImageDirectionUnknown {
+			fmt.Fprintf(&sb, " (%s)", l.Direction)
+		}
+		if len(l.Files) > 0 {
+			fmt.Fprintf(&sb, " (%s)", escapeMrkdwn(strings.Join(l.Files, ", ")))
 		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
 
-		subset := render.ChangesOfKind(changes, a.Kind)
-		if len(subset) == 0 {
-			continue
+// actionsRollup renders the "GitHub Actions" rollup in Slack mrkdwn, one bullet
+// per action whose references changed. Unpinned warnings are not rendered.
+// Returns "" when there is nothing to show.
+func actionsRollup(ac *release.ActionsData) string {
+	if !ac.HasChanges() {
+		return ""
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "*GitHub Actions (%d)*\n", len(ac.Changes))
+	for _, c := range ac.Changes {
+		action := escapeMrkdwn(c.Action)
+		switch c.Kind {
+		case release.ActionAdded:
+			fmt.Fprintf(&sb, "• `%s` added at %s", action, actionRefs(c.To))
+		case release.ActionRemoved:
+			fmt.Fprintf(&sb, "• `%s` removed (was %s)", action, actionRefs(c.From))
+		default:
+			fmt.Fprintf(&sb, "• `%s`: %s → %s", action, actionRefs(c.From), actionRefs(c.To))
 		}
+		switch {
+		case c.Kind == release.ActionPinned || c.Kind == release.ActionUnpinned:
+			fmt.Fprintf(&sb, " (%s)", c.Kind)
+		case c.Kind == release.ActionAdded && c.ThirdParty:
+			sb.WriteString(" (third-party)")
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// goModRollup renders the "Go modules" rollup in Slack mrkdwn, one bullet per
+// go.mod directive that was added, removed or changed. Returns "" when there is
+// nothing to show.
+func goModRollup(gm *release.GoModData) string {
+	if !gm.HasChanges() {
+		return ""
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "*Go modules (%d)*\n", len(gm.Changes))
+	for _, c := range gm.Changes {
+		switch c.Kind {
+		case release.GoModAdded:
+			fmt.Fprintf(&sb, "• `%s` added", c.Statement(c.To))
+		case release.GoModRemoved:
+			fmt.Fprintf(&sb, "• `%s` removed", c.Statement(c.From))
+		default:
+			fmt.Fprintf(&sb, "• `%s`: `%s` → `%s`", c.Subject(), c.From, c.To)
+		}
+		if c.LocalPath {
+			sb.WriteString(" (local path)")
+		}
+		if c.Rationale != "" {
+			fmt.Fprintf(&sb, ": %s", escapeMrkdwn(c.Rationale))
+		}
+		if c.File != "go.mod" {
+			fmt.Fprintf(&sb, " (%s)", escapeMrkdwn(c.File))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func actionRefs(refs []string) string {
+	out := make([]string, len(refs))
+	for i, r := range refs {
+		out[i] = "`" + escapeMrkdwn(release.ShortActionRef(r)) + "`"
+	}
+	return strings.Join(out, ", ")
+}
 
-		// the kind is a bullet subordinate to the *Dependencies* header.
-		fmt.Fprintf(&sb, "• %s (%s)\n", a.Label, rc.PackageCountLabel(len(subset)))
-		if mode != render.ModeList {
+// formatEcosystemActions renders the per-change-kind bullets for one ecosystem's
+// changes. Slack has no <details> or tables, so "collapsed" falls through to the
+// configured fallback (list or summary). Each block (a kind, or its transitive
+// share when split out) is a bullet; in list mode its packages render as
+// indented sub-bullets.
+func formatEcosystemActions(changes []dependency.PackageChange, rc *render.Config) string {
+	var sb strings.Builder
+	for _, b := range rc.Blocks(changes, false) { // slack cannot collapse
+		// the block is a bullet subordinate to the *Dependencies* header.
+		fmt.Fprintf(&sb, "• %s (%s)\n", b.Label, rc.PackageCountLabel(len(b.Changes)))
+		if b.Mode != render.ModeList {
 			continue
 		}
-		for _, c := range subset {
+		for _, c := range b.Changes {
 			sb.WriteString("    " + dependencyChangeLine(c) + "\n")
 		}
 	}
@@ -300,7 +460,7 @@ func formatEcosystemActions(changes []dependency.PackageChange, rc *render.Confi
 
 // writeVulnGroup writes one labeled vulnerability rollup group as Slack bullets
 // (or nothing when empty): a bold label with a count, then one bullet per vuln —
-// the linked ID, its severity, and the affected packages. Mirrors the markdown
+// the linked ID, its severity, its KEV/EPSS markers, and the affected packages. Mirrors the markdown
 // encoder's group in Slack mrkdwn.
 func writeVulnGroup(sb *strings.Builder, label string, vulns []render.VulnListing) {
 	if len(vulns) == 0 {
@@ -308,10 +468,13 @@ func writeVulnGroup(sb *strings.Builder, label string, vulns []render.VulnListin
 	}
 	fmt.Fprintf(sb, "\n*%s (%d)*\n", label, len(vulns))
 	for _, v := range vulns {
-		fmt.Fprintf(sb, "• %s", vulnLink(dependency.Vulnerability{ID: v.ID, DataSource: v.DataSource}))
+		fmt.Fprintf(sb, "• %s", vulnLink(v.Vulnerability()))
 		if v.Severity != "" {
 			fmt.Fprintf(sb, " (%s)", escapeMrkdwn(v.Severity))
 		}
+		if m := render.VulnMarkers(v.Vulnerability(), false); len(m) > 0 {
+			fmt.Fprintf(sb, " [%s]", strings.Join(m, ", "))
+		}
 		if len(v.Packages) > 0 {
 			fmt.Fprintf(sb, " — %s", escapeMrkdwn(strings.Join(v.Packages, ", ")))
 		}
@@ -319,12 +482,38 @@ func writeVulnGroup(sb *strings.Builder, label string, vulns []render.VulnListin
 	}
 }
 
+// licenseSection is the markdown encoder's license policy warnings and license
+// transitions in Slack mrkdwn. Returns "" when neither has anything to show.
+func licenseSection(d dependency.Diff) string {
+	var sb strings.Builder
+	if len(d.LicenseViolations) > 0 {
+		fmt.Fprintf(&sb, "\n*⚠️ License policy (%d)*\n", len(d.LicenseViolations))
+		for _, v := range d.LicenseViolations {
+			fmt.Fprintf(&sb, "• %s %s is licensed `%s`, which the license policy denies\n", escapeMrkdwn(v.Name), render.Backtick(render.ShortenVersion(v.Version)), v.License)
+		}
+	}
+	if changes := render.LicenseChanges(d); len(changes) > 0 {
+		fmt.Fprintf(&sb, "\n*License changes (%d)*\n", len(changes))
+		for _, c := range changes {
+			fmt.Fprintf(&sb, "• %s: %s\n", escapeMrkdwn(c.Name), render.LicenseTransitionWith(c, render.Backtick))
+		}
+	}
+	return sb.String()
+}
+
 // dependencyChangeLine renders a single change as a Slack bullet: the package
-// name, the version transition in code, and any vulnerability note in bold
-// parentheses (mirroring the markdown list, in Slack mrkdwn — `*x*` is bold).
+// name, the version transition in code and the pull requests that made it, an added package's licenses, and any
+// vulnerability note in bold parentheses (mirroring the markdown list, in Slack
+// mrkdwn — `*x*` is bold).
 func dependencyChangeLine(c dependency.PackageChange) string {
 	var sb strings.Builder
 	fmt.Fprintf(&sb, "• %s %s", escapeMrkdwn(c.Name), render.VersionTransitionWith(c, render.Backtick))
+	if prs := render.PullRequestsWith(c, prLink); prs != "" {
+		sb.WriteString(" (" + prs + ")")
+	}
+	if c.Kind == dependency.Added && len(c.ToLicenses) > 0 {
+		sb.WriteString(" (" + escapeMrkdwn(render.LicenseListWith(c.ToLicenses, nil)) + ")")
+	}
 	if note := render.VulnNoteWith(c, vulnLink); note != "" {
 		sb.WriteString(" *(" + note + ")*")
 	}
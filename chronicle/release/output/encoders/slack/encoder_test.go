@@ -236,6 +236,115 @@ func TestSlackPresenter_Present_EscapesMrkdwn(t *testing.T) {
 	)
 }
 
+func TestSlackPresenter_Present_DependencyDiff_TransitiveSplit(t *testing.T) {
+	diff := dependency.NewDiff([]dependency.PackageChange{
+		{Name: "github.com/spf13/cobra", Type: "go-module", FromVersion: "v1.7.0", ToVersion: "v1.8.0", Kind: dependency.Updated, Relationship: dependency.Direct},
+		{Name: "golang.org/x/sys", Type: "go-module", FromVersion: "v0.19.0", ToVersion: "v0.20.0", Kind: dependency.Updated, Relationship: dependency.Transitive},
+		{Name: "golang.org/x/text", Type: "go-module", FromVersion: "v0.14.0", ToVersion: "v0.15.0", Kind: dependency.Updated, Relationship: dependency.Transitive},
+		{Name: "github.com/inconshreveable/mousetrap", Type: "go-module", ToVersion: "v1.1.0", Kind: dependency.Added, Relationship: dependency.Transitive},
+	})
+	rc := render.Config{
+		Actions: map[dependency.ChangeKind][]render.Mode{
+			dependency.Updated: {render.ModeList},
+			dependency.Added:   {render.ModeList},
+		},
+		Transitive: map[dependency.ChangeKind][]render.Mode{
+			dependency.Updated: {render.ModeSummary},
+		},
+	}
+
+	assertEncoderAgainstGoldenSnapshot(t,
+		"Changelog",
+		release.Description{
+			Release:          release.Release{Version: "v0.20.0"},
+			VCSChangesURL:    "https://github.com/anchore/syft/compare/v0.19.0...v0.20.0",
+			DependencyDiff:   &diff,
+			DependencyRender: &rc,
+		},
+	)
+}
+
+func TestSlackPresenter_Present_DependencyDiff_Licenses(t *testing.T) {
+	diff := dependency.NewDiff([]dependency.PackageChange{
+		{Name: "left-pad", Type: "npm", FromVersion: "1.2.0", ToVersion: "1.3.0", Kind: dependency.Updated, FromLicenses: []string{"MIT"}, ToLicenses: []string{"BUSL-1.1"}},
+		{Name: "lodash", Type: "npm", FromVersion: "4.17.20", ToVersion: "4.17.21", Kind: dependency.Updated, FromLicenses: []string{"MIT"}, ToLicenses: []string{"MIT"}},
+		{Name: "copyleft", Type: "npm", ToVersion: "2.0.0", Kind: dependency.Added, ToLicenses: []string{"AGPL-3.0-only"}},
+		{Name: "dual", Type: "npm", ToVersion: "1.0.0", Kind: dependency.Added, ToLicenses: []string{"Apache-2.0", "MIT"}},
+	})
+	diff.LicenseViolations = dependency.LicensePolicy{Deny: []string{"AGPL"}}.Violations(diff.Changes)
+	rc := render.Config{
+		Actions: map[dependency.ChangeKind][]render.Mode{
+			dependency.Updated: {render.ModeList},
+			dependency.Added:   {render.ModeList},
+		},
+	}
+
+	assertEncoderAgainstGoldenSnapshot(t,
+		"Changelog",
+		release.Description{
+			Release:          release.Release{Version: "v0.20.0"},
+			VCSChangesURL:    "https://github.com/anchore/syft/compare/v0.19.0...v0.20.0",
+			DependencyDiff:   &diff,
+			DependencyRender: &rc,
+		},
+	)
+}
+
+func TestSlackPresenter_Present_DependencyDiff_PullRequests(t *testing.T) {
+	diff := dependency.NewDiff([]dependency.PackageChange{
+		{Name: "golang.org/x/net", Type: "go-module", FromVersion: "v0.22.0", ToVersion: "v0.23.0", Kind: dependency.Updated, PullRequests: []dependency.PullRequest{
+			{Number: 101, Title: "bump x/net", URL: "https://github.com/anchore/syft/pull/101", Author: "dependabot"},
+			{Number: 107, Title: "bump x/net again", URL: "https://github.com/anchore/syft/pull/107", Author: "dependabot"},
+		}},
+		{Name: "golang.org/x/text", Type: "go-module", FromVersion: "v0.14.0", ToVersion: "v0.15.0", Kind: dependency.Updated},
+		{Name: "github.com/google/uuid", Type: "go-module", ToVersion: "v1.6.0", Kind: dependency.Added, PullRequests: []dependency.PullRequest{
+			{Number: 104, Title: "add uuid"},
+		}},
+	})
+	rc := render.Config{
+		Actions: map[dependency.ChangeKind][]render.Mode{
+			dependency.Updated: {render.ModeList},
+			dependency.Added:   {render.ModeList},
+		},
+	}
+
+	assertEncoderAgainstGoldenSnapshot(t,
+		"Changelog",
+		release.Description{
+			Release:          release.Release{Version: "v0.20.0"},
+			VCSChangesURL:    "https://github.com/anchore/syft/compare/v0.19.0...v0.20.0",
+			DependencyDiff:   &diff,
+			DependencyRender: &rc,
+		},
+	)
+}
+
+func TestSlackPresenter_Present_DependencyDiff_Workspace(t *testing.T) {
+	// changes spanning several workspace members get a bold label per member,
+	// the root first.
+	diff := dependency.NewDiff([]dependency.PackageChange{
+		{Name: "golang.org/x/net", Type: "go-module", FromVersion: "v0.22.0", ToVersion: "v0.23.0", Kind: dependency.Updated, Member: "api"},
+		{Name: "golang.org/x/net", Type: "go-module", FromVersion: "v0.22.0", ToVersion: "v0.23.0", Kind: dependency.Updated, Member: "."},
+		{Name: "github.com/google/uuid", Type: "go-module", ToVersion: "v1.6.0", Kind: dependency.Added, Member: "cli"},
+	})
+	rc := render.Config{
+		Actions: map[dependency.ChangeKind][]render.Mode{
+			dependency.Updated: {render.ModeList},
+			dependency.Added:   {render.ModeList},
+		},
+	}
+
+	assertEncoderAgainstGoldenSnapshot(t,
+		"Changelog",
+		release.Description{
+			Release:          release.Release{Version: "v0.20.0"},
+			VCSChangesURL:    "https://github.com/anchore/syft/compare/v0.19.0...v0.20.0",
+			DependencyDiff:   &diff,
+			DependencyRender: &rc,
+		},
+	)
+}
+
 func TestSlackPresenter_Present_Toolchain(t *testing.T) {
 	assertEncoderAgainstGoldenSnapshot(t,
 		"Changelog",
@@ -285,6 +394,84 @@ func TestSlackPresenter_Present_ToolchainDowngrade(t *testing.T) {
 	)
 }
 
+func TestSlackPresenter_Present_BaseImages(t *testing.T) {
+	assertEncoderAgainstGoldenSnapshot(t,
+		"Changelog",
+		release.Description{
+			SupportedChanges: []change.TypeTitle{},
+			Release:          release.Release{Version: "v0.19.1"},
+			VCSChangesURL:    "https://github.com/anchore/syft/compare/v0.19.0...v0.19.1",
+			BaseImages: &release.BaseImageData{
+				Updates: []release.BaseImageUpdate{
+					{
+						File: "Dockerfile", Stage: "build",
+						From:      release.ImageReference{Name: "golang", Tag: "1.22-alpine"},
+						To:        release.ImageReference{Name: "golang", Tag: "1.23-alpine"},
+						Direction: release.BaseImageUpgrade,
+					},
+					{
+						File: "Dockerfile", Stage: "#1",
+						From: release.ImageReference{Name: "alpine", Tag: "3.20", Digest: "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaa"},
+						To:   release.ImageReference{Name: "alpine", Tag: "3.20", Digest: "sha256:bbbbbbbbbbbbbbbbbbbbbbbbbbbb"},
+					},
+				},
+			},
+		},
+	)
+}
+
+func TestSlackPresenter_Present_Actions(t *testing.T) {
+	assertEncoderAgainstGoldenSnapshot(t,
+		"Changelog",
+		release.Description{
+			SupportedChanges: []change.TypeTitle{},
+			Release:          release.Release{Version: "v0.19.1"},
+			VCSChangesURL:    "https://github.com/anchore/syft/compare/v0.19.0...v0.19.1",
+			Actions:          sampleActions(),
+		},
+	)
+}
+
+func sampleActions() *release.ActionsData {
+	return &release.ActionsData{
+		Changes: []release.ActionChange{
+			{Action: "actions/checkout", Kind: release.ActionPinned, From: []string{"v4"}, To: []string{"b4ffde65f46336ab88eb53be808477a3936bae11"}},
+			{Action: "actions/setup-go", Kind: release.ActionUnpinned, From: []string{"0c52d547c9bc32b1aa3301fd7a9cb496313a4491"}, To: []string{"v5"}},
+			{Action: "golangci/golangci-lint-action", Kind: release.ActionUpdated, From: []string{"v3"}, To: []string{"v3", "v6"}, ThirdParty: true},
+			{Action: "new/action", Kind: release.ActionAdded, To: []string{"main"}, ThirdParty: true},
+			{Action: "old/action", Kind: release.ActionRemoved, From: []string{"v1"}, ThirdParty: true},
+		},
+		Warnings: []release.ActionWarning{
+			{Action: "new/action", Ref: "main", Message: "new/action@main is not pinned to a commit SHA (.github/workflows/ci.yml)"},
+		},
+	}
+}
+
+func TestSlackPresenter_Present_GoMod(t *testing.T) {
+	assertEncoderAgainstGoldenSnapshot(t,
+		"Changelog",
+		release.Description{
+			SupportedChanges: []change.TypeTitle{},
+			Release:          release.Release{Version: "v0.19.1"},
+			VCSChangesURL:    "https://github.com/anchore/syft/compare/v0.19.0...v0.19.1",
+			GoMod:            sampleGoMod(),
+		},
+	)
+}
+
+func sampleGoMod() *release.GoModData {
+	return &release.GoModData{
+		Changes: []release.GoModChange{
+			{Directive: release.GoModReplace, Kind: release.GoModAdded, File: "go.mod", Key: "github.com/anchore/stereoscope", To: "../stereoscope", LocalPath: true},
+			{Directive: release.GoModReplace, Kind: release.GoModChanged, File: "go.mod", Key: "github.com/docker/docker", From: "github.com/docker/docker v24.0.0+incompatible", To: "github.com/docker/docker v25.0.0+incompatible"},
+			{Directive: release.GoModReplace, Kind: release.GoModRemoved, File: "tools/go.mod", Key: "github.com/anchore/syft", From: "../"},
+			{Directive: release.GoModRetract, Kind: release.GoModAdded, File: "go.mod", Key: "v0.19.0", Rationale: "panics on empty images"},
+			{Directive: release.GoModGodebug, Kind: release.GoModChanged, File: "go.mod", Key: "x509negativeserial", From: "0", To: "1"},
+			{Directive: release.GoModToolchain, Kind: release.GoModChanged, File: "go.mod", From: "go1.22.1", To: "go1.23.0"},
+		},
+	}
+}
+
 func assertEncoderAgainstGoldenSnapshot(t *testing.T, title string, d release.Description) {
 	t.Helper()
 	var buf bytes.Buffer
@@ -292,6 +479,30 @@ func assertEncoderAgainstGoldenSnapshot(t *testing.T, title string, d release.De
 	snaps.MatchSnapshot(t, buf.String())
 }
 
+func Test_formatNotice(t *testing.T) {
+	tests := []struct {
+		name   string
+		notice string
+		want   string
+	}{
+		{
+			name:   "plain text is escaped",
+			notice: "Drops support for Go < 1.22 & macOS 12.\n",
+			want:   "Drops support for Go &lt; 1.22 &amp; macOS 12.",
+		},
+		{
+			name:   "links and bold carry over",
+			notice: "**Heads up:** see the [migration guide](https://example.com/migrate?from=1&to=2).",
+			want:   "*Heads up:* see the <https://example.com/migrate?from=1&amp;to=2|migration guide>.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, formatNotice(tt.notice))
+		})
+	}
+}
+
 func Test_formatReferences(t *testing.T) {
 	pr1 := change.Reference{Text: "#1", URL: "https://github.com/o/r/pull/1"}
 	pr2 := change.Reference{Text: "#2", URL: "https://github.com/o/r/pull/2"}
@@ -375,3 +586,11 @@ func Test_formatReferences(t *testing.T) {
 		})
 	}
 }
+
+func TestEncoder_WebhookPayload(t *testing.T) {
+	body, contentType, err := (&Encoder{}).WebhookPayload([]byte("*v1.0.0*\n\n• fix \"quotes\" & <links>\n"))
+	require.NoError(t, err)
+	require.Equal(t, "application/json", contentType)
+	require.JSONEq(t, `{"text":"*v1.0.0*\n\n• fix \"quotes\" & <links>\n"}`, string(body))
+	require.Contains(t, string(body), "<links>", "mrkdwn brackets should not be HTML-escaped")
+}
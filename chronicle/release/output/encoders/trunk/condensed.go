@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/anchore/chronicle/chronicle/dependency"
 	"github.com/anchore/chronicle/chronicle/release"
 	"github.com/anchore/chronicle/chronicle/release/change"
 )
@@ -19,6 +20,7 @@ func (e *Encoder) renderCondensed(w io.Writer, d release.Description) error {
 
 	rows := e.buildCondensedRows(d)
 	wHash, wPR, wTitle, wCloses := measureCondensedColumns(rows)
+	depPrefix := buildIssuePrefix(wHash, wPR)
 
 	// header row sits on top of the trunk line. Putting "│" at column 0 keeps
 	// the trunk visually continuous from anchor → header → data rows.
@@ -50,6 +52,9 @@ func (e *Encoder) renderCondensed(w io.Writer, d release.Description) error {
 		if err := writeCondensedRow(w, st, r, title, wHash, wPR, wTitle, wCloses); err != nil {
 			return err
 		}
+		if err := writeDependencyRows(w, st, r.deps, depPrefix, r.filtered); err != nil {
+			return err
+		}
 	}
 
 	if d.PreviousRelease != nil {
@@ -143,16 +148,18 @@ type condensedRow struct {
 	typ      string
 	filtered bool
 	kind     change.SemVerKind
+	deps     []dependency.PackageChange
 }
 
 // buildCondensedRows returns the rows that should be rendered, already formatted,
-// respecting ShowFiltered.
+// respecting ShowFiltered. A filtered commit that changed dependencies is kept
+// anyway, so the timeline never loses an event to a bot PR's label.
 func (e *Encoder) buildCondensedRows(d release.Description) []condensedRow {
 	var rows []condensedRow
 	for _, c := range d.Trunk.Commits {
 		filtered := c.PR == nil || c.PR.Filtered
 
-		if filtered && !e.ShowFiltered {
+		if filtered && !e.ShowFiltered && len(c.Dependencies) == 0 {
 			continue
 		}
 
@@ -207,6 +214,7 @@ func (e *Encoder) buildCondensedRows(d release.Description) []condensedRow {
 			typ:      typ,
 			filtered: filtered,
 			kind:     kind,
+			deps:     c.Dependencies,
 		})
 	}
 	return rows
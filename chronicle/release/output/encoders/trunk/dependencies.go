@@ -0,0 +1,54 @@
+package trunk
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/anchore/chronicle/chronicle/dependency"
+	"github.com/anchore/chronicle/chronicle/release/render"
+)
+
+// dependencyGlyph marks a dependency event by how the package moved.
+func dependencyGlyph(k dependency.ChangeKind) string {
+	switch k {
+	case dependency.Added:
+		return "+"
+	case dependency.Removed:
+		return "-"
+	case dependency.Updated:
+		return "↑"
+	case dependency.Downgraded:
+		return "↓"
+	}
+	return "·"
+}
+
+// dependencyText is the visible text of one dependency event row, e.g.
+// "↑ golang.org/x/net v0.22.0 → v0.23.0".
+func dependencyText(c dependency.PackageChange) string {
+	return dependencyGlyph(c.Kind) + " " + c.Name + " " + render.VersionTransitionWith(c, nil)
+}
+
+// writeDependencyRows renders a commit's dependency events (from the
+// dependency timeline) as indented rows that start under the title column, in
+// both display modes. Downgrades take the major (red) color since they are
+// what an audit is usually looking for; events on a filtered commit render dim
+// like the commit itself.
+func writeDependencyRows(w io.Writer, st styles, changes []dependency.PackageChange, prefix string, filtered bool) error {
+	// prefix is "│" followed by spaces; render the trunk char dim so it
+	// matches the trunk decoration on the spacer lines.
+	lead := st.dim.Render(st.trunkLine) + prefix[len(st.trunkLine):]
+	for _, c := range changes {
+		style := st.normal
+		switch {
+		case filtered:
+			style = st.dim
+		case c.Kind == dependency.Downgraded:
+			style = st.major
+		}
+		if _, err := fmt.Fprintln(w, lead+style.Render(dependencyText(c))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
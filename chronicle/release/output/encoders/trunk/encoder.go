@@ -1,7 +1,9 @@
 // Package trunk provides a commit-anchored vertical-trunk visualization of a release.
 // It renders commits newest-first between version anchors, showing PR numbers, titles,
 // linked issues, and change types. Two display modes are available: condensed (one row
-// per commit) and expanded (issues get their own indented rows beneath their PR).
+// per commit) and expanded (issues get their own indented rows beneath their PR). In
+// both, the dependency events the dependency timeline placed on a commit are listed in
+// indented rows beneath it.
 package trunk
 
 import (
@@ -18,7 +20,8 @@ const ID = "trunk"
 //
 // Condensed=true collapses issues into the "closes" column of each commit row.
 // Condensed=false (expanded) gives each issue its own indented row.
-// ShowFiltered=true renders filtered rows in a dim style; false omits them.
+// ShowFiltered=true renders filtered rows in a dim style; false omits them, except
+// for commits that carry dependency events.
 // IsTTY controls whether ANSI escape codes are emitted.
 type Encoder struct {
 	Condensed    bool
@@ -9,6 +9,7 @@ import (
 	"github.com/gkampitakis/go-snaps/snaps"
 	"github.com/stretchr/testify/require"
 
+	"github.com/anchore/chronicle/chronicle/dependency"
 	"github.com/anchore/chronicle/chronicle/release"
 	"github.com/anchore/chronicle/chronicle/release/change"
 )
@@ -229,6 +230,46 @@ func TestEncoder_Encode(t *testing.T) {
 	}
 }
 
+func TestEncoder_DependencyTimeline(t *testing.T) {
+	// the kept PR bumped two packages; the dependabot PR is filtered out of the
+	// changelog but still shown, since it carries the downgrade being audited;
+	// the plain filtered commit changed nothing and stays hidden.
+	trunk := &release.TrunkData{
+		Commits: []release.TrunkCommit{
+			{
+				Hash: "a1b2c3d4e5f6abc",
+				PR: &release.TrunkPR{
+					Number:      466,
+					Title:       "feat: multi-output formats",
+					ChangeTypes: []change.Type{change.NewType("enhancement", change.SemVerMinor)},
+					Issues:      []release.TrunkIssue{{Number: 450, Title: "add JSON output to releases"}},
+				},
+				Dependencies: []dependency.PackageChange{
+					{Name: "github.com/google/uuid", Type: "go-module", ToVersion: "v1.6.0", Kind: dependency.Added},
+					{Name: "golang.org/x/net", Type: "go-module", FromVersion: "v0.22.0", ToVersion: "v0.23.0", Kind: dependency.Updated},
+				},
+			},
+			{Hash: "deadbeefcafe000", Subject: "chore: fix typo in README"},
+			{
+				Hash: "f7e8d9c0b1a2345",
+				PR:   &release.TrunkPR{Number: 467, Title: "chore(deps): bump golang.org/x/text", Filtered: true, Reason: "author:dependabot"},
+				Dependencies: []dependency.PackageChange{
+					{Name: "golang.org/x/text", Type: "go-module", FromVersion: "v0.15.0", ToVersion: "v0.14.0", Kind: dependency.Downgraded},
+					{Name: "golang.org/x/tools", Type: "go-module", FromVersion: "v0.0.0-20240101000000-abcdef123456", Kind: dependency.Removed},
+				},
+			},
+		},
+	}
+
+	for _, condensed := range []bool{true, false} {
+		t.Run(map[bool]string{true: "condensed", false: "expanded"}[condensed], func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, (&Encoder{Condensed: condensed}).Encode(&buf, "", fixtureDescription(false, fixturePreviousRelease, trunk)))
+			snaps.MatchSnapshot(t, buf.String())
+		})
+	}
+}
+
 func TestEncoder_Hyperlinks(t *testing.T) {
 	// fixture with URLs populated on commit, PR, and issue.
 	withURLs := &release.TrunkData{
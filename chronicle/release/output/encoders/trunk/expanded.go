@@ -5,6 +5,7 @@ import (
 	"io"
 	"strings"
 
+	"github.com/anchore/chronicle/chronicle/dependency"
 	"github.com/anchore/chronicle/chronicle/release"
 	"github.com/anchore/chronicle/chronicle/release/change"
 )
@@ -42,6 +43,12 @@ func (e *Encoder) renderExpanded(w io.Writer, d release.Description) error {
 			}
 			continue
 		}
+		if r.deps != nil {
+			if err := writeDependencyRows(w, st, r.deps, issuePrefix, r.filtered); err != nil {
+				return err
+			}
+			continue
+		}
 
 		if _, err := fmt.Fprintln(w, st.dim.Render(st.trunkLine)); err != nil {
 			return err
@@ -74,6 +81,10 @@ func measureExpandedColumns(rows []expandedRow) (wHash, wPR, wTitle int) {
 	wPR = len("pr")
 	wTitle = len("title")
 	for _, r := range rows {
+		if r.deps != nil {
+			// dependency rows trail past the title column; they don't size it.
+			continue
+		}
 		if l := visibleLen(r.title); l > wTitle {
 			wTitle = l
 		}
@@ -160,8 +171,8 @@ func writeExpandedIssueRow(w io.Writer, st styles, r expandedRow, issuePrefix st
 	return err
 }
 
-// expandedRow is a single line in the expanded output — either a commit row or
-// an issue sub-row.
+// expandedRow is a single line in the expanded output — a commit row, an issue
+// sub-row, or the block of a commit's dependency events.
 type expandedRow struct {
 	// commit fields (only set when isIssue=false).
 	glyph    string
@@ -177,25 +188,31 @@ type expandedRow struct {
 	isIssue  bool
 	issueNum int
 	issueURL string
+	// dependency events (only set on a commit's dependency block, which
+	// carries its commit's filtered flag).
+	deps []dependency.PackageChange
 }
 
-// buildExpandedRows returns commit rows interleaved with issue sub-rows.
+// buildExpandedRows returns commit rows interleaved with issue sub-rows and
+// dependency blocks. A filtered commit that changed dependencies is kept even
+// when filtered rows are hidden, so the timeline never loses an event.
 func (e *Encoder) buildExpandedRows(d release.Description) []expandedRow {
 	var rows []expandedRow
 	for _, c := range d.Trunk.Commits {
 		filtered := c.PR == nil || c.PR.Filtered
 
-		if filtered && !e.ShowFiltered {
+		if filtered && !e.ShowFiltered && len(c.Dependencies) == 0 {
 			continue
 		}
 
 		rows = append(rows, buildExpandedCommitRow(c, filtered))
 
-		if filtered || c.PR == nil {
-			continue
+		if !filtered && c.PR != nil {
+			rows = append(rows, e.buildExpandedIssueRows(c.PR.Issues)...)
+		}
+		if len(c.Dependencies) > 0 {
+			rows = append(rows, expandedRow{deps: c.Dependencies, filtered: filtered})
 		}
-
-		rows = append(rows, e.buildExpandedIssueRows(c.PR.Issues)...)
 	}
 	return rows
 }
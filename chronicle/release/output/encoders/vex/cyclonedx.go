@@ -0,0 +1,147 @@
+package vex
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/CycloneDX/cyclonedx-go"
+
+	"github.com/anchore/chronicle/chronicle/dependency"
+	"github.com/anchore/chronicle/chronicle/release"
+)
+
+// CycloneDXID is the registered name for the CycloneDX VEX encoder.
+const CycloneDXID = "cyclonedx-vex"
+
+// CycloneDXEncoder writes the same statements as Encoder as a CycloneDX BOM
+// carrying only vulnerabilities: the release is the metadata component, the
+// dependencies involved are the components, and each vulnerability's analysis
+// state is "resolved" (fixed) or "exploitable" (affected) — the CycloneDX
+// equivalents of the OpenVEX statuses.
+type CycloneDXEncoder struct {
+	// Author is the document author; DefaultAuthor when empty.
+	Author string
+	// Product overrides the product identifier derived from the repository.
+	Product string
+}
+
+func (e *CycloneDXEncoder) ID() string { return CycloneDXID }
+
+func (e *CycloneDXEncoder) Encode(w io.Writer, _ string, d release.Description) error {
+	if d.Version == "" {
+		return errors.New("version is empty (was --speculate-next-version expected?)")
+	}
+	stmts, err := statements(d.DependencyDiff)
+	if err != nil {
+		return err
+	}
+
+	prod := newProduct(d, e.Product)
+	author := e.Author
+	if author == "" {
+		author = DefaultAuthor
+	}
+
+	bom := cyclonedx.NewBOM()
+	bom.Metadata = &cyclonedx.Metadata{
+		Timestamp: timestamp(d).Format(time.RFC3339),
+		Authors:   &[]cyclonedx.OrganizationalContact{{Name: author}},
+		Component: &cyclonedx.Component{
+			BOMRef:     prod.ID,
+			Type:       cyclonedx.ComponentTypeApplication,
+			Name:       prod.Name,
+			Version:    prod.Version,
+			PackageURL: prod.PURL,
+		},
+	}
+
+	components := map[string]cyclonedx.Component{}
+	vulns := []cyclonedx.Vulnerability{}
+	for _, s := range stmts {
+		for _, c := range s.Components {
+			components[c.ref()] = cyclonedx.Component{
+				BOMRef:     c.ref(),
+				Type:       cyclonedx.ComponentTypeLibrary,
+				Name:       c.Name,
+				Version:    c.Version,
+				PackageURL: c.PURL,
+			}
+		}
+		vulns = append(vulns, cyclonedxVulnerability(s))
+	}
+
+	comps := make([]cyclonedx.Component, 0, len(components))
+	for _, c := range components {
+		comps = append(comps, c)
+	}
+	sort.Slice(comps, func(i, j int) bool { return comps[i].BOMRef < comps[j].BOMRef })
+	bom.Components = &comps
+	bom.Vulnerabilities = &vulns
+
+	enc := cyclonedx.NewBOMEncoder(w, cyclonedx.BOMFileFormatJSON)
+	enc.SetPretty(true)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(bom); err != nil {
+		return fmt.Errorf("unable to encode cyclonedx vex document: %w", err)
+	}
+	return nil
+}
+
+func cyclonedxVulnerability(s statement) cyclonedx.Vulnerability {
+	v := cyclonedx.Vulnerability{
+		// the same vulnerability can be both fixed (in one dependency) and
+		// affected (in another), so the status is part of the reference.
+		BOMRef:  s.Vuln.ID + "#" + string(s.Status),
+		ID:      s.Vuln.ID,
+		Ratings: &[]cyclonedx.VulnerabilityRating{{Severity: cyclonedxSeverity(s.Vuln.Severity)}},
+		Analysis: &cyclonedx.VulnerabilityAnalysis{
+			State:  cyclonedx.IASExploitable,
+			Detail: s.notes(),
+		},
+	}
+	if s.Vuln.DataSource != "" {
+		v.Source = &cyclonedx.Source{URL: s.Vuln.DataSource}
+	}
+
+	versionStatus := cyclonedx.VulnerabilityStatusAffected
+	if s.Status == fixed {
+		v.Analysis.State = cyclonedx.IASResolved
+		versionStatus = cyclonedx.VulnerabilityStatusNotAffected
+	} else {
+		v.Recommendation = s.action()
+	}
+
+	var affects []cyclonedx.Affects
+	for _, c := range s.Components {
+		a := cyclonedx.Affects{Ref: c.ref()}
+		// a removed package has no version in this release to vouch for; the
+		// "resolved" analysis alone carries the fix.
+		if c.Version != "" && c.Kind != dependency.Removed {
+			a.Range = &[]cyclonedx.AffectedVersions{{Version: c.Version, Status: versionStatus}}
+		}
+		affects = append(affects, a)
+	}
+	v.Affects = &affects
+	return v
+}
+
+func cyclonedxSeverity(s string) cyclonedx.Severity {
+	switch strings.ToLower(s) {
+	case "critical":
+		return cyclonedx.SeverityCritical
+	case "high":
+		return cyclonedx.SeverityHigh
+	case "medium":
+		return cyclonedx.SeverityMedium
+	case "low":
+		return cyclonedx.SeverityLow
+	case "negligible":
+		return cyclonedx.SeverityInfo
+	default:
+		return cyclonedx.SeverityUnknown
+	}
+}
@@ -0,0 +1,228 @@
+package vex
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/openvex/go-vex/pkg/vex"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/chronicle/chronicle/dependency"
+	"github.com/anchore/chronicle/chronicle/release"
+)
+
+func sampleDescription() release.Description {
+	cve1 := dependency.Vulnerability{ID: "CVE-2026-0001", Severity: "High", FixState: "fixed", DataSource: "https://nvd.nist.gov/vuln/detail/CVE-2026-0001"}
+	ghsa := dependency.Vulnerability{ID: "GHSA-aaaa-bbbb-cccc", Severity: "Medium", FixState: "not-fixed"}
+	carried := dependency.Vulnerability{ID: "CVE-2025-9999", Severity: "Critical", FixState: "fixed"}
+
+	diff := dependency.NewDiff([]dependency.PackageChange{
+		{
+			Name: "golang.org/x/net", Type: "go-module", FromVersion: "v0.1.0", ToVersion: "v0.2.0", Kind: dependency.Updated,
+			PURL: "pkg:golang/golang.org/x/net@v0.2.0",
+			Vuln: &dependency.VulnDelta{Remediated: []dependency.Vulnerability{cve1}},
+		},
+		{
+			Name: "lodash", Type: "npm", FromVersion: "4.17.20", Kind: dependency.Removed,
+			PURL: "pkg:npm/lodash@4.17.20",
+			Vuln: &dependency.VulnDelta{Remediated: []dependency.Vulnerability{cve1}},
+		},
+		{
+			Name: "requests", Type: "python", FromVersion: "2.32.0", ToVersion: "2.31.0", Kind: dependency.Downgraded,
+			Vuln: &dependency.VulnDelta{Introduced: []dependency.Vulnerability{ghsa}},
+		},
+		{Name: "left-pad", Type: "npm", ToVersion: "1.3.0", Kind: dependency.Added, Vuln: &dependency.VulnDelta{}},
+	})
+	diff.Since.Vulns = map[dependency.VulnKey][]dependency.Vulnerability{}
+	diff.Until.Vulns = map[dependency.VulnKey][]dependency.Vulnerability{}
+	diff.Remaining = []dependency.PackageVulns{
+		{Package: dependency.Package{Name: "openssl", Version: "3.0.0", Type: "binary", PURL: "pkg:generic/openssl@3.0.0"}, Vulns: []dependency.Vulnerability{carried}},
+	}
+	diff.RemainingCount = 1
+
+	return release.Description{
+		Release:         release.Release{Version: "v1.2.3", Date: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)},
+		VCSReferenceURL: "https://github.com/anchore/chronicle/tree/v1.2.3",
+		DependencyDiff:  &diff,
+	}
+}
+
+func TestEncoder_Snapshot(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, (&Encoder{}).Encode(&buf, "", sampleDescription()))
+	snaps.MatchSnapshot(t, buf.String())
+}
+
+func TestCycloneDXEncoder_Snapshot(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, (&CycloneDXEncoder{Author: "security@example.com"}).Encode(&buf, "", sampleDescription()))
+	snaps.MatchSnapshot(t, buf.String())
+}
+
+func TestEncoder_Statements(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, (&Encoder{}).Encode(&buf, "", sampleDescription()))
+
+	doc, err := vex.Parse(buf.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, DefaultAuthor, doc.Author)
+	require.NotEmpty(t, doc.ID)
+
+	byVuln := map[string]vex.Statement{}
+	for _, s := range doc.Statements {
+		require.NoError(t, s.Validate())
+		require.Len(t, s.Products, 1)
+		require.Equal(t, "pkg:github/anchore/chronicle@v1.2.3", s.Products[0].ID)
+		byVuln[string(s.Vulnerability.Name)] = s
+	}
+	require.Len(t, byVuln, 3)
+
+	// remediated through two packages: one fixed statement naming both
+	fixed := byVuln["CVE-2026-0001"]
+	require.Equal(t, vex.StatusFixed, fixed.Status)
+	require.Equal(t, "https://nvd.nist.gov/vuln/detail/CVE-2026-0001", fixed.Vulnerability.ID)
+	require.Len(t, fixed.Products[0].Subcomponents, 2)
+
+	// introduced without a scanner purl: a generic one is synthesized
+	introduced := byVuln["GHSA-aaaa-bbbb-cccc"]
+	require.Equal(t, vex.StatusAffected, introduced.Status)
+	require.NotEmpty(t, introduced.ActionStatement)
+	require.Equal(t, "pkg:generic/requests@2.31.0", introduced.Products[0].Subcomponents[0].ID)
+
+	remaining := byVuln["CVE-2025-9999"]
+	require.Equal(t, vex.StatusAffected, remaining.Status)
+	require.Contains(t, remaining.StatusNotes, "Carried over")
+}
+
+func Test_statements_oneStancePerVulnerability(t *testing.T) {
+	cve := dependency.Vulnerability{ID: "CVE-2026-0001", Severity: "High", FixState: "fixed"}
+	remediatedByA := dependency.PackageChange{
+		Name: "a", Type: "npm", FromVersion: "1.0.0", ToVersion: "1.1.0", Kind: dependency.Updated,
+		Vuln: &dependency.VulnDelta{Remediated: []dependency.Vulnerability{cve}},
+	}
+
+	tests := []struct {
+		name      string
+		changes   []dependency.PackageChange
+		remaining []dependency.PackageVulns
+		want      status
+		wantRefs  []string
+	}{
+		{
+			name:     "remediated everywhere is fixed",
+			changes:  []dependency.PackageChange{remediatedByA},
+			want:     fixed,
+			wantRefs: []string{"pkg:generic/a@1.1.0"},
+		},
+		{
+			name: "introduced through another package is affected",
+			changes: []dependency.PackageChange{remediatedByA, {
+				Name: "b", Type: "npm", ToVersion: "2.0.0", Kind: dependency.Added,
+				Vuln: &dependency.VulnDelta{Introduced: []dependency.Vulnerability{cve}},
+			}},
+			want:     affected,
+			wantRefs: []string{"pkg:generic/b@2.0.0"},
+		},
+		{
+			name:    "still present through another package is affected",
+			changes: []dependency.PackageChange{remediatedByA},
+			remaining: []dependency.PackageVulns{
+				{Package: dependency.Package{Name: "c", Version: "3.0.0", Type: "npm"}, Vulns: []dependency.Vulnerability{cve}},
+			},
+			want:     affected,
+			wantRefs: []string{"pkg:generic/c@3.0.0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := dependency.NewDiff(tt.changes)
+			diff.Until.Vulns = map[dependency.VulnKey][]dependency.Vulnerability{}
+			diff.Remaining = tt.remaining
+
+			got, err := statements(&diff)
+			require.NoError(t, err)
+			require.Len(t, got, 1)
+			require.Equal(t, tt.want, got[0].Status)
+			var refs []string
+			for _, c := range got[0].Components {
+				refs = append(refs, c.ref())
+			}
+			require.Equal(t, tt.wantRefs, refs)
+		})
+	}
+}
+
+func TestEncoder_Reproducible(t *testing.T) {
+	var a, b bytes.Buffer
+	require.NoError(t, (&Encoder{}).Encode(&a, "", sampleDescription()))
+	require.NoError(t, (&Encoder{}).Encode(&b, "", sampleDescription()))
+	require.Equal(t, a.String(), b.String())
+}
+
+func TestEncoders_Errors(t *testing.T) {
+	unannotated := sampleDescription()
+	diff := dependency.NewDiff(unannotated.DependencyDiff.Changes)
+	unannotated.DependencyDiff = &diff
+
+	noVersion := sampleDescription()
+	noVersion.Version = ""
+
+	tests := []struct {
+		name    string
+		desc    release.Description
+		wantErr string
+	}{
+		{name: "no dependency diff", desc: release.Description{Release: release.Release{Version: "v1.0.0"}}, wantErr: "needs vulnerability data"},
+		{name: "unannotated diff", desc: unannotated, wantErr: "needs vulnerability data"},
+		{name: "no version", desc: noVersion, wantErr: "version is empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorContains(t, (&Encoder{}).Encode(&bytes.Buffer{}, "", tt.desc), tt.wantErr)
+			require.ErrorContains(t, (&CycloneDXEncoder{}).Encode(&bytes.Buffer{}, "", tt.desc), tt.wantErr)
+		})
+	}
+}
+
+func Test_newProduct(t *testing.T) {
+	tests := []struct {
+		name     string
+		ref      string
+		override string
+		want     product
+	}{
+		{
+			name: "github reference url",
+			ref:  "https://github.com/anchore/chronicle/tree/v1.2.3",
+			want: product{ID: "pkg:github/anchore/chronicle@v1.2.3", Name: "anchore/chronicle", Version: "v1.2.3", PURL: "pkg:github/anchore/chronicle@v1.2.3"},
+		},
+		{
+			name: "other host falls back to the reference url",
+			ref:  "https://gitlab.com/o/r/-/tags/v1.2.3",
+			want: product{ID: "https://gitlab.com/o/r/-/tags/v1.2.3", Name: "https://gitlab.com/o/r/-/tags/v1.2.3", Version: "v1.2.3"},
+		},
+		{
+			name: "no reference url",
+			want: product{ID: "v1.2.3", Name: "v1.2.3", Version: "v1.2.3"},
+		},
+		{
+			name:     "purl override gets the version",
+			ref:      "https://github.com/anchore/chronicle/tree/v1.2.3",
+			override: "pkg:oci/chronicle?repository_url=ghcr.io/anchore",
+			want:     product{ID: "pkg:oci/chronicle@v1.2.3?repository_url=ghcr.io%2Fanchore", Name: "chronicle", Version: "v1.2.3", PURL: "pkg:oci/chronicle@v1.2.3?repository_url=ghcr.io%2Fanchore"},
+		},
+		{
+			name:     "versioned override is verbatim",
+			override: "pkg:golang/github.com/anchore/chronicle@v1.2.3",
+			want:     product{ID: "pkg:golang/github.com/anchore/chronicle@v1.2.3", Name: "github.com/anchore/chronicle", Version: "v1.2.3", PURL: "pkg:golang/github.com/anchore/chronicle@v1.2.3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := release.Description{Release: release.Release{Version: "v1.2.3"}, VCSReferenceURL: tt.ref}
+			require.Equal(t, tt.want, newProduct(d, tt.override))
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package vex
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/openvex/go-vex/pkg/vex"
+
+	"github.com/anchore/chronicle/chronicle/release"
+)
+
+// ID is the registered name for the OpenVEX encoder.
+const ID = "vex"
+
+// Encoder writes an OpenVEX document describing how this release stands with
+// respect to the vulnerabilities the dependency diff matched: "fixed" for each
+// one a dependency change remediated, "affected" for each one introduced or
+// still carried. Statements are scoped to the release (the product) and name
+// the dependencies involved as subcomponents.
+type Encoder struct {
+	// Author is the document author; DefaultAuthor when empty.
+	Author string
+	// Product overrides the product identifier derived from the repository.
+	Product string
+}
+
+func (e *Encoder) ID() string { return ID }
+
+func (e *Encoder) Encode(w io.Writer, _ string, d release.Description) error {
+	if d.Version == "" {
+		return errors.New("version is empty (was --speculate-next-version expected?)")
+	}
+	stmts, err := statements(d.DependencyDiff)
+	if err != nil {
+		return err
+	}
+
+	prod := newProduct(d, e.Product)
+	ts := timestamp(d)
+	author := e.Author
+	if author == "" {
+		author = DefaultAuthor
+	}
+
+	doc := vex.VEX{
+		Metadata: vex.Metadata{
+			Context:   vex.ContextLocator(),
+			Author:    author,
+			Timestamp: &ts,
+			Version:   1,
+			Tooling:   "chronicle",
+		},
+		Statements: []vex.Statement{},
+	}
+	for _, s := range stmts {
+		doc.Statements = append(doc.Statements, openVEXStatement(s, prod))
+	}
+
+	// a content-derived @id keeps the document reproducible: the same release
+	// always produces byte-identical output.
+	if _, err := doc.GenerateCanonicalID(); err != nil {
+		return fmt.Errorf("unable to generate vex document id: %w", err)
+	}
+	return doc.ToJSON(w)
+}
+
+func openVEXStatement(s statement, prod product) vex.Statement {
+	p := vex.Product{Component: openVEXComponent(prod.ID, prod.PURL)}
+	for _, c := range s.Components {
+		p.Subcomponents = append(p.Subcomponents, vex.Subcomponent{Component: openVEXComponent(c.ref(), c.PURL)})
+	}
+
+	out := vex.Statement{
+		Vulnerability: vex.Vulnerability{ID: s.Vuln.DataSource, Name: vex.VulnerabilityID(s.Vuln.ID)},
+		Products:      []vex.Product{p},
+		StatusNotes:   s.notes(),
+	}
+	switch s.Status {
+	case fixed:
+		out.Status = vex.StatusFixed
+	case affected:
+		out.Status = vex.StatusAffected
+		out.ActionStatement = s.action()
+	}
+	return out
+}
+
+func openVEXComponent(id, purl string) vex.Component {
+	c := vex.Component{ID: id}
+	if purl != "" {
+		c.Identifiers = map[vex.IdentifierType]string{vex.PURL: purl}
+	}
+	return c
+}
@@ -0,0 +1,219 @@
+package vex
+
+import (
+	"errors"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/anchore/chronicle/chronicle/dependency"
+	"github.com/anchore/chronicle/chronicle/release"
+	"github.com/anchore/packageurl-go"
+)
+
+// DefaultAuthor is the document author used when none is configured.
+const DefaultAuthor = "chronicle"
+
+// errNotAnnotated is returned when the description carries no vulnerability
+// data. An empty VEX document would read as "nothing affects this release",
+// which is a claim we can't make without having matched anything.
+var errNotAnnotated = errors.New("vex output needs vulnerability data; enable dependencies.annotate-vulnerabilities (--vulnerabilities)")
+
+// status is the stance a statement takes on a vulnerability for the product.
+type status string
+
+const (
+	fixed    status = "fixed"    // remediated by this release
+	affected status = "affected" // introduced by, or carried into, this release
+)
+
+// statement is the format-neutral content of one VEX statement: a single
+// vulnerability, the stance this release takes on it, and the dependencies
+// through which it applies. Both encoders render from these.
+type statement struct {
+	Vuln       dependency.Vulnerability
+	Status     status
+	Components []component
+}
+
+// component is one dependency a statement is about, at the version relevant to
+// the statement (the version shipped in the release, or for a removed package,
+// the last version that was shipped).
+type component struct {
+	Name    string
+	Type    string
+	Version string
+	PURL    string
+	Kind    dependency.ChangeKind // "" for carried-over (remaining) vulnerabilities
+}
+
+// product identifies the thing the statements are scoped to: this release.
+type product struct {
+	ID      string // IRI / purl used as the VEX product @id
+	Name    string
+	Version string
+	PURL    string // set when ID is a purl
+}
+
+// statements derives the VEX statements from an annotated dependency diff:
+// remediated vulnerabilities are fixed, and introduced and remaining ones are
+// affected. A vulnerability that arrives through several packages is a single
+// statement listing each of them, and the release takes one stance on it: one
+// remediated through some packages but still present through others is
+// affected, not also fixed. The result is sorted so output is stable.
+func statements(diff *dependency.Diff) ([]statement, error) {
+	if diff == nil || (diff.Since.Vulns == nil && diff.Until.Vulns == nil) {
+		return nil, errNotAnnotated
+	}
+
+	type key struct {
+		id     string
+		status status
+	}
+	idx := map[key]*statement{}
+	var order []key
+	add := func(v dependency.Vulnerability, s status, c component) {
+		k := key{id: v.ID, status: s}
+		st, ok := idx[k]
+		if !ok {
+			st = &statement{Vuln: v, Status: s}
+			idx[k] = st
+			order = append(order, k)
+		}
+		st.Components = append(st.Components, c)
+	}
+
+	for _, c := range diff.Changes {
+		if c.Vuln == nil {
+			continue
+		}
+		version := c.ToVersion
+		if c.Kind == dependency.Removed {
+			version = c.FromVersion
+		}
+		comp := component{Name: c.Name, Type: c.Type, Version: version, PURL: c.PURL, Kind: c.Kind}
+		for _, v := range c.Vuln.Remediated {
+			add(v, fixed, comp)
+		}
+		for _, v := range c.Vuln.Introduced {
+			add(v, affected, comp)
+		}
+	}
+	for _, pv := range diff.Remaining {
+		comp := component{Name: pv.Package.Name, Type: pv.Package.Type, Version: pv.Package.Version, PURL: pv.Package.PURL}
+		for _, v := range pv.Vulns {
+			add(v, affected, comp)
+		}
+	}
+
+	out := make([]statement, 0, len(order))
+	for _, k := range order {
+		if _, stillAffected := idx[key{id: k.id, status: affected}]; k.status == fixed && stillAffected {
+			continue
+		}
+		st := *idx[k]
+		sort.SliceStable(st.Components, func(i, j int) bool { return st.Components[i].ref() < st.Components[j].ref() })
+		out = append(out, st)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Vuln.ID < out[j].Vuln.ID })
+	return out, nil
+}
+
+// ref is the identifier used for the component in both documents: its package
+// URL, or a generic one synthesized from name and version when the scanner
+// didn't report a purl.
+func (c component) ref() string {
+	if c.PURL != "" {
+		return c.PURL
+	}
+	return packageurl.NewPackageURL("generic", "", c.Name, c.Version, nil, "").ToString()
+}
+
+// action is the remediation advice VEX requires for an affected statement.
+func (s statement) action() string {
+	if s.Vuln.FixState == "fixed" {
+		return "Update the affected dependencies to a version that includes the upstream fix."
+	}
+	return "No upstream fix is available yet; monitor the vulnerability for a fixed release."
+}
+
+// notes explains how the statement's status was determined.
+func (s statement) notes() string {
+	if s.Status == fixed {
+		return "Remediated by the dependency changes in this release."
+	}
+	for _, c := range s.Components {
+		if c.Kind != "" {
+			return "Introduced by the dependency changes in this release."
+		}
+	}
+	return "Carried over from the previous release; still present in this release's dependencies."
+}
+
+// newProduct resolves the product the document is about. An explicit override
+// wins (a purl without a version gets the release version appended); otherwise
+// a GitHub reference URL becomes a pkg:github purl, and anything else falls back
+// to the reference URL itself, or just the version.
+func newProduct(d release.Description, override string) product {
+	p := product{Version: d.Version}
+
+	if override != "" {
+		p.ID = override
+		if purl, err := packageurl.FromString(override); err == nil {
+			if purl.Version == "" {
+				purl.Version = d.Version
+			}
+			p.ID = purl.ToString()
+			p.PURL = p.ID
+		}
+		p.Name = productName(p.ID)
+		return p
+	}
+
+	if owner, repo, ok := githubRepo(d.VCSReferenceURL); ok {
+		p.PURL = packageurl.NewPackageURL(packageurl.TypeGithub, owner, repo, d.Version, nil, "").ToString()
+		p.ID = p.PURL
+		p.Name = owner + "/" + repo
+		return p
+	}
+
+	p.ID = d.VCSReferenceURL
+	if p.ID == "" {
+		p.ID = d.Version
+	}
+	p.Name = productName(p.ID)
+	return p
+}
+
+func githubRepo(ref string) (owner, repo string, ok bool) {
+	u, err := url.Parse(ref)
+	if err != nil || !strings.EqualFold(u.Hostname(), "github.com") {
+		return "", "", false
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], strings.TrimSuffix(parts[1], ".git"), true
+}
+
+// productName is a human-readable name for an override or fallback ID.
+func productName(id string) string {
+	if p, err := packageurl.FromString(id); err == nil {
+		if p.Namespace != "" {
+			return p.Namespace + "/" + p.Name
+		}
+		return p.Name
+	}
+	return id
+}
+
+// timestamp is the document time. The release date is used (rather than "now")
+// so the same release always yields the same document.
+func timestamp(d release.Description) time.Time {
+	if d.Date.IsZero() {
+		return time.Now().UTC()
+	}
+	return d.Date.UTC()
+}
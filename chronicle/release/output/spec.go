@@ -2,6 +2,7 @@ package output
 
 import (
 	"fmt"
+	"net/url"
 	"path/filepath"
 	"strings"
 )
@@ -10,7 +11,8 @@ import (
 // side of an `=`. Borrowed from the unix convention.
 const stdoutToken = "-"
 
-// Spec is one parsed `-o NAME[=PATH]` entry. An empty Path means stdout.
+// Spec is one parsed `-o NAME[=PATH]` entry. An empty Path means stdout; an
+// http(s) URL means the encoded output is POSTed to that URL.
 type Spec struct {
 	Name string
 	Path string // empty for stdout
@@ -21,6 +23,27 @@ func (s Spec) IsStdout() bool {
 	return s.Path == ""
 }
 
+// IsWebhook reports whether the spec delivers to an http(s) URL rather than a
+// file. The scheme check is case-insensitive; anything else (including
+// relative paths that merely contain "://") is treated as a file path.
+func (s Spec) IsWebhook() bool {
+	p := strings.ToLower(s.Path)
+	return strings.HasPrefix(p, "http://") || strings.HasPrefix(p, "https://")
+}
+
+// Destination is the spec's target as it is safe to show the user: the file
+// path, "stdout", or a webhook URL trimmed to its scheme and host.
+func (s Spec) Destination() string {
+	switch {
+	case s.IsStdout():
+		return "stdout"
+	case s.IsWebhook():
+		return redactURL(s.Path)
+	default:
+		return s.Path
+	}
+}
+
 // ParseSpec parses a single `NAME[=PATH]` token. NAME=- and bare NAME both
 // mean stdout. NAME= (empty path) is rejected as ambiguous.
 func ParseSpec(raw string) (Spec, error) {
@@ -63,6 +86,7 @@ func ParseSpecs(raws []string) ([]Spec, error) {
 //   - at least one entry
 //   - at most one entry writes to stdout (otherwise outputs would interleave)
 //   - no two entries write to the same absolute file path
+//   - no two entries post to the same webhook URL
 //
 // Encoder-name validity is checked later by New, against the Encoders set
 // the caller supplies — this layer is intentionally encoder-agnostic.
@@ -72,13 +96,23 @@ func Validate(specs []Spec) error {
 	}
 
 	var stdoutCount int
-	seenPaths := map[string]string{} // abs path -> original spec string for diagnostics
+	seenPaths := map[string]string{} // abs path (or webhook URL) -> original spec string for diagnostics
 
 	for _, s := range specs {
 		if s.IsStdout() {
 			stdoutCount++
 			continue
 		}
+		if s.IsWebhook() {
+			if _, err := url.ParseRequestURI(s.Path); err != nil {
+				return fmt.Errorf("output %q: invalid webhook URL", s.Name)
+			}
+			if prev, dup := seenPaths[s.Path]; dup {
+				return fmt.Errorf("two outputs post to the same webhook (%s and %s)", prev, formatSpec(s))
+			}
+			seenPaths[s.Path] = formatSpec(s)
+			continue
+		}
 		abs, err := filepath.Abs(s.Path)
 		if err != nil {
 			return fmt.Errorf("output %q: cannot resolve path: %w", s.Name, err)
@@ -98,5 +132,7 @@ func formatSpec(s Spec) string {
 	if s.IsStdout() {
 		return s.Name
 	}
-	return s.Name + "=" + s.Path
+	// Destination never echoes a webhook URL in full: its path is usually the
+	// credential
+	return s.Name + "=" + s.Destination()
 }
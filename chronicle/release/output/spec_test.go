@@ -112,6 +112,19 @@ func TestValidate(t *testing.T) {
 			specs:   []Spec{{Name: "md", Path: "out.md"}, {Name: "json", Path: "out.md"}},
 			wantErr: require.Error,
 		},
+		{
+			name:  "webhook alongside stdout and file",
+			specs: []Spec{{Name: "md"}, {Name: "json", Path: "out.json"}, {Name: "slack", Path: "https://hooks.example.com/a"}},
+		},
+		{
+			name:  "two different webhooks",
+			specs: []Spec{{Name: "slack", Path: "https://hooks.example.com/a"}, {Name: "json", Path: "https://hooks.example.com/b"}},
+		},
+		{
+			name:    "same webhook twice",
+			specs:   []Spec{{Name: "slack", Path: "https://hooks.example.com/a"}, {Name: "json", Path: "https://hooks.example.com/a"}},
+			wantErr: require.Error,
+		},
 		// note: unknown encoder names are no longer Validate's job; that check
 		// belongs to New, where the caller-supplied Encoders set is in scope.
 	}
@@ -126,3 +139,31 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestSpec_IsWebhook(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "", want: false},
+		{path: "CHANGELOG.md", want: false},
+		{path: "./http://weird", want: false},
+		{path: "http://localhost:8080/hook", want: true},
+		{path: "https://hooks.slack.com/services/T0/B0/x", want: true},
+		{path: "HTTPS://hooks.slack.com/services/T0/B0/x", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			require.Equal(t, tt.want, Spec{Name: "slack", Path: tt.path}.IsWebhook())
+		})
+	}
+}
+
+func TestParseSpec_WebhookURLKeepsEquals(t *testing.T) {
+	// only the first '=' separates name from destination; query strings with
+	// their own '=' must survive intact.
+	got, err := ParseSpec("slack=https://example.com/hook?token=abc")
+	require.NoError(t, err)
+	require.Equal(t, Spec{Name: "slack", Path: "https://example.com/hook?token=abc"}, got)
+	require.True(t, got.IsWebhook())
+}
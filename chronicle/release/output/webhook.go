@@ -0,0 +1,251 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anchore/chronicle/internal/bus"
+	"github.com/anchore/chronicle/internal/log"
+)
+
+// WebhookEncoder is an optional interface for encoders whose raw output is
+// not itself a valid webhook request body. The Slack encoder, for example,
+// produces mrkdwn text that has to be wrapped as {"text": ...} before a Slack
+// incoming webhook will accept it. Encoders that don't implement this are
+// posted verbatim as text/plain.
+type WebhookEncoder interface {
+	Encoder
+	WebhookPayload(encoded []byte) (body []byte, contentType string, err error)
+}
+
+// WebhookConfig tunes delivery for specs whose destination is an http(s) URL.
+// The zero value is usable: it sends for real with the default retry policy.
+type WebhookConfig struct {
+	// DryRun prints the request that would be sent (to stdout, via the bus)
+	// instead of sending it.
+	DryRun bool
+
+	// Retries is the number of additional attempts after the first one fails
+	// with a retryable error (network error, 429, or 5xx). Negative means 0.
+	Retries int
+
+	// Backoff is the delay before the first retry; it doubles on each
+	// subsequent retry. A server-provided Retry-After takes precedence.
+	Backoff time.Duration
+
+	// Client is the HTTP client used to send requests. Nil means a client
+	// with a modest per-request timeout.
+	Client *http.Client
+}
+
+const (
+	defaultWebhookRetries = 3
+	defaultWebhookBackoff = time.Second
+	defaultWebhookTimeout = 15 * time.Second
+
+	// maxWebhookResponseSnippet caps how much of an error response body is
+	// surfaced in the returned error; chat services tend to answer with short
+	// plain-text reasons ("invalid_payload", "no_service") which is all we need.
+	maxWebhookResponseSnippet = 256
+)
+
+// DefaultWebhookConfig returns the delivery policy used by New.
+func DefaultWebhookConfig() WebhookConfig {
+	return WebhookConfig{
+		Retries: defaultWebhookRetries,
+		Backoff: defaultWebhookBackoff,
+	}
+}
+
+// webhookSink buffers encoder output like publisherSink does, then POSTs it to
+// the destination URL on Commit. Delivery happens only after every encoder
+// succeeded (Close aborts instead of committing otherwise), so a failed run
+// never announces a half-rendered release to a chat channel.
+type webhookSink struct {
+	url     string
+	payload func([]byte) ([]byte, string, error)
+	cfg     WebhookConfig
+	buf     bytes.Buffer
+	done    bool
+
+	// sleep is swapped out by tests so retry paths don't actually wait.
+	sleep func(time.Duration)
+}
+
+func newWebhookSink(rawURL string, enc Encoder, cfg WebhookConfig) *webhookSink {
+	payload := func(b []byte) ([]byte, string, error) {
+		return b, "text/plain; charset=utf-8", nil
+	}
+	if we, ok := enc.(WebhookEncoder); ok {
+		payload = we.WebhookPayload
+	}
+	if cfg.Client == nil {
+		cfg.Client = &http.Client{Timeout: defaultWebhookTimeout}
+	}
+	if cfg.Retries < 0 {
+		cfg.Retries = 0
+	}
+	return &webhookSink{
+		url:     rawURL,
+		payload: payload,
+		cfg:     cfg,
+		sleep:   time.Sleep,
+	}
+}
+
+func (s *webhookSink) Write(p []byte) (int, error) { return s.buf.Write(p) }
+
+// Commit delivers the buffered payload (or prints it, in dry-run mode).
+// Idempotent — subsequent calls are no-ops.
+func (s *webhookSink) Commit() error {
+	if s.done {
+		return nil
+	}
+	s.done = true
+
+	body, contentType, err := s.payload(s.buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("unable to build webhook payload for %s: %w", redactURL(s.url), err)
+	}
+
+	if s.cfg.DryRun {
+		bus.Report(formatDryRun(s.url, contentType, body))
+		return nil
+	}
+
+	return s.deliver(body, contentType)
+}
+
+// Abort discards the buffered payload; nothing has been sent yet.
+func (s *webhookSink) Abort() error {
+	s.done = true
+	s.buf.Reset()
+	return nil
+}
+
+func (s *webhookSink) deliver(body []byte, contentType string) error {
+	target := redactURL(s.url)
+	backoff := s.cfg.Backoff
+
+	var lastErr error
+	for attempt := 0; attempt <= s.cfg.Retries; attempt++ {
+		if attempt > 0 {
+			wait := backoff
+			var re *retryAfterError
+			if errors.As(lastErr, &re) && re.after > 0 {
+				wait = re.after
+			}
+			log.WithFields("url", target, "attempt", attempt+1, "wait", wait).Debug("retrying webhook delivery")
+			s.sleep(wait)
+			backoff *= 2
+		}
+
+		retryable, err := s.post(body, contentType)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if !retryable {
+			break
+		}
+	}
+	return fmt.Errorf("unable to deliver webhook to %s: %w", target, lastErr)
+}
+
+// post makes one delivery attempt. It reports whether a failure is worth
+// retrying: transport errors, 429 and 5xx are (the service may recover), any
+// other non-2xx is not (a bad URL or payload won't get better on its own).
+func (s *webhookSink) post(body []byte, contentType string) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("User-Agent", "chronicle")
+
+	resp, err := s.cfg.Client.Do(req)
+	if err != nil {
+		// the url may carry a secret token in its path; the *url.Error from the
+		// client would echo it back verbatim, so report only the cause.
+		var ue *url.Error
+		if errors.As(err, &ue) {
+			err = ue.Err
+		}
+		return true, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return false, nil
+	}
+
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxWebhookResponseSnippet))
+	err = fmt.Errorf("server responded %s", resp.Status)
+	if msg := strings.TrimSpace(string(snippet)); msg != "" {
+		err = fmt.Errorf("server responded %s: %s", resp.Status, msg)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return true, &retryAfterError{err: err, after: parseRetryAfter(resp.Header.Get("Retry-After"))}
+	case resp.StatusCode >= 500:
+		return true, err
+	default:
+		return false, err
+	}
+}
+
+// retryAfterError carries a server-requested delay alongside the failure so
+// the retry loop can honor it instead of its own backoff.
+type retryAfterError struct {
+	err   error
+	after time.Duration
+}
+
+func (e *retryAfterError) Error() string { return e.err.Error() }
+func (e *retryAfterError) Unwrap() error { return e.err }
+
+// parseRetryAfter understands the delay-seconds form of Retry-After, which is
+// what chat services send in practice. The HTTP-date form is ignored (falls
+// back to the configured backoff).
+func parseRetryAfter(v string) time.Duration {
+	secs, err := strconv.Atoi(strings.TrimSpace(v))
+	if err != nil || secs < 0 {
+		return 0
+	}
+	return time.Duration(secs) * time.Second
+}
+
+// formatDryRun renders the request roughly as it would appear on the wire.
+func formatDryRun(rawURL, contentType string, body []byte) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "POST %s\n", redactURL(rawURL))
+	fmt.Fprintf(&b, "Content-Type: %s\n\n", contentType)
+	b.Write(body)
+	if len(body) > 0 && body[len(body)-1] != '\n' {
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// redactURL trims a webhook URL down to its scheme and host. Incoming-webhook
+// URLs (Slack, Teams, Discord, ...) embed their credential in the path, so the
+// full URL must never reach logs, errors, or CI output.
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return "webhook"
+	}
+	if u.Path == "" || u.Path == "/" {
+		return u.Scheme + "://" + u.Host
+	}
+	return u.Scheme + "://" + u.Host + "/…"
+}
@@ -0,0 +1,224 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/chronicle/chronicle/release"
+)
+
+// wrappingEncoder records like recordingEncoder but also implements
+// WebhookEncoder, wrapping its output in a {"text": ...} envelope the way the
+// slack encoder does.
+type wrappingEncoder struct{ recordingEncoder }
+
+func (e *wrappingEncoder) WebhookPayload(b []byte) ([]byte, string, error) {
+	body, err := json.Marshal(map[string]string{"text": string(b)})
+	return body, "application/json", err
+}
+
+type receivedRequest struct {
+	contentType string
+	body        string
+}
+
+func newTestWebhookWriter(t *testing.T, specs []Spec, encs Encoders, cfg WebhookConfig) Writer {
+	t.Helper()
+	w, err := NewWithWebhook(specs, encs, cfg)
+	require.NoError(t, err)
+	// retries should not actually wait in tests
+	for _, p := range w.(*multiWriter).pairs {
+		if ws, ok := p.sk.(*webhookSink); ok {
+			ws.sleep = func(time.Duration) {}
+		}
+	}
+	return w
+}
+
+func TestWebhook_DeliversWrappedPayload(t *testing.T) {
+	got := make(chan receivedRequest, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		b, _ := io.ReadAll(r.Body)
+		got <- receivedRequest{contentType: r.Header.Get("Content-Type"), body: string(b)}
+	}))
+	defer srv.Close()
+
+	encs := NewEncoders(&wrappingEncoder{recordingEncoder{id: "chat"}})
+	w := newTestWebhookWriter(t, []Spec{{Name: "chat", Path: srv.URL + "/services/T000/B000/secret"}}, encs, DefaultWebhookConfig())
+
+	require.NoError(t, w.Write("Title", release.Description{Release: release.Release{Version: "v1.0.0"}}))
+	require.NoError(t, w.Close())
+
+	req := <-got
+	require.Equal(t, "application/json", req.contentType)
+	require.JSONEq(t, `{"text":"chat:Title:v1.0.0"}`, req.body)
+}
+
+func TestWebhook_PlainEncoderPostedVerbatim(t *testing.T) {
+	got := make(chan receivedRequest, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		got <- receivedRequest{contentType: r.Header.Get("Content-Type"), body: string(b)}
+	}))
+	defer srv.Close()
+
+	encs := NewEncoders(&recordingEncoder{id: "rec"})
+	w := newTestWebhookWriter(t, []Spec{{Name: "rec", Path: srv.URL}}, encs, DefaultWebhookConfig())
+
+	require.NoError(t, w.Write("T", release.Description{Release: release.Release{Version: "v2"}}))
+	require.NoError(t, w.Close())
+
+	req := <-got
+	require.Equal(t, "text/plain; charset=utf-8", req.contentType)
+	require.Equal(t, "rec:T:v2", req.body)
+}
+
+func TestWebhook_RetriesTransientFailures(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		switch calls.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer srv.Close()
+
+	encs := NewEncoders(&recordingEncoder{id: "rec"})
+	w := newTestWebhookWriter(t, []Spec{{Name: "rec", Path: srv.URL}}, encs, WebhookConfig{Retries: 3})
+
+	require.NoError(t, w.Write("", release.Description{}))
+	require.NoError(t, w.Close())
+	require.Equal(t, int32(3), calls.Load())
+}
+
+func TestWebhook_FailureAfterRetries(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	encs := NewEncoders(&recordingEncoder{id: "rec"}, &recordingEncoder{id: "file"})
+	path := filepath.Join(t.TempDir(), "out.txt")
+	w := newTestWebhookWriter(t, []Spec{
+		{Name: "rec", Path: srv.URL + "/hooks/secret-token"},
+		{Name: "file", Path: path},
+	}, encs, WebhookConfig{Retries: 2})
+
+	require.NoError(t, w.Write("", release.Description{}))
+	err := w.Close()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "502")
+	require.NotContains(t, err.Error(), "secret-token", "webhook credential leaked into error")
+	require.Equal(t, int32(3), calls.Load(), "expected first attempt plus 2 retries")
+
+	// the local file still lands even though delivery failed
+	_, statErr := os.Stat(path)
+	require.NoError(t, statErr)
+}
+
+func TestWebhook_ClientErrorNotRetried(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, "no_service")
+	}))
+	defer srv.Close()
+
+	encs := NewEncoders(&recordingEncoder{id: "rec"})
+	w := newTestWebhookWriter(t, []Spec{{Name: "rec", Path: srv.URL}}, encs, WebhookConfig{Retries: 3})
+
+	require.NoError(t, w.Write("", release.Description{}))
+	err := w.Close()
+	require.ErrorContains(t, err, "no_service")
+	require.Equal(t, int32(1), calls.Load())
+}
+
+func TestWebhook_NotSentOnEncodeError(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		calls.Add(1)
+	}))
+	defer srv.Close()
+
+	encs := NewEncoders(&recordingEncoder{id: "rec"}, &erroringEncoder{id: "bad"})
+	w := newTestWebhookWriter(t, []Spec{{Name: "rec", Path: srv.URL}, {Name: "bad"}}, encs, DefaultWebhookConfig())
+
+	require.Error(t, w.Write("", release.Description{}))
+	require.NoError(t, w.Close())
+	require.Zero(t, calls.Load())
+}
+
+func TestWebhook_NotSentWhenFileCommitFails(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		calls.Add(1)
+	}))
+	defer srv.Close()
+
+	encs := NewEncoders(&recordingEncoder{id: "rec"}, &recordingEncoder{id: "file"})
+	path := filepath.Join(t.TempDir(), "out.txt")
+	w := newTestWebhookWriter(t, []Spec{{Name: "rec", Path: srv.URL}, {Name: "file", Path: path}}, encs, DefaultWebhookConfig())
+
+	require.NoError(t, w.Write("", release.Description{}))
+	// a non-empty directory at the destination makes the rename fail
+	require.NoError(t, os.MkdirAll(filepath.Join(path, "occupied"), 0o755))
+
+	require.ErrorContains(t, w.Close(), "rename to")
+	require.Zero(t, calls.Load())
+}
+
+func TestWebhook_DryRunDoesNotSend(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		calls.Add(1)
+	}))
+	defer srv.Close()
+
+	encs := NewEncoders(&wrappingEncoder{recordingEncoder{id: "chat"}})
+	w := newTestWebhookWriter(t, []Spec{{Name: "chat", Path: srv.URL}}, encs, WebhookConfig{DryRun: true})
+
+	require.NoError(t, w.Write("T", release.Description{Release: release.Release{Version: "v1"}}))
+	require.NoError(t, w.Close())
+	require.Zero(t, calls.Load())
+}
+
+func TestFormatDryRun(t *testing.T) {
+	got := formatDryRun("https://hooks.slack.com/services/T0/B0/secret", "application/json", []byte(`{"text":"hi"}`))
+	require.Equal(t, "POST https://hooks.slack.com/…\nContent-Type: application/json\n\n{\"text\":\"hi\"}\n", got)
+}
+
+func TestRedactURL(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "https://hooks.slack.com/services/T0/B0/secret", want: "https://hooks.slack.com/…"},
+		{in: "http://localhost:8080", want: "http://localhost:8080"},
+		{in: "http://localhost:8080/", want: "http://localhost:8080"},
+		{in: "https://example.com/hook?token=abc", want: "https://example.com/…"},
+		{in: "not a url", want: "webhook"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			require.Equal(t, tt.want, redactURL(tt.in))
+		})
+	}
+}
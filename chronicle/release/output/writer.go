@@ -22,9 +22,17 @@ type Writer interface {
 //
 // On any subsequent Encode error, Close removes all temp files and returns
 // the first encode error joined with any close errors. On success, Close
-// renames each temp file into place.
+// renames each temp file into place and delivers any webhook payloads.
 func New(specs []Spec, encs Encoders) (Writer, error) {
-	return newWithSink(specs, encs, func() sink { return &publisherSink{} })
+	return NewWithWebhook(specs, encs, DefaultWebhookConfig())
+}
+
+// NewWithWebhook is New with an explicit delivery policy for specs whose
+// destination is an http(s) URL (e.g. `-o slack=https://hooks.slack.com/...`).
+// A failed delivery surfaces as an error from Close, after every other sink
+// has been committed.
+func NewWithWebhook(specs []Spec, encs Encoders, wh WebhookConfig) (Writer, error) {
+	return newWithSink(specs, encs, func() sink { return &publisherSink{} }, wh)
 }
 
 // Check runs all validation that does not require opening sinks: structural
@@ -42,7 +50,7 @@ func Check(specs []Spec, encs Encoders) error {
 			return fmt.Errorf("unknown output format %q (known: %v)", s.Name, encs.Names())
 		}
 		if so, ok := enc.(StdoutOnlyEncoder); ok && so.StdoutOnly() && !s.IsStdout() {
-			return fmt.Errorf("output %q can only write to stdout (got path %q)", s.Name, s.Path)
+			return fmt.Errorf("output %q can only write to stdout (got %q)", s.Name, formatSpec(s))
 		}
 	}
 	return nil
@@ -50,7 +58,7 @@ func Check(specs []Spec, encs Encoders) error {
 
 // newWithSink is the shared construction path: the stdoutMaker is invoked once
 // per stdout-bound spec, freshly producing the sink to use for that spec.
-func newWithSink(specs []Spec, encs Encoders, stdoutMaker func() sink) (Writer, error) {
+func newWithSink(specs []Spec, encs Encoders, stdoutMaker func() sink, wh WebhookConfig) (Writer, error) {
 	if err := Check(specs, encs); err != nil {
 		return nil, err
 	}
@@ -58,9 +66,12 @@ func newWithSink(specs []Spec, encs Encoders, stdoutMaker func() sink) (Writer,
 	for _, s := range specs {
 		enc, _ := encs.Lookup(s.Name) // checked above
 		var sk sink
-		if s.IsStdout() {
+		switch {
+		case s.IsStdout():
 			sk = stdoutMaker()
-		} else {
+		case s.IsWebhook():
+			sk = newWebhookSink(s.Path, enc, wh)
+		default:
 			fs, err := newFileSink(s.Path)
 			if err != nil {
 				// abort sinks already opened so we don't leak temp files
@@ -101,7 +112,10 @@ func (w *multiWriter) Write(title string, d release.Description) error {
 }
 
 // Close commits each file sink (rename) on success, or aborts (remove temp)
-// if any prior Write failed. Stdout sinks are no-ops.
+// if any prior Write failed. Stdout sinks are no-ops. Webhook sinks are
+// committed last so a slow or failing endpoint never holds up (or prevents)
+// the local files from landing, and only once every other sink committed: a
+// webhook never announces a release whose files failed to land.
 func (w *multiWriter) Close() error {
 	if w.closed {
 		return nil
@@ -113,6 +127,25 @@ func (w *multiWriter) Close() error {
 	}
 	var errs []error
 	for _, p := range w.pairs {
+		if p.spec.IsWebhook() {
+			continue
+		}
+		if err := p.sk.Commit(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		for _, p := range w.pairs {
+			if p.spec.IsWebhook() {
+				_ = p.sk.Abort()
+			}
+		}
+		return errors.Join(errs...)
+	}
+	for _, p := range w.pairs {
+		if !p.spec.IsWebhook() {
+			continue
+		}
 		if err := p.sk.Commit(); err != nil {
 			errs = append(errs, err)
 		}
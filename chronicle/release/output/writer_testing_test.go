@@ -17,5 +17,5 @@ func (s *stdoutSink) Abort() error                { return nil }
 // writes directly to the supplied io.Writer rather than going through the bus
 // so tests can assert byte-exact content via a bytes.Buffer.
 func newWithStdout(specs []Spec, encs Encoders, stdout io.Writer) (Writer, error) {
-	return newWithSink(specs, encs, func() sink { return &stdoutSink{w: stdout} })
+	return newWithSink(specs, encs, func() sink { return &stdoutSink{w: stdout} }, DefaultWebhookConfig())
 }
@@ -0,0 +1,142 @@
+// Package releasenote reads the release note a pull request author wrote in
+// the PR body, in either of the shapes PR templates ask for it: a fenced block
+// (Kubernetes' ```release-note) or a section under a heading ("### Release
+// note").
+package releasenote
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Extractor finds a release note in a PR body. The zero value finds nothing.
+type Extractor struct {
+	// Fence is the info string of a fenced code block holding the note, e.g.
+	// "release-note"; "" skips fenced blocks.
+	Fence string
+	// Headings are the titles of a markdown section holding the note, matched
+	// case-insensitively; empty skips sections.
+	Headings []string
+}
+
+// Extract returns the release note the body declares, trying a fenced block
+// before a heading. ok is false when the body has no note, including a
+// template section left holding only its placeholder comment; the PR title
+// stands then. none reports that the author explicitly wrote NONE (or N/A):
+// the change isn't worth a changelog entry.
+func (e Extractor) Extract(body string) (note string, none, ok bool) {
+	lines := splitLines(body)
+	if e.Fence != "" {
+		if text, found := fencedBlock(lines, e.Fence); found {
+			if note, none, ok = classify(text); ok || none {
+				return note, none, ok
+			}
+		}
+	}
+	if len(e.Headings) > 0 {
+		if text, found := section(lines, e.Headings); found {
+			return classify(unfence(text))
+		}
+	}
+	return "", false, false
+}
+
+// Section returns the content under the first markdown heading whose text is
+// one of titles (case-insensitive, ignoring a trailing colon), up to the next
+// heading of the same or a higher level. Template comments are dropped, and a
+// section that only says NONE or N/A counts as empty.
+func Section(body string, titles ...string) string {
+	text, _ := section(splitLines(body), titles)
+	note, _, _ := classify(text)
+	return note
+}
+
+var (
+	headingPattern     = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	fencePattern       = regexp.MustCompile("^\\s{0,3}(`{3,}|~{3,})\\s*([^`\\s]*)")
+	htmlCommentPattern = regexp.MustCompile(`(?s)<!--.*?-->`)
+)
+
+func splitLines(body string) []string {
+	return strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+}
+
+// fencedBlock returns the content of the first fenced block whose info string
+// is fence. The closing fence must use the same character and be at least as
+// long, as CommonMark requires.
+func fencedBlock(lines []string, fence string) (string, bool) {
+	for i, line := range lines {
+		m := fencePattern.FindStringSubmatch(line)
+		if m == nil || !strings.EqualFold(m[2], fence) {
+			continue
+		}
+		open := m[1]
+		var out []string
+		for _, l := range lines[i+1:] {
+			if c := fencePattern.FindStringSubmatch(l); c != nil && c[2] == "" && c[1][0] == open[0] && len(c[1]) >= len(open) {
+				return strings.Join(out, "\n"), true
+			}
+			out = append(out, l)
+		}
+		// an unclosed block runs to the end of the body, as in CommonMark.
+		return strings.Join(out, "\n"), true
+	}
+	return "", false
+}
+
+func section(lines []string, titles []string) (string, bool) {
+	level := 0
+	var out []string
+	for _, line := range lines {
+		m := headingPattern.FindStringSubmatch(line)
+		if level == 0 {
+			if m != nil && matchesTitle(m[2], titles) {
+				level = len(m[1])
+			}
+			continue
+		}
+		if m != nil && len(m[1]) <= level {
+			break
+		}
+		out = append(out, line)
+	}
+	return strings.Join(out, "\n"), level > 0
+}
+
+func matchesTitle(heading string, titles []string) bool {
+	heading = strings.TrimSuffix(strings.TrimSpace(heading), ":")
+	for _, t := range titles {
+		if strings.EqualFold(heading, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// unfence unwraps a section whose whole content is one fenced block, which is
+// how templates keep a section's placeholder from rendering as prose.
+func unfence(text string) string {
+	lines := splitLines(strings.TrimSpace(htmlCommentPattern.ReplaceAllString(text, "")))
+	if len(lines) < 2 {
+		return text
+	}
+	first := fencePattern.FindStringSubmatch(lines[0])
+	last := fencePattern.FindStringSubmatch(lines[len(lines)-1])
+	if first == nil || last == nil || last[2] != "" || last[1][0] != first[1][0] {
+		return text
+	}
+	return strings.Join(lines[1:len(lines)-1], "\n")
+}
+
+// classify drops template comments and surrounding whitespace, and tells an
+// explicit NONE apart from a note nobody wrote.
+func classify(text string) (note string, none, ok bool) {
+	text = strings.TrimSpace(htmlCommentPattern.ReplaceAllString(text, ""))
+	switch strings.ToLower(strings.TrimSuffix(text, ".")) {
+	case "":
+		return "", false, false
+	case "none", "n/a", "na":
+		return "", true, false
+	}
+	return text, false, true
+}
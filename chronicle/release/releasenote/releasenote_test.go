@@ -0,0 +1,136 @@
+package releasenote
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractor_Extract(t *testing.T) {
+	both := Extractor{Fence: "release-note", Headings: []string{"release note", "release notes"}}
+
+	tests := []struct {
+		name      string
+		extractor Extractor
+		body      string
+		wantNote  string
+		wantNone  bool
+		wantOK    bool
+	}{
+		{
+			name:      "kubernetes style fenced block",
+			extractor: both,
+			body:      "#### What this PR does\nrefactors the parser\n\n#### Does this PR introduce a user-facing change?\n```release-note\nThe `--since` flag accepts commit hashes.\n```\n",
+			wantNote:  "The `--since` flag accepts commit hashes.",
+			wantOK:    true,
+		},
+		{
+			name:      "fenced NONE excludes the PR",
+			extractor: both,
+			body:      "```release-note\r\nNONE\r\n```",
+			wantNone:  true,
+		},
+		{
+			name:      "other fenced blocks are ignored",
+			extractor: both,
+			body:      "```go\nfmt.Println()\n```\n",
+			wantOK:    false,
+		},
+		{
+			name:      "longer closing fence and inner backticks",
+			extractor: both,
+			body:      "````release-note\nuse ```yaml blocks``` in config\n`````\nafter",
+			wantNote:  "use ```yaml blocks``` in config",
+			wantOK:    true,
+		},
+		{
+			name:      "heading section",
+			extractor: both,
+			body:      "## Summary\nterse title\n\n### Release note\n<!-- describe the change for users -->\nAdds Slack output.\n\n### Checklist\n- [x] tests",
+			wantNote:  "Adds Slack output.",
+			wantOK:    true,
+		},
+		{
+			name:      "heading section wrapping a plain fence",
+			extractor: both,
+			body:      "### Release notes:\n```\nn/a\n```\n",
+			wantNone:  true,
+		},
+		{
+			name:      "a template section nobody filled in is no note",
+			extractor: both,
+			body:      "## Summary\nterse title\n\n### Release note\n<!-- describe the change for users, or write NONE -->\n\n### Checklist\n- [x] tests",
+			wantOK:    false,
+		},
+		{
+			name:      "an empty fenced block falls back to the heading",
+			extractor: both,
+			body:      "```release-note\n<!-- your note -->\n```\n### Release note\nfrom the heading",
+			wantNote:  "from the heading",
+			wantOK:    true,
+		},
+		{
+			name:      "fence beats heading",
+			extractor: both,
+			body:      "### Release note\nfrom the heading\n```release-note\nfrom the fence\n```",
+			wantNote:  "from the fence",
+			wantOK:    true,
+		},
+		{
+			name:      "headings only",
+			extractor: Extractor{Headings: []string{"changelog"}},
+			body:      "```release-note\nfrom the fence\n```\n# Changelog\nfrom the heading",
+			wantNote:  "from the heading",
+			wantOK:    true,
+		},
+		{
+			name:      "nothing declared",
+			extractor: both,
+			body:      "just a description",
+			wantOK:    false,
+		},
+		{
+			name:      "zero value finds nothing",
+			extractor: Extractor{},
+			body:      "```release-note\nhello\n```",
+			wantOK:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			note, none, ok := tt.extractor.Extract(tt.body)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantNone, none)
+			assert.Equal(t, tt.wantNote, note)
+		})
+	}
+}
+
+func TestSection(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "runs to the next heading of the same level",
+			body: "### Release notes:\nfirst\n\n#### Details\nsecond\n### Testing\nthird",
+			want: "first\n\n#### Details\nsecond",
+		},
+		{
+			name: "none means no notes",
+			body: "## Release note\r\nNONE\r\n",
+			want: "",
+		},
+		{
+			name: "missing section",
+			body: "just a description",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Section(tt.body, "release notes", "release note"))
+		})
+	}
+}
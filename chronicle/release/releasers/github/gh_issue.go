@@ -11,6 +11,7 @@ import (
 	"golang.org/x/oauth2"
 
 	"github.com/anchore/chronicle/chronicle/event"
+	"github.com/anchore/chronicle/chronicle/release/change"
 	"github.com/anchore/chronicle/internal"
 	"github.com/anchore/chronicle/internal/log"
 )
@@ -26,6 +27,20 @@ type ghIssue struct {
 	URL        string
 }
 
+// typed converts the issue into the source-agnostic payload carried on a
+// change.Change.
+func (i ghIssue) typed() *change.Issue {
+	return &change.Issue{
+		Number:     i.Number,
+		Title:      i.Title,
+		Author:     i.Author,
+		URL:        i.URL,
+		ClosedAt:   i.ClosedAt,
+		Labels:     i.Labels,
+		NotPlanned: i.NotPlanned,
+	}
+}
+
 // issueFilter decides whether to keep an issue. When returning false, the optional
 // ctx pointer (if non-nil) is populated with a short reason identifier.
 type issueFilter func(issue ghIssue, ctx ...*string) bool
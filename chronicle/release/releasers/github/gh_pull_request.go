@@ -12,6 +12,7 @@ import (
 	"golang.org/x/oauth2"
 
 	"github.com/anchore/chronicle/chronicle/event"
+	"github.com/anchore/chronicle/chronicle/release/change"
 	"github.com/anchore/chronicle/internal"
 	"github.com/anchore/chronicle/internal/git"
 	"github.com/anchore/chronicle/internal/log"
@@ -26,6 +27,27 @@ type ghPullRequest struct {
 	URL          string
 	LinkedIssues []ghIssue
 	MergeCommit  string
+	Body         string
+}
+
+// typed converts the PR into the source-agnostic payload carried on a
+// change.Change.
+func (pr ghPullRequest) typed() *change.PullRequest {
+	var linked []int
+	for _, is := range pr.LinkedIssues {
+		linked = append(linked, is.Number)
+	}
+	return &change.PullRequest{
+		Number:       pr.Number,
+		Title:        pr.Title,
+		Author:       pr.Author,
+		URL:          pr.URL,
+		MergedAt:     pr.MergedAt,
+		Labels:       pr.Labels,
+		MergeCommit:  pr.MergeCommit,
+		Body:         pr.Body,
+		LinkedIssues: linked,
+	}
 }
 
 // prFilter decides whether to keep a PR. When returning false, the optional ctx
@@ -299,6 +321,19 @@ func keepPRsWithCommits(dropped []droppedPR, commits []string, filters ...prFilt
 	return results
 }
 
+// prsWithoutReleaseNoteNone drops PRs whose author declared the release note
+// as NONE: refactors, CI tweaks and the like that users needn't hear about.
+func prsWithoutReleaseNoteNone(config Config) prFilter {
+	return func(pr ghPullRequest, ctx ...*string) bool {
+		if _, none, _ := config.ReleaseNotes.Extract(pr.Body); none {
+			setReason(ctx, "release-note:none")
+			log.Tracef("PR #%d filtered out: release note is NONE", pr.Number)
+			return false
+		}
+		return true
+	}
+}
+
 // setReason writes the reason into the first element of the variadic ctx slice,
 // if provided and non-nil. This allows filter callers to retrieve the drop reason.
 func setReason(ctx []*string, reason string) {
@@ -344,6 +379,7 @@ func fetchMergedPRs(user, repo string, since *time.Time, leaf *event.Leaf) ([]gh
 							Title  githubv4.String
 							Number githubv4.Int
 							URL    githubv4.String
+							Body   githubv4.String
 							Author struct {
 								Login githubv4.String
 							}
@@ -442,6 +478,7 @@ func fetchMergedPRs(user, repo string, since *time.Time, leaf *event.Leaf) ([]gh
 					Number:       int(prEdge.Node.Number),
 					LinkedIssues: linkedIssues,
 					MergeCommit:  string(prEdge.Node.MergeCommit.OID),
+					Body:         string(prEdge.Node.Body),
 				})
 			}
 
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/anchore/chronicle/chronicle/release/change"
+	"github.com/anchore/chronicle/chronicle/release/releasenote"
 )
 
 func Test_prsAtOrAfter(t *testing.T) {
@@ -252,6 +253,72 @@ func Test_prsWithoutAuthor(t *testing.T) {
 	}
 }
 
+func Test_prsWithoutReleaseNoteNone(t *testing.T) {
+	k8s := Config{ReleaseNotes: releasenote.Extractor{Fence: "release-note", Headings: []string{"release note"}}}
+	tests := []struct {
+		name   string
+		config Config
+		pr     ghPullRequest
+		keep   bool
+		reason string
+	}{
+		{
+			name:   "fenced NONE",
+			config: k8s,
+			pr:     ghPullRequest{Body: "```release-note\nNONE\n```"},
+			keep:   false,
+			reason: "release-note:none",
+		},
+		{
+			name:   "heading NONE",
+			config: k8s,
+			pr:     ghPullRequest{Body: "### Release note\nnone\n"},
+			keep:   false,
+			reason: "release-note:none",
+		},
+		{
+			name:   "a note is kept",
+			config: k8s,
+			pr:     ghPullRequest{Body: "```release-note\nAdds things.\n```"},
+			keep:   true,
+		},
+		{
+			name:   "an unfilled template section is kept",
+			config: k8s,
+			pr:     ghPullRequest{Title: "terse", Body: "### Release note\n<!-- describe the change, or write NONE -->\n"},
+			keep:   true,
+		},
+		{
+			name:   "no note is kept",
+			config: k8s,
+			pr:     ghPullRequest{Body: "just a description"},
+			keep:   true,
+		},
+		{
+			name: "extraction disabled",
+			pr:   ghPullRequest{Body: "```release-note\nNONE\n```"},
+			keep: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var reason string
+			assert.Equal(t, test.keep, prsWithoutReleaseNoteNone(test.config)(test.pr, &reason))
+			assert.Equal(t, test.reason, reason)
+		})
+	}
+}
+
+func Test_prText(t *testing.T) {
+	k8s := Config{ReleaseNotes: releasenote.Extractor{Fence: "release-note"}}
+	body := "refactor internals\n\n```release-note\nThe `--since` flag now accepts\ncommit hashes.\n```\n"
+
+	assert.Equal(t, "The `--since` flag now accepts commit hashes.", prText(k8s, ghPullRequest{Title: "refactor", Body: body}))
+	assert.Equal(t, "refactor", prText(k8s, ghPullRequest{Title: "refactor", Body: "no note"}))
+	assert.Equal(t, "refactor", prText(k8s, ghPullRequest{Title: "refactor", Body: "```release-note\n<!-- your note -->\n```"}))
+	assert.Equal(t, "refactor", prText(Config{}, ghPullRequest{Title: "refactor", Body: body}))
+}
+
 func Test_prsWithoutClosedLinkedIssue(t *testing.T) {
 	tests := []struct {
 		name string
@@ -12,6 +12,7 @@ import (
 	"github.com/anchore/chronicle/chronicle/event"
 	"github.com/anchore/chronicle/chronicle/release"
 	"github.com/anchore/chronicle/chronicle/release/change"
+	"github.com/anchore/chronicle/chronicle/release/releasenote"
 	"github.com/anchore/chronicle/internal"
 	"github.com/anchore/chronicle/internal/git"
 	"github.com/anchore/chronicle/internal/log"
@@ -49,6 +50,11 @@ type Config struct {
 	// ChangeTypesByConventionalCommitType maps a conventional-commit prefix (e.g.
 	// "feat", "fix", or the "!" breaking marker) to a change type.
 	ChangeTypesByConventionalCommitType change.TypeSet
+
+	// ReleaseNotes finds the release note an author wrote in the PR body; when
+	// it finds one the note replaces the PR title, and a note of NONE drops the
+	// PR. The zero value leaves titles alone.
+	ReleaseNotes releasenote.Extractor
 }
 
 type Summarizer struct {
@@ -740,7 +746,7 @@ func createChangesFromPRs(config Config, prs []ghPullRequest) []change.Change {
 		}
 
 		summaries = append(summaries, change.Change{
-			Text:        pr.Title,
+			Text:        prText(config, pr),
 			ChangeTypes: changeTypes,
 			Timestamp:   pr.MergedAt,
 			References: []change.Reference{
@@ -753,13 +759,24 @@ func createChangesFromPRs(config Config, prs []ghPullRequest) []change.Change {
 					URL:  fmt.Sprintf("https://%s/%s", config.Host, pr.Author),
 				},
 			},
-			EntryType: "githubPR",
-			Entry:     pr,
+			EntryType:   "githubPR",
+			Entry:       pr,
+			PullRequest: pr.typed(),
 		})
 	}
 	return summaries
 }
 
+// prText is what a PR contributes to the changelog: its release note when the
+// body has one, else its title. A note spanning several lines is folded onto
+// one, since every output renders a change as a single entry.
+func prText(config Config, pr ghPullRequest) string {
+	if note, _, ok := config.ReleaseNotes.Extract(pr.Body); ok {
+		return strings.Join(strings.Fields(note), " ")
+	}
+	return pr.Title
+}
+
 func logPRs(prs []ghPullRequest) {
 	for idx, pr := range prs {
 		var branch = treeBranch
@@ -813,6 +830,7 @@ func changesFromUnlabeledPRs(config Config, allMergedPRs []ghPullRequest, sinceT
 		// standard (typed) PR path; exclude it here so it isn't counted twice.
 		prsWithoutChangeType(config),
 		prsWithoutAuthor(config.ExcludeAuthors...),
+		prsWithoutReleaseNoteNone(config),
 	}
 
 	filters = append(filters, standardChronologicalPrFilters(config, sinceTag, untilTag, includeCommits)...)
@@ -878,6 +896,7 @@ func createChangesFromIssues(config Config, allMergedPRs []ghPullRequest, issues
 			References:  references,
 			EntryType:   "githubIssue",
 			Entry:       issue,
+			Issue:       issue.typed(),
 		})
 	}
 	return changes
@@ -957,6 +976,7 @@ func standardQualitativePrFilters(config Config) []prFilter {
 		prsWithChangeTypes(config),
 		prsWithoutLabel(config.ExcludeLabels...),
 		prsWithoutAuthor(config.ExcludeAuthors...),
+		prsWithoutReleaseNoteNone(config),
 		// Merged PRs linked to closed issues should be hidden so that the closed issue title takes precedence over the pr title
 		prsWithoutClosedLinkedIssue(),
 		// Merged PRs with open issues indicates a partial implementation. When the last PR is merged for the issue
@@ -929,6 +929,7 @@ func Test_createChangesFromIssues(t *testing.T) {
 					},
 					EntryType: "githubIssue",
 					Entry:     issue1,
+					Issue:     issue1.typed(),
 				},
 				{
 					Text:        "Issue 2",
@@ -950,6 +951,7 @@ func Test_createChangesFromIssues(t *testing.T) {
 					},
 					EntryType: "githubIssue",
 					Entry:     issue2,
+					Issue:     issue2.typed(),
 				},
 				{
 					Text:        "Issue 3 no PRs",
@@ -963,6 +965,7 @@ func Test_createChangesFromIssues(t *testing.T) {
 					},
 					EntryType: "githubIssue",
 					Entry:     issue3,
+					Issue:     issue3.typed(),
 				},
 			},
 		},
@@ -1055,8 +1058,9 @@ func Test_changesFromUnlabeledPRs(t *testing.T) {
 							URL:  "https://some-host/some-author",
 						},
 					},
-					EntryType: "githubPR",
-					Entry:     prWithoutLabels,
+					EntryType:   "githubPR",
+					Entry:       prWithoutLabels,
+					PullRequest: prWithoutLabels.typed(),
 				},
 				{
 					Text:        "pr without labels 2",
@@ -1072,8 +1076,9 @@ func Test_changesFromUnlabeledPRs(t *testing.T) {
 							URL:  "https://some-host/some-author-2",
 						},
 					},
-					EntryType: "githubPR",
-					Entry:     prWithoutLabels2,
+					EntryType:   "githubPR",
+					Entry:       prWithoutLabels2,
+					PullRequest: prWithoutLabels2.typed(),
 				},
 			},
 		},
@@ -1116,8 +1121,9 @@ func Test_changesFromUnlabeledPRs(t *testing.T) {
 							URL:  "https://some-host/some-author",
 						},
 					},
-					EntryType: "githubPR",
-					Entry:     prWithoutLabels,
+					EntryType:   "githubPR",
+					Entry:       prWithoutLabels,
+					PullRequest: prWithoutLabels.typed(),
 				},
 			},
 		},
@@ -1235,6 +1241,7 @@ func Test_changesFromUnlabeledIssues(t *testing.T) {
 					},
 					EntryType: "githubIssue",
 					Entry:     issueWithoutLabels,
+					Issue:     issueWithoutLabels.typed(),
 				},
 				{
 					Text:        "issue without labels 2",
@@ -1248,6 +1255,7 @@ func Test_changesFromUnlabeledIssues(t *testing.T) {
 					},
 					EntryType: "githubIssue",
 					Entry:     issueWithoutLabels2,
+					Issue:     issueWithoutLabels2.typed(),
 				},
 			},
 		},
@@ -147,6 +147,7 @@ func buildTrunkPRMap(config Config, allMergedPRs []ghPullRequest, commitHashSet
 			URL:      pr.URL,
 			Author:   pr.Author,
 			Labels:   pr.Labels,
+			Body:     pr.Body,
 			Filtered: true,
 			Reason:   explainPRNotKept(pr, config, sinceTag, untilTag),
 		}
@@ -181,6 +182,7 @@ func buildKeptTrunkPR(config Config, pr ghPullRequest, keptForCommit []change.Ch
 		URL:         pr.URL,
 		Author:      pr.Author,
 		Labels:      pr.Labels,
+		Body:        pr.Body,
 		ChangeTypes: changeTypes,
 		Issues:      buildKeptTrunkIssues(config, pr.LinkedIssues, keptIssueURLs, sinceTag, untilTag),
 		Filtered:    false,
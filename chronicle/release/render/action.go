@@ -31,3 +31,49 @@ func ChangesOfKind(changes []dependency.PackageChange, kind dependency.ChangeKin
 	}
 	return subset
 }
+
+// Block is one group of changes an encoder renders under a single header: all
+// of a kind's changes, or — when the config splits transitive changes out — the
+// direct or the transitive share of them.
+type Block struct {
+	Label   string // e.g. "Updated", or "Updated, transitive" for a split-out block
+	Mode    Mode   // resolved for the encoder; never ModeHide
+	Changes []dependency.PackageChange
+}
+
+// Blocks groups one ecosystem's changes into the blocks to render, in
+// ActionOrder, resolving each block's display mode for the encoder. Hidden and
+// empty blocks are left out.
+func (c *Config) Blocks(changes []dependency.PackageChange, supportsCollapsed bool) []Block {
+	var out []Block
+	add := func(label string, mode Mode, subset []dependency.PackageChange) {
+		if mode == ModeHide || len(subset) == 0 {
+			return
+		}
+		out = append(out, Block{Label: label, Mode: mode, Changes: subset})
+	}
+	for _, a := range ActionOrder {
+		subset := ChangesOfKind(changes, a.Kind)
+		if !c.SplitsTransitive(a.Kind) {
+			add(a.Label, c.ResolveDisplay(a.Kind, supportsCollapsed), subset)
+			continue
+		}
+		direct, transitive := SplitTransitive(subset)
+		add(a.Label, c.ResolveDisplay(a.Kind, supportsCollapsed), direct)
+		add(a.Label+", transitive", c.ResolveTransitiveDisplay(a.Kind, supportsCollapsed), transitive)
+	}
+	return out
+}
+
+// SplitTransitive partitions changes into the transitive ones and the rest
+// (direct or unclassified), preserving the incoming order.
+func SplitTransitive(changes []dependency.PackageChange) (direct, transitive []dependency.PackageChange) {
+	for _, c := range changes {
+		if c.Relationship == dependency.Transitive {
+			transitive = append(transitive, c)
+		} else {
+			direct = append(direct, c)
+		}
+	}
+	return direct, transitive
+}
@@ -38,6 +38,15 @@ const (
 type Config struct {
 	Actions map[dependency.ChangeKind][]Mode
 
+	// Transitive optionally splits each kind's transitive changes into a block
+	// of their own with its own modes — typically listing the direct updates
+	// someone chose to make while reducing the dozens of transitive bumps they
+	// pulled in to a count. A kind with no entry keeps its direct and transitive
+	// changes together under Actions. Changes the scanner could not classify
+	// stay with the direct ones, so nothing is hidden by a missing
+	// classification.
+	Transitive map[dependency.ChangeKind][]Mode
+
 	// OnlyVulnerable enumerates only the changes that remediated or introduced a
 	// vulnerability, while the summary still reports the full per-kind totals.
 	// The filtering happens at render time (VisibleChanges) so the underlying
@@ -90,7 +99,27 @@ func (c *Config) ModesFor(kind dependency.ChangeKind) []Mode {
 // an empty header in only-vulnerable output (e.g. md-pretty, which can't
 // collapse and so falls through to summary).
 func (c *Config) ResolveDisplay(kind dependency.ChangeKind, supportsCollapsed bool) Mode {
-	for _, m := range c.ModesFor(kind) {
+	return c.resolve(c.ModesFor(kind), supportsCollapsed)
+}
+
+// SplitsTransitive reports whether a kind's transitive changes render as their
+// own block (see Transitive). Nil-safe.
+func (c *Config) SplitsTransitive(kind dependency.ChangeKind) bool {
+	return c != nil && len(c.Transitive[kind]) > 0
+}
+
+// ResolveTransitiveDisplay is ResolveDisplay for the transitive block of a kind
+// that SplitsTransitive.
+func (c *Config) ResolveTransitiveDisplay(kind dependency.ChangeKind, supportsCollapsed bool) Mode {
+	var modes []Mode
+	if c != nil {
+		modes = c.Transitive[kind]
+	}
+	return c.resolve(modes, supportsCollapsed)
+}
+
+func (c *Config) resolve(modes []Mode, supportsCollapsed bool) Mode {
+	for _, m := range modes {
 		if m == ModeCollapsed && !supportsCollapsed {
 			continue // unsupported here — try the next fallback mode
 		}
@@ -88,6 +88,54 @@ func TestConfig_ResolveDisplay_DefaultsWhenEmpty(t *testing.T) {
 	require.Equal(t, ModeList, rc.ResolveDisplay(dependency.Added, false))
 }
 
+func TestConfig_Blocks(t *testing.T) {
+	changes := []dependency.PackageChange{
+		{Name: "cobra", Kind: dependency.Updated, Relationship: dependency.Direct},
+		{Name: "x/sys", Kind: dependency.Updated, Relationship: dependency.Transitive},
+		{Name: "x/net", Kind: dependency.Updated, Relationship: dependency.Transitive},
+		{Name: "unclassified", Kind: dependency.Updated},
+		{Name: "x/text", Kind: dependency.Added, Relationship: dependency.Transitive},
+		{Name: "old", Kind: dependency.Removed, Relationship: dependency.Transitive},
+	}
+	type block struct {
+		label string
+		mode  Mode
+		names []string
+	}
+	flatten := func(blocks []Block) []block {
+		var out []block
+		for _, b := range blocks {
+			bl := block{label: b.Label, mode: b.Mode}
+			for _, c := range b.Changes {
+				bl.names = append(bl.names, c.Name)
+			}
+			out = append(out, bl)
+		}
+		return out
+	}
+
+	// without a transitive entry a kind renders as one block, as before.
+	require.Equal(t, []block{
+		{label: "Updated", mode: ModeList, names: []string{"cobra", "x/sys", "x/net", "unclassified"}},
+		{label: "Added", mode: ModeList, names: []string{"x/text"}},
+		{label: "Removed", mode: ModeList, names: []string{"old"}},
+	}, flatten((&Config{}).Blocks(changes, false)))
+
+	// a transitive entry splits that kind; unclassified changes stay with the
+	// direct ones, and a hidden transitive block leaves the kind's direct block
+	// alone (here, empty).
+	rc := &Config{Transitive: map[dependency.ChangeKind][]Mode{
+		dependency.Updated: {ModeCollapsed, ModeSummary},
+		dependency.Added:   {ModeHide},
+	}}
+	require.Equal(t, []block{
+		{label: "Updated", mode: ModeCollapsed, names: []string{"cobra", "unclassified"}},
+		{label: "Updated, transitive", mode: ModeCollapsed, names: []string{"x/sys", "x/net"}},
+		{label: "Removed", mode: ModeCollapsed, names: []string{"old"}},
+	}, flatten(rc.Blocks(changes, true)))
+	require.Equal(t, ModeSummary, rc.Blocks(changes, false)[1].Mode)
+}
+
 func TestConfig_PackageCountLabel(t *testing.T) {
 	plain := &Config{}
 	require.Equal(t, "11 packages", plain.PackageCountLabel(11))
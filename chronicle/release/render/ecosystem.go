@@ -43,7 +43,8 @@ func ecosystemTitle(c dependency.PackageChange) string {
 }
 
 // EcosystemGroup is a set of changes that share an ecosystem title, used by
-// encoders to render per-ecosystem subsections.
+// encoders to render per-ecosystem subsections. GroupChanges reuses it for
+// workspace members, titling each group after its member.
 type EcosystemGroup struct {
 	Title   string
 	Changes []dependency.PackageChange
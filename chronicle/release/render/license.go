@@ -0,0 +1,41 @@
+package render
+
+import (
+	"strings"
+
+	"github.com/anchore/chronicle/chronicle/dependency"
+)
+
+// LicenseChanges returns the changes whose package moved to a different set of
+// licenses, in the diff's order. It reads every change rather than the visible
+// ones: a license transition is worth surfacing to legal even on a package the
+// list below hides.
+func LicenseChanges(d dependency.Diff) []dependency.PackageChange {
+	var out []dependency.PackageChange
+	for _, c := range d.Changes {
+		if c.LicenseChanged() {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// LicenseTransitionWith renders a change's license movement as "from → to",
+// each side a comma-separated list with every license rendered through code
+// (like VersionTransitionWith, a nil code yields bare text).
+func LicenseTransitionWith(c dependency.PackageChange, code func(string) string) string {
+	return LicenseListWith(c.FromLicenses, code) + " → " + LicenseListWith(c.ToLicenses, code)
+}
+
+// LicenseListWith renders licenses as a comma-separated list, each rendered
+// through code. Returns "" for no licenses.
+func LicenseListWith(licenses []string, code func(string) string) string {
+	out := make([]string, len(licenses))
+	for i, l := range licenses {
+		if code != nil {
+			l = code(l)
+		}
+		out[i] = l
+	}
+	return strings.Join(out, ", ")
+}
@@ -0,0 +1,63 @@
+package render
+
+import (
+	"sort"
+
+	"github.com/anchore/chronicle/chronicle/dependency"
+)
+
+// rootMemberTitle labels the changes made at the repository root of a
+// workspace, where its root manifests (and usually the shared lockfile) live.
+const rootMemberTitle = "root"
+
+// GroupChanges buckets changes into the subsections encoders render. Changes
+// from a workspace spanning more than one member are grouped per member (the
+// root first, then by path), each split further by ecosystem when the changes
+// cover more than one, titled "member (Ecosystem)". Everything else groups by
+// ecosystem alone, exactly as GroupByEcosystem does.
+func GroupChanges(changes []dependency.PackageChange) []EcosystemGroup {
+	byMember := make(map[string][]dependency.PackageChange)
+	for _, c := range changes {
+		byMember[c.Member] = append(byMember[c.Member], c)
+	}
+	if len(byMember) < 2 {
+		return GroupByEcosystem(changes)
+	}
+
+	members := make([]string, 0, len(byMember))
+	for m := range byMember {
+		members = append(members, m)
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return memberRank(members[i]) < memberRank(members[j])
+	})
+
+	multiEcosystem := len(GroupByEcosystem(changes)) > 1
+	var groups []EcosystemGroup
+	for _, m := range members {
+		title := memberTitle(m)
+		if !multiEcosystem {
+			groups = append(groups, EcosystemGroup{Title: title, Changes: byMember[m]})
+			continue
+		}
+		for _, g := range GroupByEcosystem(byMember[m]) {
+			groups = append(groups, EcosystemGroup{Title: title + " (" + g.Title + ")", Changes: g.Changes})
+		}
+	}
+	return groups
+}
+
+func memberTitle(m string) string {
+	if m == "." || m == "" {
+		return rootMemberTitle
+	}
+	return m
+}
+
+// memberRank sorts the root ahead of every member directory.
+func memberRank(m string) string {
+	if m == "." || m == "" {
+		return ""
+	}
+	return "/" + m
+}
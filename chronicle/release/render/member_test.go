@@ -0,0 +1,58 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/chronicle/chronicle/dependency"
+)
+
+func TestGroupChanges(t *testing.T) {
+	titles := func(groups []EcosystemGroup) []string {
+		var out []string
+		for _, g := range groups {
+			out = append(out, g.Title)
+		}
+		return out
+	}
+
+	goMod := func(name, member string) dependency.PackageChange {
+		return dependency.PackageChange{Name: name, Type: "go-module", Member: member}
+	}
+	npm := func(name, member string) dependency.PackageChange {
+		return dependency.PackageChange{Name: name, Type: "npm", Member: member}
+	}
+
+	tests := []struct {
+		name    string
+		changes []dependency.PackageChange
+		want    []string
+	}{
+		{
+			name:    "no workspace groups by ecosystem",
+			changes: []dependency.PackageChange{npm("a", ""), goMod("b", "")},
+			want:    []string{"Go", "JavaScript"},
+		},
+		{
+			name:    "a single member groups by ecosystem",
+			changes: []dependency.PackageChange{npm("a", "web"), goMod("b", "web")},
+			want:    []string{"Go", "JavaScript"},
+		},
+		{
+			name:    "members of one ecosystem",
+			changes: []dependency.PackageChange{goMod("a", "tools"), goMod("b", "."), goMod("c", "api")},
+			want:    []string{"root", "api", "tools"},
+		},
+		{
+			name:    "members across ecosystems",
+			changes: []dependency.PackageChange{npm("a", "."), goMod("b", "api"), goMod("c", ".")},
+			want:    []string{"root (Go)", "root (JavaScript)", "api (Go)"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, titles(GroupChanges(tt.changes)))
+		})
+	}
+}
@@ -11,7 +11,7 @@ import (
 // full per-kind change totals — whole even when only a vulnerable subset is
 // enumerated below (VisibleChanges filters at render time without touching the
 // diff) — followed by the vulnerability remediated/introduced counts when
-// present.
+// present, and how many vulnerabilities VEX statements kept out of them.
 func SummaryLine(d dependency.Diff) string {
 	t := d.Totals
 
@@ -30,6 +30,9 @@ func SummaryLine(d dependency.Diff) string {
 	if v := vulnSentence(d.RemediatedCount, d.IntroducedCount); v != "" {
 		sb.WriteString(" " + v)
 	}
+	if d.SuppressedCount > 0 {
+		fmt.Fprintf(&sb, " %s suppressed by VEX statements.", pluralVulns(d.SuppressedCount))
+	}
 	return sb.String()
 }
 
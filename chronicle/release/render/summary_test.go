@@ -24,9 +24,10 @@ func TestSummaryLine(t *testing.T) {
 	}
 
 	tests := []struct {
-		name    string
-		changes []dependency.PackageChange
-		want    string
+		name       string
+		changes    []dependency.PackageChange
+		suppressed int
+		want       string
 	}{
 		{
 			name: "breakdown derived from changes",
@@ -48,11 +49,18 @@ func TestSummaryLine(t *testing.T) {
 			},
 			want: "2 dependency changes (1 updated, 1 downgraded). 3 vulnerabilities remediated and 1 vulnerability introduced.",
 		},
+		{
+			name:       "with vex suppressions",
+			changes:    []dependency.PackageChange{vuln(dependency.Updated, []string{"CVE-1"}, nil)},
+			suppressed: 2,
+			want:       "1 dependency change (1 updated). 1 vulnerability remediated. 2 vulnerabilities suppressed by VEX statements.",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			d := dependency.NewDiff(tt.changes)
+			d.SuppressedCount = tt.suppressed
 			if got := SummaryLine(d); got != tt.want {
 				t.Errorf("SummaryLine() =\n  %q\nwant\n  %q", got, tt.want)
 			}
@@ -1,6 +1,7 @@
 package render
 
 import (
+	"fmt"
 	"regexp"
 	"sort"
 	"strings"
@@ -55,6 +56,26 @@ func VersionTransitionWith(c dependency.PackageChange, code func(string) string)
 	return ""
 }
 
+// PRLinker renders a single pull request reference. Encoders supply one to wrap
+// "#N" in their format's link syntax (using pr.URL); a nil linker yields the
+// bare "#N".
+type PRLinker func(pr dependency.PullRequest) string
+
+// PullRequestsWith renders the pull requests a change is attributed to as a
+// comma-joined list in merge order, each through link, or "" when there are
+// none. Encoders place it next to the version transition.
+func PullRequestsWith(c dependency.PackageChange, link PRLinker) string {
+	refs := make([]string, len(c.PullRequests))
+	for i, pr := range c.PullRequests {
+		if link != nil {
+			refs[i] = link(pr)
+		} else {
+			refs[i] = fmt.Sprintf("#%d", pr.Number)
+		}
+	}
+	return strings.Join(refs, ", ")
+}
+
 // VulnLinker renders a single vulnerability as display text. Encoders supply
 // one to wrap the ID in their format's link syntax (using v.DataSource); a nil
 // linker yields the bare ID.
@@ -65,6 +86,7 @@ type VulnLinker func(v dependency.Vulnerability) string
 // (re)introduced IDs. Returns "" when the change has no vulnerability impact (or
 // was not annotated). Each vulnerability ID is rendered through link, so encoders
 // can hyperlink CVE/GHSA IDs to their data source; a nil link yields bare IDs.
+// IDs carry their VulnMarkers, and introduced ones the versions that fix them.
 func VulnNoteWith(c dependency.PackageChange, link VulnLinker) string {
 	if c.Vuln == nil {
 		return ""
@@ -72,21 +94,22 @@ func VulnNoteWith(c dependency.PackageChange, link VulnLinker) string {
 	var parts []string
 	if len(c.Vuln.Remediated) > 0 {
 		// 🟢 = remediated (good), 🔴 = (re)introduced (bad).
-		parts = append(parts, "🟢 remediated "+joinVulnIDs(c.Vuln.Remediated, link))
+		parts = append(parts, "🟢 remediated "+joinVulnIDs(c.Vuln.Remediated, link, false))
 	}
 	switch {
 	case c.Kind == dependency.Downgraded && len(c.Vuln.Introduced) > 0:
-		parts = append(parts, "🔴 reintroduces "+joinVulnIDs(c.Vuln.Introduced, link))
+		parts = append(parts, "🔴 reintroduces "+joinVulnIDs(c.Vuln.Introduced, link, true))
 	case len(c.Vuln.Introduced) > 0:
-		parts = append(parts, "🔴 introduces "+joinVulnIDs(c.Vuln.Introduced, link))
+		parts = append(parts, "🔴 introduces "+joinVulnIDs(c.Vuln.Introduced, link, true))
 	}
 	return strings.Join(parts, "; ")
 }
 
 // joinVulnIDs returns the vulnerabilities sorted by ID and comma-joined, each
-// rendered through link when non-nil (else the bare ID). Sorting is by ID so
-// ordering stays stable regardless of how the linker wraps the text.
-func joinVulnIDs(vulns []dependency.Vulnerability, link VulnLinker) string {
+// rendered through link when non-nil (else the bare ID) and followed by its
+// markers. Sorting is by ID so ordering stays stable regardless of how the
+// linker wraps the text.
+func joinVulnIDs(vulns []dependency.Vulnerability, link VulnLinker, withFix bool) string {
 	sorted := make([]dependency.Vulnerability, len(vulns))
 	copy(sorted, vulns)
 	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
@@ -98,6 +121,7 @@ func joinVulnIDs(vulns []dependency.Vulnerability, link VulnLinker) string {
 		} else {
 			ids[i] = v.ID
 		}
+		ids[i] = WithMarkers(ids[i], v, withFix)
 	}
 	return strings.Join(ids, ", ")
 }
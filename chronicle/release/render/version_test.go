@@ -59,3 +59,22 @@ func TestVulnNote(t *testing.T) {
 	require.Equal(t, "🟢 remediated CVE-1; 🔴 introduces CVE-9",
 		VulnNoteWith(dependency.PackageChange{Kind: dependency.Updated, Vuln: &dependency.VulnDelta{Remediated: []dependency.Vulnerability{{ID: "CVE-1"}}, Introduced: []dependency.Vulnerability{{ID: "CVE-9"}}}}, nil))
 }
+
+func TestVulnNote_Markers(t *testing.T) {
+	kev := dependency.Vulnerability{ID: "CVE-1", KnownExploited: true, EPSS: 0.943, FixedIn: []string{"1.2.4"}}
+	quiet := dependency.Vulnerability{ID: "CVE-2", EPSS: 0.004}
+
+	// remediated vulns don't need a fix pointer; still-present ones do.
+	require.Equal(t, "🟢 remediated CVE-1 [KEV, EPSS 94%], CVE-2",
+		VulnNoteWith(dependency.PackageChange{Kind: dependency.Updated, Vuln: &dependency.VulnDelta{Remediated: []dependency.Vulnerability{kev, quiet}}}, nil))
+	require.Equal(t, "🔴 introduces CVE-1 [KEV, EPSS 94%, fixed in 1.2.4]",
+		VulnNoteWith(dependency.PackageChange{Kind: dependency.Added, Vuln: &dependency.VulnDelta{Introduced: []dependency.Vulnerability{kev}}}, nil))
+}
+
+func TestVulnMarkers(t *testing.T) {
+	require.Empty(t, VulnMarkers(dependency.Vulnerability{ID: "CVE-1"}, true))
+	require.Equal(t, []string{"EPSS 1%"}, VulnMarkers(dependency.Vulnerability{EPSS: 0.01}, true))
+	require.Empty(t, VulnMarkers(dependency.Vulnerability{EPSS: 0.0099}, true), "scores under 1% are noise")
+	require.Equal(t, []string{"KEV"}, VulnMarkers(dependency.Vulnerability{KnownExploited: true, FixedIn: []string{"2.0.0"}}, false))
+	require.Equal(t, []string{"fixed in 1.0.1, 2.0.3"}, VulnMarkers(dependency.Vulnerability{FixedIn: []string{"1.0.1", "2.0.3"}}, true))
+}
@@ -1,7 +1,9 @@
 package render
 
 import (
+	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/anchore/chronicle/chronicle/dependency"
 )
@@ -11,10 +13,52 @@ import (
 // section. Packages are the affected package names (sorted, deduped) — the
 // vuln-centric inverse of the per-package annotations shown further down.
 type VulnListing struct {
-	ID         string
-	Severity   string
-	DataSource string // grype's primary reference URL; "" if none
-	Packages   []string
+	ID             string
+	Severity       string
+	DataSource     string // grype's primary reference URL; "" if none
+	KnownExploited bool
+	EPSS           float64
+	Packages       []string
+}
+
+// Vulnerability returns the listing as the vulnerability it aggregates, for
+// rendering through a VulnLinker or VulnMarkers. Per-package data (the fixed-in
+// versions) is not carried.
+func (l VulnListing) Vulnerability() dependency.Vulnerability {
+	return dependency.Vulnerability{ID: l.ID, Severity: l.Severity, DataSource: l.DataSource, KnownExploited: l.KnownExploited, EPSS: l.EPSS}
+}
+
+// epssNotable is the EPSS score from which VulnMarkers shows it. Most
+// vulnerabilities score well under 1%, and printing that on every ID would
+// bury the few that matter.
+const epssNotable = 0.01
+
+// VulnMarkers returns the prioritization markers for a vulnerability, in the
+// order they matter: a KEV badge when CISA lists it as known exploited, its
+// EPSS score when notable, and — when withFix is set, for a vulnerability the
+// release still carries — the versions that fix it. Empty when there is nothing
+// to call out.
+func VulnMarkers(v dependency.Vulnerability, withFix bool) []string {
+	var out []string
+	if v.KnownExploited {
+		out = append(out, "KEV")
+	}
+	if v.EPSS >= epssNotable {
+		out = append(out, fmt.Sprintf("EPSS %.0f%%", v.EPSS*100))
+	}
+	if withFix && len(v.FixedIn) > 0 {
+		out = append(out, "fixed in "+strings.Join(v.FixedIn, ", "))
+	}
+	return out
+}
+
+// WithMarkers appends v's markers to text (usually its rendered ID) in
+// brackets, e.g. "CVE-2024-3094 [KEV, EPSS 85%]".
+func WithMarkers(text string, v dependency.Vulnerability, withFix bool) string {
+	if m := VulnMarkers(v, withFix); len(m) > 0 {
+		return text + " [" + strings.Join(m, ", ") + "]"
+	}
+	return text
 }
 
 // RemediatedVulns returns every remediated vulnerability in the diff as a flat,
@@ -80,7 +124,7 @@ func newVulnAccumulator() *vulnAccumulator {
 
 func (a *vulnAccumulator) add(v dependency.Vulnerability, pkg string) {
 	if _, ok := a.byID[v.ID]; !ok {
-		a.byID[v.ID] = &VulnListing{ID: v.ID, Severity: v.Severity, DataSource: v.DataSource}
+		a.byID[v.ID] = &VulnListing{ID: v.ID, Severity: v.Severity, DataSource: v.DataSource, KnownExploited: v.KnownExploited, EPSS: v.EPSS}
 		a.pkgs[v.ID] = make(map[string]struct{})
 	}
 	a.pkgs[v.ID][pkg] = struct{}{}
@@ -3,6 +3,7 @@ package release
 import (
 	"time"
 
+	"github.com/anchore/chronicle/chronicle/dependency"
 	"github.com/anchore/chronicle/chronicle/release/change"
 )
 
@@ -18,6 +19,9 @@ type TrunkCommit struct {
 	Author    string
 	Timestamp time.Time
 	PR        *TrunkPR // nil when no merge PR maps to this commit
+	// Dependencies are the package changes this commit's manifest and lockfile
+	// edits made, when the dependency timeline is enabled; nil otherwise.
+	Dependencies []dependency.PackageChange
 }
 
 type TrunkPR struct {
@@ -26,6 +30,7 @@ type TrunkPR struct {
 	URL         string
 	Author      string
 	Labels      []string
+	Body        string // the PR description, e.g. for a notice written in it; not rendered
 	ChangeTypes []change.Type
 	Issues      []TrunkIssue
 	Filtered    bool
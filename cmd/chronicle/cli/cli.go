@@ -14,9 +14,10 @@ import (
 
 func New(id clio.Identification) clio.Application {
 	clioCfg := clio.NewSetupConfig(id).
-		WithGlobalConfigFlag().   // add persistent -c <path> for reading an application config from
-		WithGlobalLoggingFlags(). // add persistent -v and -q flags tied to the logging config
-		WithConfigInRootHelp().   // --help on the root command renders the full application config in the help text
+		WithGlobalConfigFlag().             // add persistent -c <path> for reading an application config from
+		WithGlobalLoggingFlags().           // add persistent -v and -q flags tied to the logging config
+		WithConfigInRootHelp().             // --help on the root command renders the full application config in the help text
+		WithMapExitCode(commands.ExitCode). // a release the vulnerability gate rejects (or can't judge) exits with its own code
 		WithUIConstructor(
 			// select a UI based on the logging configuration and state of stdin (if stdin is a tty)
 			func(cfg clio.Config) (*clio.UICollection, error) {
@@ -55,6 +56,8 @@ func New(id clio.Identification) clio.Application {
 	root.AddCommand(
 		create,
 		commands.NextVersion(app),
+		commands.Render(app),
+		commands.Schema(),
 		clio.VersionCommand(id),
 		clio.ConfigCommand(app, nil),
 	)
@@ -13,6 +13,8 @@ import (
 	"github.com/anchore/chronicle/chronicle/event"
 	"github.com/anchore/chronicle/chronicle/release"
 	"github.com/anchore/chronicle/chronicle/release/change"
+	vexenc "github.com/anchore/chronicle/chronicle/release/output/encoders/vex"
+	"github.com/anchore/chronicle/cmd/chronicle/cli/options"
 	"github.com/anchore/chronicle/internal/bus"
 	"github.com/anchore/chronicle/internal/git"
 	"github.com/anchore/chronicle/internal/log"
@@ -97,10 +99,42 @@ func runCreate(ctx context.Context, appConfig *createConfig) error {
 		}
 	}
 
+	if err := appConfig.Dependencies.Check(); err != nil {
+		return err
+	}
+
+	if err := appConfig.SignedTags.Check(); err != nil {
+		return err
+	}
+
+	if err := appConfig.Notice.Check(); err != nil {
+		return err
+	}
+
 	// vulnerability annotation operates on the dependency diff, so it has
-	// nothing to act on without an ecosystem (syft cataloger selector) to scan.
+	// nothing to act on without an ecosystem (syft cataloger selector) to scan
+	// or an SBOM to read.
 	if appConfig.Dependencies.AnnotateVulnerabilities && !appConfig.Dependencies.Enabled() {
-		return errors.New("--vulnerabilities requires at least one dependency ecosystem to scan; set --dependencies (e.g. --dependencies language)")
+		return errors.New("--vulnerabilities requires at least one dependency ecosystem to scan; set --dependencies (e.g. --dependencies language) --sbom-input-template, or --image-template")
+	}
+
+	// the SBOMs are the dependency scan's own catalogs; without an ecosystem to
+	// scan there is nothing to write.
+	if appConfig.Dependencies.SBOM.Requested() {
+		if !appConfig.Dependencies.Enabled() {
+			return errors.New("--sbom-since/--sbom-until require at least one dependency ecosystem to scan; set --dependencies (e.g. --dependencies language) --sbom-input-template, or --image-template")
+		}
+		if err := appConfig.Dependencies.SBOM.Check(); err != nil {
+			return err
+		}
+	}
+
+	// a vex document is a statement about vulnerabilities; without matching it
+	// would be empty, which reads as "nothing affects this release".
+	if !appConfig.Dependencies.AnnotateVulnerabilities {
+		if name, ok := requestsVEX(&appConfig.Output); ok {
+			return fmt.Errorf("-o %s requires vulnerability annotation; set --vulnerabilities", name)
+		}
 	}
 
 	// fail loudly on a misspelled min-severity rather than silently treating it
@@ -108,6 +142,12 @@ func runCreate(ctx context.Context, appConfig *createConfig) error {
 	if !dependency.ValidSeverity(appConfig.Dependencies.MinSeverity) {
 		return fmt.Errorf("invalid dependencies.min-severity %q; valid values: negligible, low, medium, high, critical", appConfig.Dependencies.MinSeverity)
 	}
+	if e := appConfig.Dependencies.MinEPSS; e < 0 || e > 1 {
+		return fmt.Errorf("invalid dependencies.min-epss %v; EPSS scores are probabilities between 0 and 1", e)
+	}
+	if err := checkFailOn(appConfig.Dependencies); err != nil {
+		return err
+	}
 
 	startRelease, description, err := selectWorker(appConfig.RepoPath)(ctx, appConfig)
 	if err != nil {
@@ -133,24 +173,150 @@ func runCreate(ctx context.Context, appConfig *createConfig) error {
 	// re-parsed (rather than tracked through the writer) because the writer
 	// abstraction does not surface its specs and we don't want to break that
 	// boundary just for a status line.
-	notifyFileSinks(appConfig, description)
+	notifyFileSinks(&appConfig.Output, description)
+
+	// the gate is evaluated before the recap is published so the recap can list
+	// what tripped it.
+	var offending []dependency.PackageChange
+	if description.DependencyDiff != nil {
+		offending = description.DependencyDiff.GateViolations
+	}
 
 	// publish the raw figures for the post-teardown recap block. The UI renders
 	// it; NextVersion empty means speculation was off and the UI omits the
 	// version-transition line.
-	bus.PublishSummary(summaryEvent(startRelease, description, appConfig.SpeculateNextVersion))
+	summary := summaryEvent(startRelease, description, appConfig.SpeculateNextVersion)
+	summary.GateViolations = gateViolations(offending)
+	bus.PublishSummary(summary)
+
+	// the policy gates fail the run only once the changelog is out, so the
+	// changes that failed it are there to review.
+	return errors.Join(
+		checkLicensePolicy(appConfig.Dependencies.Licenses, description.DependencyDiff),
+		checkVulnGate(appConfig.Dependencies.FailOn.Gate(), description.DependencyDiff),
+	)
+}
+
+// ExitVulnGate is the exit code of a run whose changelog was written but whose
+// release introduces a vulnerability the fail-on policy forbids. It is distinct
+// from the 1 of any other failure so a pipeline can tell a policy decision from
+// a broken run, and matches grype's exit code for its own fail-on threshold.
+const ExitVulnGate = 2
 
+// ExitVulnGateUnevaluated is the exit code of a run whose changelog was
+// written but whose fail-on policy could not be judged, because the dependency
+// diff is missing or wasn't matched against a vulnerability DB. A gate that
+// can't look fails closed, and with its own code, so a pipeline can tell "no
+// verdict" from both a rejected release and a broken run.
+const ExitVulnGateUnevaluated = 3
+
+// ExitCode maps a command error onto the process exit code.
+func ExitCode(err error) int {
+	var gate *vulnGateError
+	if errors.As(err, &gate) {
+		return ExitVulnGate
+	}
+	var unevaluated *vulnGateUnevaluatedError
+	if errors.As(err, &unevaluated) {
+		return ExitVulnGateUnevaluated
+	}
+	return 1
+}
+
+// vulnGateError is the error of a release the vulnerability gate rejects.
+type vulnGateError struct {
+	offending []dependency.PackageChange
+}
+
+func (e *vulnGateError) Error() string {
+	var parts []string
+	for _, c := range e.offending {
+		for _, v := range c.Vuln.Introduced {
+			parts = append(parts, fmt.Sprintf("%s in %s@%s", v.ID, c.Name, c.ToVersion))
+		}
+	}
+	return fmt.Sprintf("dependency changes introduce vulnerabilities the fail-on policy forbids: %s", strings.Join(parts, ", "))
+}
+
+// vulnGateUnevaluatedError is the error of a release the vulnerability gate
+// could not judge.
+type vulnGateUnevaluatedError struct {
+	reason string
+}
+
+func (e *vulnGateUnevaluatedError) Error() string {
+	return fmt.Sprintf("unable to evaluate the dependencies.fail-on policy: %s", e.reason)
+}
+
+// checkVulnGate judges diff against an enabled gate: a *vulnGateError when it
+// found offending changes, and a *vulnGateUnevaluatedError when there is no
+// diff to judge or either ref went unmatched (no usable DB, or a failed
+// match), since an unmatched side would hide introduced vulnerabilities or
+// invent them.
+func checkVulnGate(gate dependency.VulnGate, diff *dependency.Diff) error {
+	switch {
+	case !gate.Enabled():
+		return nil
+	case diff == nil:
+		return &vulnGateUnevaluatedError{reason: "the dependency diff could not be computed"}
+	case diff.Since.Vulns == nil || diff.Until.Vulns == nil:
+		return &vulnGateUnevaluatedError{reason: "vulnerabilities could not be matched at both refs"}
+	case len(diff.GateViolations) > 0:
+		return &vulnGateError{offending: diff.GateViolations}
+	}
+	return nil
+}
+
+// gateViolations flattens the offending changes into the recap's figures.
+func gateViolations(offending []dependency.PackageChange) []event.SummaryGateViolation {
+	var out []event.SummaryGateViolation
+	for _, c := range offending {
+		gv := event.SummaryGateViolation{Package: c.Name, Version: c.ToVersion}
+		for _, v := range c.Vuln.Introduced {
+			gv.Vulnerabilities = append(gv.Vulnerabilities, event.SummaryGateVuln{ID: v.ID, Severity: v.Severity, KnownExploited: v.KnownExploited})
+		}
+		out = append(out, gv)
+	}
+	return out
+}
+
+// checkFailOn validates the vulnerability gate upfront: a misspelled severity
+// would otherwise disable the check, and without annotation there is nothing to
+// gate on, so either would let every release through.
+func checkFailOn(cfg options.Dependencies) error {
+	f := cfg.FailOn
+	if !dependency.ValidSeverity(f.IntroducedSeverity) {
+		return fmt.Errorf("invalid dependencies.fail-on.introduced-severity %q; valid values: negligible, low, medium, high, critical", f.IntroducedSeverity)
+	}
+	if f.Gate().Enabled() && !cfg.AnnotateVulnerabilities {
+		return errors.New("dependencies.fail-on requires vulnerability annotation; set --vulnerabilities")
+	}
 	return nil
 }
 
+// checkLicensePolicy returns an error when fail-on-denied is set and the
+// dependency diff brought in a denied license.
+func checkLicensePolicy(cfg options.DependencyLicenses, diff *dependency.Diff) error {
+	if !cfg.FailOnDenied || diff == nil || len(diff.LicenseViolations) == 0 {
+		return nil
+	}
+	var names []string
+	for _, v := range diff.LicenseViolations {
+		names = append(names, fmt.Sprintf("%s (%s)", v.Name, v.License))
+	}
+	return fmt.Errorf("dependency changes bring in denied licenses: %s", strings.Join(names, ", "))
+}
+
 // notifyFileSinks emits a Notify per non-stdout output sink. The version
 // encoder gets a tailored phrasing ("wrote version ..."); other formats use a
-// generic "wrote <name> ..." phrasing.
-func notifyFileSinks(appConfig *createConfig, description *release.Description) {
+// generic "wrote <name> ..." phrasing. Webhook destinations report delivery
+// instead (nothing is reported for a dry run — the request itself is the
+// output).
+func notifyFileSinks(o *options.Output, description *release.Description) {
 	if description == nil {
 		return
 	}
-	specs, err := appConfig.Specs()
+	specs, err := o.Specs()
 	if err != nil {
 		return
 	}
@@ -158,6 +324,12 @@ func notifyFileSinks(appConfig *createConfig, description *release.Description)
 		if s.IsStdout() {
 			continue
 		}
+		if s.IsWebhook() {
+			if !o.Webhook.DryRun {
+				bus.Notify(fmt.Sprintf("posted %s to %s", s.Name, s.Destination()))
+			}
+			continue
+		}
 		if s.Name == "version" {
 			bus.Notify(fmt.Sprintf("wrote version %q to %s", description.Version, s.Path))
 		} else {
@@ -199,3 +371,18 @@ func selectWorker(repo string) func(context.Context, *createConfig) (*release.Re
 	// TODO: we only support github, but this is the spot to add support for other providers such as GitLab or Bitbucket or other VCSs altogether, such as subversion.
 	return createChangelogFromGithub
 }
+
+// requestsVEX reports whether any configured output is a vex format, returning
+// the first such format name.
+func requestsVEX(o *options.Output) (string, bool) {
+	specs, err := o.Specs()
+	if err != nil {
+		return "", false
+	}
+	for _, s := range specs {
+		if s.Name == vexenc.ID || s.Name == vexenc.CycloneDXID {
+			return s.Name, true
+		}
+	}
+	return "", false
+}
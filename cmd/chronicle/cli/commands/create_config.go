@@ -15,6 +15,8 @@ type createConfig struct {
 	SpeculateNextVersion bool                     `yaml:"speculate-next-version" json:"speculate-next-version" mapstructure:"speculate-next-version"` // -n, guess the next version based on issues and PRs
 	RepoPath             string                   `yaml:"repo-path" json:"repo-path" mapstructure:"-"`
 	EnforceV0            options.EnforceV0        `yaml:"enforce-v0" json:"enforce-v0" mapstructure:"enforce-v0"`
+	SignedTags           options.SignedTags       `yaml:"signed-tags" json:"signed-tags" mapstructure:"signed-tags"` // signature verification of the since/until tags
+	Notice               options.Notice           `yaml:"notice" json:"notice" mapstructure:"notice"`                // where the release notice is read from
 }
 
 var _ clio.FlagAdder = (*createConfig)(nil)
@@ -28,6 +30,8 @@ func (c *createConfig) DescribeFields(descriptions clio.FieldDescriptionSet) {
 	descriptions.Add(&c.Dependencies, "source-scan dependency diff configuration")
 	descriptions.Add(&c.SpeculateNextVersion, "guess the next version based on issues and PRs")
 	descriptions.Add(&c.EnforceV0, "major changes bump minor version for versions < 1.0")
+	descriptions.Add(&c.Notice, "where the release notice (a highlight paragraph shown above the changes) is read from")
+	descriptions.Add(&c.SignedTags, "verify the since and until tags' GPG or SSH signatures against trusted keys")
 }
 
 func (c *createConfig) AddFlags(flags clio.FlagSet) {
@@ -55,6 +59,12 @@ func (c *createConfig) AddFlags(flags clio.FlagSet) {
 		"guess the next release version based off of issues and PRs in cases where there is no semver tag after --since-tag (cannot use with --until-tag)",
 	)
 
+	flags.BoolVarP(
+		&c.SignedTags.Require,
+		"require-signed-tags", "",
+		"fail unless the since and until refs are tags signed by a key in signed-tags.allowed-signers or signed-tags.keyring",
+	)
+
 	flags.StringArrayVarP(
 		&c.Dependencies.Ecosystems,
 		"dependencies", "",
@@ -66,6 +76,72 @@ func (c *createConfig) AddFlags(flags clio.FlagSet) {
 		"vulnerabilities", "",
 		"annotate dependency changes with known vulnerability information",
 	)
+
+	flags.StringVarP(
+		&c.Dependencies.VulnerabilityDB.Import,
+		"vulnerability-db", "",
+		"import the grype vulnerability DB from this archive, .db file, or URL and annotate against it instead of grype's shared cache (for air-gapped builds); requires --vulnerabilities",
+	)
+
+	flags.BoolVarP(
+		&c.Dependencies.Timeline,
+		"dependency-timeline", "",
+		"show the dependency changes each commit made in the trunk output, read from the lockfiles and manifests it edited",
+	)
+
+	flags.StringArrayVarP(
+		&c.Dependencies.VEXInput.Documents,
+		"vex-input", "",
+		"OpenVEX document whose not_affected statements keep vulnerabilities out of the annotations; repeatable; requires --vulnerabilities",
+	)
+
+	flags.StringArrayVarP(
+		&c.Dependencies.SBOM.Since,
+		"sbom-since", "",
+		"write the SBOM cataloged for the since ref as FORMAT=PATH (e.g. spdx-json=since.spdx.json); repeatable; requires --dependencies",
+	)
+
+	flags.StringArrayVarP(
+		&c.Dependencies.SBOM.Until,
+		"sbom-until", "",
+		"write the SBOM cataloged for the until ref as FORMAT=PATH (e.g. cyclonedx-json=sbom.cdx.json); repeatable; requires --dependencies",
+	)
+
+	flags.StringVarP(
+		&c.Dependencies.SBOMInput.Since,
+		"sbom-input-since", "",
+		"read the since ref's dependencies from this SBOM file (SPDX, CycloneDX, or syft JSON) instead of scanning the source tree",
+	)
+
+	flags.StringVarP(
+		&c.Dependencies.SBOMInput.Until,
+		"sbom-input-until", "",
+		"read the until ref's dependencies from this SBOM file instead of scanning the source tree",
+	)
+
+	flags.StringVarP(
+		&c.Dependencies.SBOMInput.Template,
+		"sbom-input-template", "",
+		"read each ref's dependencies from the SBOM file at this path, with {ref} replaced by the ref (e.g. sboms/{ref}.spdx.json)",
+	)
+
+	flags.StringVarP(
+		&c.Dependencies.Image.Since,
+		"image-since", "",
+		"catalog this container image (registry reference, OCI layout directory, or image archive) as the since ref's dependencies instead of scanning the source tree",
+	)
+
+	flags.StringVarP(
+		&c.Dependencies.Image.Until,
+		"image-until", "",
+		"catalog this container image as the until ref's dependencies instead of scanning the source tree",
+	)
+
+	flags.StringVarP(
+		&c.Dependencies.Image.Template,
+		"image-template", "",
+		"catalog the container image at this reference for each ref, with {ref} replaced by the ref and {version} by its release version without a leading v (e.g. ghcr.io/org/app:{version})",
+	)
 }
 
 func defaultCreateConfig() *createConfig {
@@ -79,5 +155,6 @@ func defaultCreateConfig() *createConfig {
 		EnforceV0:            false,
 		Github:               options.DefaultGithubSimmarizer(),
 		Dependencies:         options.DefaultDependencies(),
+		Notice:               options.DefaultNotice(),
 	}
 }
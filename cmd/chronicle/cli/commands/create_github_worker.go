@@ -5,17 +5,25 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/anchore/chronicle/chronicle/dependency"
+	"github.com/anchore/chronicle/chronicle/dependency/actions"
+	"github.com/anchore/chronicle/chronicle/dependency/attribution"
+	"github.com/anchore/chronicle/chronicle/dependency/baseimage"
 	"github.com/anchore/chronicle/chronicle/dependency/scan"
 	"github.com/anchore/chronicle/chronicle/dependency/source"
+	"github.com/anchore/chronicle/chronicle/dependency/timeline"
 	"github.com/anchore/chronicle/chronicle/dependency/toolchain"
+	"github.com/anchore/chronicle/chronicle/dependency/vex"
+	"github.com/anchore/chronicle/chronicle/dependency/workspace"
 	"github.com/anchore/chronicle/chronicle/event"
 	"github.com/anchore/chronicle/chronicle/release"
 	"github.com/anchore/chronicle/chronicle/release/change"
+	"github.com/anchore/chronicle/chronicle/release/notice"
 	"github.com/anchore/chronicle/chronicle/release/releasers/github"
 	"github.com/anchore/chronicle/chronicle/release/render"
 	"github.com/anchore/chronicle/cmd/chronicle/cli/options"
@@ -32,6 +40,13 @@ func createChangelogFromGithub(ctx context.Context, appConfig *createConfig) (*r
 		return nil, nil, err
 	}
 
+	// load the trusted keys up front so an unreadable keyring fails before any
+	// GitHub API work rather than after it.
+	tagVerifier, err := appConfig.SignedTags.Verifier()
+	if err != nil {
+		return nil, nil, err
+	}
+
 	ghConfig := buildGithubConfig(appConfig)
 
 	gitter, err := git.New(appConfig.RepoPath)
@@ -92,6 +107,14 @@ func createChangelogFromGithub(ctx context.Context, appConfig *createConfig) (*r
 		log.Info("until the current revision (no end tag)")
 	}
 
+	// the until tag is known before the changelog is built, so a release cut
+	// from an untrusted tag fails before any of the (slow) GitHub fetching.
+	untilSigner, err := verifyTagSignature(appConfig, tagVerifier, gitter, "until", untilTag)
+	if err != nil {
+		rng.Slot("until").Fail(err)
+		return nil, nil, err
+	}
+
 	changelogConfig := buildChangelogConfig(appConfig, untilTag, changeTypeTitles, evidence, gitter)
 
 	startRelease, description, err := release.ChangelogInfo(summer, changelogConfig)
@@ -105,15 +128,24 @@ func createChangelogFromGithub(ctx context.Context, appConfig *createConfig) (*r
 		description.ConventionalCommitTypes = getGithubConventionalCommitTypes(appConfig)
 	}
 
+	// the since tag is only known now that the previous release is resolved.
+	sinceSigner, sinceErr := verifyTagSignature(appConfig, tagVerifier, gitter, "since", rangeSinceTag(appConfig.SinceTag, description))
+
 	// resolve range slots from what we now know about each end of the range.
-	resolveRangeSlots(rng, gitter, appConfig.SinceTag, untilTag, description)
+	resolveRangeSlots(rng, gitter, appConfig.SinceTag, untilTag, description, sinceSigner, untilSigner)
+	if sinceErr != nil {
+		rng.Slot("since").Fail(sinceErr)
+		return startRelease, description, sinceErr
+	}
 
 	// surface raw fetch totals and resolve evidence leaves with kept counts.
 	resolveEvidenceLeaves(evidence, summer, description)
 
 	// enrich the description with the two opt-in diffs (toolchain + dependencies),
 	// joined before returning so the description is fully populated.
-	enrichDescription(ctx, appConfig, gitter, startRelease, untilTag, description, evidence, dbRefresh, vulnLeaf)
+	if err := enrichDescription(ctx, appConfig, gitter, startRelease, untilTag, description, evidence, dbRefresh, vulnLeaf); err != nil {
+		return startRelease, description, err
+	}
 
 	return startRelease, description, nil
 }
@@ -129,47 +161,119 @@ func buildGithubConfig(appConfig *createConfig) github.Config {
 	return ghConfig
 }
 
-// enrichDescription runs the two opt-in, description-enriching diffs concurrently.
-// Toolchain detection only reads go.mod (etc.) at the two refs, so it is independent of
-// the much heavier dependency scan and writes a separate field of the description; running
-// it concurrently hides its latency behind the scan. Each gitter call opens its own repo
-// handle, so the shared gitter is safe to use from both. Joined before returning.
-func enrichDescription(ctx context.Context, appConfig *createConfig, gitter git.Interface, startRelease *release.Release, untilTag string, description *release.Description, evidence *event.Tree, dbRefresh <-chan *scan.DB, vulnLeaf *event.Leaf) {
+// enrichDescription fills in the opt-in parts of the description that come from diffing the two
+// refs. Toolchain, go.mod, base-image and workflow action detection and the dependency timeline
+// each run in their own goroutine alongside the much heavier dependency scan: they only read
+// manifests, Dockerfiles and workflows (the timeline commit by commit) and each writes a field
+// the others don't, so their latency hides behind the scan. The gitter holds no state between
+// calls and each call opens its own repository handle, so all six can share it. PR attribution
+// walks the trunk commits the timeline writes to, so it runs only once everything has joined.
+// The only errors are a requested SBOM that could not be written and a requested vulnerability
+// DB that could not be loaded.
+func enrichDescription(ctx context.Context, appConfig *createConfig, gitter git.Interface, startRelease *release.Release, untilTag string, description *release.Description, evidence *event.Tree, dbRefresh <-chan vulnDBLoad, vulnLeaf *event.Leaf) error {
+	sinceRef, untilRef := detectionRange(appConfig, startRelease, untilTag)
+
 	var wg sync.WaitGroup
 	wg.Go(func() {
 		// detect toolchain-requirement changes (opt-in) using the now-resolved range. The result
 		// shares the evidence tree as one more row (nil leaf when detection is disabled) and is
 		// rendered as a rollup within the Dependencies section.
-		resolveToolchain(appConfig, gitter, startRelease, untilTag, description, evidence.Leaf("toolchain"))
+		resolveToolchain(appConfig, gitter, sinceRef, untilRef, description, evidence.Leaf("toolchain"))
+	})
+	wg.Go(func() {
+		// base-image detection reads Dockerfiles at the same two refs, so it is just as light.
+		resolveBaseImages(appConfig, gitter, sinceRef, untilRef, description, evidence.Leaf("base images"))
+	})
+	wg.Go(func() {
+		// ...and so does reading the workflow files for action references.
+		resolveActions(appConfig, gitter, sinceRef, untilRef, description, evidence.Leaf("actions"))
+	})
+	wg.Go(func() {
+		// go.mod insights read the same go.mod files toolchain detection does.
+		resolveGoMod(appConfig, gitter, sinceRef, untilRef, description, evidence.Leaf("go.mod"))
+	})
+	wg.Go(func() {
+		// the timeline diffs manifests commit by commit, still without a scan.
+		resolveTimeline(appConfig, gitter, sinceRef, untilRef, description, evidence.Leaf("timeline"))
 	})
 
 	// optional source-scan dependency diff. Non-fatal: a changelog must not
 	// fail because grype isn't ready or syft hit a snag. Await the DB refresh
 	// kicked off above (parallel with the fetch) and hand the loaded DB to the scan.
-	db := awaitVulnDB(dbRefresh)
-	attachDependencyDiff(ctx, appConfig, gitter, untilTag, description, db, evidence.Leaf("source sbom"), vulnLeaf)
+	// A DB the run asked for by name that can't be loaded is the one failure
+	// that isn't degraded: annotating against any other DB would defeat it.
+	db, err := awaitVulnDB(dbRefresh)
+	if err != nil {
+		vulnLeaf.Fail(err)
+	} else {
+		err = attachDependencyDiff(ctx, appConfig, gitter, untilTag, description, db, evidence.Leaf("source sbom"), vulnLeaf)
+	}
 
 	wg.Wait()
+
+	// attribution walks the trunk commits the timeline fills in, so it waits
+	// for every detector rather than racing them.
+	if appConfig.Dependencies.AttributePullRequests && description != nil && description.DependencyDiff != nil {
+		n := attribution.Attribute(gitter, description.Trunk, description.DependencyDiff)
+		log.WithFields("changes", n).Debug("attributed dependency changes to pull requests")
+	}
+	return err
+}
+
+// detectionRange resolves the refs the lightweight detectors compare: the --since-tag or else the
+// previous release, and the until tag or else HEAD. sinceRef is "" when there is no baseline (the
+// changelog starts at the beginning of history), which each detector treats as nothing to do.
+func detectionRange(appConfig *createConfig, startRelease *release.Release, untilTag string) (sinceRef, untilRef string) {
+	sinceRef = appConfig.SinceTag
+	if sinceRef == "" && startRelease != nil {
+		sinceRef = startRelease.Version
+	}
+	untilRef = untilTag
+	if untilRef == "" {
+		untilRef = "HEAD"
+	}
+	return sinceRef, untilRef
+}
+
+// detectionIgnore is the ignore list the manifest-reading detectors share: the standard
+// vendored/test globs extended with the dependencies `exclude` list, so every feature skips the
+// same trees the scan does.
+func detectionIgnore(appConfig *createConfig) []string {
+	return append(toolchain.DefaultIgnore(), appConfig.Dependencies.Exclude...)
 }
 
 // attachDependencyDiff runs the opt-in dependency diff between the resolved
 // since/until endpoints and attaches it to the description. Any failure (no DB,
 // syft error, unresolvable ref) is logged and swallowed so changelog generation
-// continues unaffected.
-func attachDependencyDiff(ctx context.Context, appConfig *createConfig, gitter git.Interface, untilTag string, description *release.Description, db *scan.DB, sbomLeaf, vulnLeaf *event.Leaf) {
+// continues unaffected — unless SBOM outputs were requested: those are artifacts
+// the caller is counting on, so not producing them is returned as an error. A
+// fail-on gate likewise can't pass without a matched diff; create fails the run
+// for it once the changelog is written.
+func attachDependencyDiff(ctx context.Context, appConfig *createConfig, gitter git.Interface, untilTag string, description *release.Description, db *scan.DB, sbomLeaf, vulnLeaf *event.Leaf) error {
 	if description == nil {
-		return
+		return nil
 	}
 
-	// the feature is enabled when at least one ecosystem is requested.
+	// the feature is enabled when at least one ecosystem is requested, or SBOMs
+	// or images were given to read in place of the scan.
 	ecosystems := appConfig.Dependencies.CleanedEcosystems()
-	if len(ecosystems) == 0 {
-		return
+	input := appConfig.Dependencies.SBOMInput
+	image := appConfig.Dependencies.Image
+	if len(ecosystems) == 0 && !input.Requested() && !image.Requested() {
+		return nil
 	}
 
+	sboms := appConfig.Dependencies.SBOM
 	sinceRef, untilRef, ok := resolveDependencyRefs(description, gitter, untilTag)
 	if !ok {
-		return
+		if sboms.Requested() {
+			return errors.New("unable to write the requested SBOMs: the dependency scan range could not be resolved")
+		}
+		return nil
+	}
+	exports, err := sboms.Exports(sinceRef, untilRef)
+	if err != nil {
+		return err
 	}
 
 	configured := appConfig.Dependencies.AnnotateVulnerabilities
@@ -178,6 +282,8 @@ func attachDependencyDiff(ctx context.Context, appConfig *createConfig, gitter g
 	// (missing/corrupt, or a failed download): behave exactly as if
 	// annotate-vulnerabilities was never passed — packages-only, with the row
 	// skipped rather than failed. The cause was already warned at load time.
+	// The changelog doesn't need the matches, but a fail-on gate does: it
+	// fails the run once the changelog is out (see checkVulnGate).
 	if configured && db == nil {
 		annotate = false
 		skipVulnLeaf(vulnLeaf)
@@ -199,6 +305,17 @@ func attachDependencyDiff(ctx context.Context, appConfig *createConfig, gitter g
 		log.Warn("dependencies.show-remaining-vulnerabilities has no effect without annotate-vulnerabilities; omitting remaining vulnerabilities")
 	}
 
+	var suppressor dependency.Suppressor
+	if annotate {
+		vs, err := loadVEX(appConfig.Dependencies.VEXInput, gitter, untilRef, detectionIgnore(appConfig), vexProject(appConfig)...)
+		if err != nil {
+			return err
+		}
+		if vs.Len() > 0 {
+			suppressor = vs
+		}
+	}
+
 	// name the syft source after the project so artifact IDs are stable across the
 	// tmpdir each ref is materialized into; fall back to the repo dir name.
 	sourceName := bus.Repo() // "owner/repo"
@@ -206,17 +323,53 @@ func attachDependencyDiff(ctx context.Context, appConfig *createConfig, gitter g
 		sourceName = filepath.Base(appConfig.RepoPath)
 	}
 
-	startDependencyLeaves(sbomLeaf, vulnLeaf, sinceRef, untilRef, annotate)
-
 	// the scanner owns materialization (the git Target) and matches against the
 	// pre-loaded DB (refreshed in parallel with the GitHub fetch); a nil db scans
 	// packages only, and ComputeDiff infers whether to attribute from the data.
-	scanner := scan.NewScanner(source.NewGitTarget(appConfig.RepoPath), sourceName, ecosystems, appConfig.Dependencies.Exclude, appConfig.Dependencies.Recursive, db)
+	// SBOMs the build produced replace the source scan outright: they see what
+	// the tree can't (e.g. the container's OS packages), so mixing in the scan
+	// would only report phantom adds and removes.
+	// Images likewise replace it, so the section reports what operators
+	// actually run.
+	var scanner dependency.Scanner
+	switch {
+	case input.Requested():
+		startDependencyLeaves(sbomLeaf, vulnLeaf, sinceRef, untilRef, "loading…", annotate)
+		scanner = scan.NewSBOMFileScanner(input.Input(sinceRef, untilRef), db, exports)
+	case image.Requested():
+		var sinceVersion string
+		if description.PreviousRelease != nil {
+			sinceVersion = description.PreviousRelease.Version
+		}
+		startDependencyLeaves(sbomLeaf, vulnLeaf, sinceRef, untilRef, "cataloging images…", annotate)
+		scanner = scan.NewImageScanner(image.Input(sinceRef, sinceVersion, untilRef, description.Version), sourceName, db, exports)
+	default:
+		// a cache that can't be set up only costs time, so scan without it.
+		cache, err := appConfig.Dependencies.Cache.Cache()
+		if err != nil {
+			log.WithFields("error", err).Warn("unable to set up the dependency scan cache; scanning without it")
+		}
+		startDependencyLeaves(sbomLeaf, vulnLeaf, sinceRef, untilRef, "cataloging…", annotate)
+		scanner = scan.NewScanner(source.NewGitTarget(appConfig.RepoPath), scan.Options{
+			SourceName: sourceName,
+			Ecosystems: ecosystems,
+			Exclude:    appConfig.Dependencies.Exclude,
+			Recursive:  appConfig.Dependencies.Recursive,
+			Workspaces: discoverWorkspaces(appConfig, gitter, sinceRef, untilRef),
+			DB:         db,
+			Exports:    exports,
+			Cache:      cache,
+		})
+	}
 	result, err := dependency.ComputeDiff(ctx, scanner, dependency.DiffConfig{
-		Comparer:    scan.NewVersionComparer(),
-		SinceRef:    sinceRef,
-		UntilRef:    untilRef,
-		MinSeverity: appConfig.Dependencies.MinSeverity,
+		Comparer:      scan.NewVersionComparer(),
+		SinceRef:      sinceRef,
+		UntilRef:      untilRef,
+		MinSeverity:   appConfig.Dependencies.MinSeverity,
+		MinEPSS:       appConfig.Dependencies.MinEPSS,
+		Suppressor:    suppressor,
+		LicensePolicy: appConfig.Dependencies.Licenses.Policy(),
+		Gate:          appConfig.Dependencies.FailOn.Gate(),
 	})
 	if err != nil {
 		log.WithFields("error", err).Warn("unable to compute dependency diff; continuing without it")
@@ -224,16 +377,79 @@ func attachDependencyDiff(ctx context.Context, appConfig *createConfig, gitter g
 		if annotate {
 			vulnLeaf.Fail(err)
 		}
-		return
+		if sboms.Requested() {
+			return fmt.Errorf("unable to write the requested SBOMs: %w", err)
+		}
+		return nil
+	}
+	if annotate {
+		// recorded so annotations can be traced to (and reproduced with) the DB
+		// build they came from.
+		info := db.Info()
+		result.VulnerabilityDB = &info
 	}
 	description.DependencyDiff = result
+	for _, v := range result.LicenseViolations {
+		log.WithFields("package", v.Name, "version", v.Version, "license", v.License).Warn("dependency change brings in a denied license")
+	}
+	notifySBOMs(sinceRef, untilRef, exports)
 	resolveDependencyLeaves(sbomLeaf, vulnLeaf, result, annotate)
 
 	// presentation travels alongside the data, not inside it.
-	rc := dependencyRenderConfig(appConfig.Dependencies)
+	rc := dependencyRenderConfig(appConfig.Dependencies.Actions, appConfig.Dependencies.TransitiveActions)
 	rc.OnlyVulnerable = onlyVulnerable
 	rc.ShowRemaining = showRemaining
 	description.DependencyRender = &rc
+	return nil
+}
+
+// loadVEX reads the configured OpenVEX documents and, when discovery is on,
+// those committed at the until ref outside the ignored paths. A configured
+// document that can't be read fails the run, since the annotations would
+// silently overstate what the project is exposed to; discovery failures only
+// skip discovery.
+func loadVEX(cfg options.DependencyVEXInput, gitter git.Interface, untilRef string, ignore []string, project ...string) (*vex.Suppressor, error) {
+	vs, err := vex.Load(cfg.Documents)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the vex input: %w", err)
+	}
+	vs.SetProject(project...)
+	if cfg.Discover {
+		n, err := vs.Discover(gitter, untilRef, cfg.DiscoveryPaths(), ignore)
+		if err != nil {
+			log.WithFields("error", err).Warn("unable to discover vex documents; continuing without them")
+		} else if n > 0 {
+			log.WithFields("documents", n, "ref", untilRef).Debug("discovered vex documents")
+		}
+	}
+	return vs, nil
+}
+
+// vexProject lists the identifiers a VEX statement can use to name this
+// project: the GitHub repository and the product the vex encoders are
+// configured to write about.
+func vexProject(appConfig *createConfig) []string {
+	var ids []string
+	if repo := bus.Repo(); repo != "" {
+		ids = append(ids, "pkg:github/"+repo)
+	}
+	if p := appConfig.Output.Vex.Product; p != "" {
+		ids = append(ids, p)
+	}
+	return ids
+}
+
+// notifySBOMs reports each SBOM the scan wrote, in the same style as the
+// changelog's own file outputs.
+func notifySBOMs(sinceRef, untilRef string, exports map[string][]scan.SBOMExport) {
+	for _, ref := range []string{sinceRef, untilRef} {
+		for _, e := range exports[ref] {
+			bus.Notify(fmt.Sprintf("wrote %s sbom for %s to %s", e.Format, ref, e.Path))
+		}
+		if sinceRef == untilRef {
+			return
+		}
+	}
 }
 
 // vulnDBMaxAge is how stale the grype vulnerability DB may be before chronicle
@@ -242,56 +458,84 @@ func attachDependencyDiff(ctx context.Context, appConfig *createConfig, gitter g
 // a warning. Matches grype's own max-allowed DB age.
 const vulnDBMaxAge = 5 * 24 * time.Hour
 
+// vulnDBLoad is the outcome of the background DB load. err is only set when
+// the run asked for a specific DB (an import or a pinned build) that couldn't be
+// loaded; any other failure has already been logged and reads as a nil db.
+type vulnDBLoad struct {
+	db  *scan.DB
+	err error
+}
+
 // startVulnDBRefresh loads the grype vulnerability DB in the background so a
 // (possibly slow) download overlaps the commit/issue/PR fetch. It returns nil
 // when vulnerability annotation is off. With DB updates enabled (the default), a
 // missing or stale DB spins the "vulnerabilities" row on "updating DB" while it
 // downloads; with updates disabled, the on-disk DB is loaded as-is and a stale
 // one only logs a warning (a missing/unusable DB then degrades to packages-only).
+// An imported DB spins the row on "importing DB" instead, and neither it nor a
+// pinned build is checked for staleness: the run asked for that DB by name.
 // awaitVulnDB joins the loaded DB before the dependency scan matches — the row's
 // matching/resolve states are driven later, as usual.
-func startVulnDBRefresh(appConfig *createConfig, vulnLeaf *event.Leaf) <-chan *scan.DB {
+func startVulnDBRefresh(appConfig *createConfig, vulnLeaf *event.Leaf) <-chan vulnDBLoad {
 	if !appConfig.Dependencies.AnnotateVulnerabilities || !appConfig.Dependencies.Enabled() {
 		return nil
 	}
 
-	// a quick, local status read (no network) decides whether to update and/or
-	// warn. We only download when the DB is stale/missing AND updates are enabled;
-	// otherwise a stale-but-present DB is used as-is with a warning.
-	present, age := scan.DBStatus()
-	stale := !present || age > vulnDBMaxAge
-	update := stale && appConfig.Dependencies.UpdateVulnerabilityDB
+	requested := appConfig.Dependencies.VulnerabilityDB
+	var stale bool
+	if requested.Import == "" {
+		// a quick, local status read (no network) decides whether to update
+		// and/or warn. We only download when the DB is stale/missing AND updates
+		// are enabled; otherwise a stale-but-present DB is used as-is with a
+		// warning.
+		info, present := scan.DBStatus()
+		age := time.Since(info.Built)
+		stale = !present || age > vulnDBMaxAge
+		if present && stale && !requested.Requested() && !appConfig.Dependencies.UpdateVulnerabilityDB {
+			log.WithFields("age", age.Round(time.Hour).String()).
+				Warn("vulnerability DB is older than the max recommended age and DB updates are disabled; results may be stale")
+		}
+	}
+	cfg := requested.Config(stale && appConfig.Dependencies.UpdateVulnerabilityDB)
+
+	// light up the row only when grype actually downloads or imports; SetStage
+	// flips the pending row to running.
 	switch {
-	case update:
-		// light up the row only when grype actually downloads; SetStage flips the
-		// pending row to running.
+	case cfg.Import != "":
+		vulnLeaf.SetStage("importing DB")
+	case cfg.Update:
 		vulnLeaf.SetStage("updating DB")
-	case present && age > vulnDBMaxAge:
-		log.WithFields("age", age.Round(time.Hour).String()).
-			Warn("vulnerability DB is older than the max recommended age and DB updates are disabled; results may be stale")
 	}
 
-	ch := make(chan *scan.DB, 1)
+	ch := make(chan vulnDBLoad, 1)
 	go func() {
-		db, err := scan.LoadDB(update)
+		db, err := scan.LoadDB(cfg)
 		if err != nil {
+			if requested.Requested() {
+				// annotations against some other DB are exactly what an
+				// import or pin is there to prevent, so don't degrade.
+				ch <- vulnDBLoad{err: err}
+				return
+			}
 			// non-fatal: the scan degrades to packages-only and the vulnerability
 			// row is skipped downstream (db is nil → attachDependencyDiff degrades).
 			log.WithFields("error", err).Warn("unable to load vulnerability DB; continuing without vulnerability annotations")
 		}
-		ch <- db
+		ch <- vulnDBLoad{db: db}
 	}()
 	return ch
 }
 
 // awaitVulnDB blocks for the background DB refresh and returns the loaded DB. It
 // returns nil when annotation is off or the refresh failed — the scan then
-// degrades to packages-only and the vulnerability leaves are failed downstream.
-func awaitVulnDB(ch <-chan *scan.DB) *scan.DB {
+// degrades to packages-only and the vulnerability leaves are failed downstream —
+// and an error only when a requested DB could not be loaded.
+func awaitVulnDB(ch <-chan vulnDBLoad) (*scan.DB, error) {
 	if ch == nil {
-		return nil
+		return nil, nil
 	}
-	return <-ch
+	load := <-ch
+	return load.db, load.err
 }
 
 // skipVulnLeaf marks the "vulnerabilities" row and its since/until branches as
@@ -340,13 +584,63 @@ func resolveDependencyRefs(description *release.Description, gitter git.Interfac
 	return sinceRef, untilRef, true
 }
 
+// discoverWorkspaces reads the workspace members each ref declares (when the
+// workspaces option is on), keyed by ref for the scanner and toolchain
+// detection. A ref without a workspace is left out, so it is scanned by the
+// recursive setting as before; so is one whose workspace can't be read, since
+// falling back only costs precision.
+func discoverWorkspaces(appConfig *createConfig, gitter git.Interface, refs ...string) map[string][]string {
+	if !appConfig.Dependencies.Workspaces {
+		return nil
+	}
+	var out map[string][]string
+	for _, ref := range refs {
+		if _, done := out[ref]; done || ref == "" {
+			continue
+		}
+		members, err := workspace.Discover(gitter, ref)
+		if err != nil {
+			log.WithFields("error", err, "ref", ref).Warn("unable to discover workspace members; scanning by the recursive setting")
+			continue
+		}
+		if members == nil {
+			continue
+		}
+		log.WithFields("ref", ref, "members", len(members)).Debug("discovered workspace members")
+		if out == nil {
+			out = make(map[string][]string)
+		}
+		out[ref] = members
+	}
+	return out
+}
+
+// releaseMembers merges the workspace members each ref declares, sorted; nil
+// when no ref declares a workspace.
+func releaseMembers(workspaces map[string][]string) []string {
+	if workspaces == nil {
+		return nil
+	}
+	out := []string{}
+	for _, members := range workspaces {
+		for _, m := range members {
+			if !slices.Contains(out, m) {
+				out = append(out, m)
+			}
+		}
+	}
+	slices.Sort(out)
+	return out
+}
+
 // startDependencyLeaves registers each ref's sbom branch leaf so the scan can
 // route syft's live package count onto the right branch (it publishes its
-// resolved source to the bus from deep inside), then kicks the spinners.
-func startDependencyLeaves(sbomLeaf, vulnLeaf *event.Leaf, sinceRef, untilRef string, annotate bool) {
+// resolved source to the bus from deep inside), then kicks the spinners with
+// stage as the sbom row's status.
+func startDependencyLeaves(sbomLeaf, vulnLeaf *event.Leaf, sinceRef, untilRef, stage string, annotate bool) {
 	bus.RegisterSBOMLeaf(sinceRef, sbomLeaf.Child("since"))
 	bus.RegisterSBOMLeaf(untilRef, sbomLeaf.Child("until"))
-	sbomLeaf.SetStage("cataloging…")
+	sbomLeaf.SetStage(stage)
 	sbomLeaf.Child("since").Start()
 	sbomLeaf.Child("until").Start()
 	if annotate {
@@ -412,27 +706,38 @@ func diffMetrics(d *dependency.Diff) []event.Metric {
 // the diff on vulnerabilities, rendered by the UI as a breakdown. Remaining is
 // the carried-over burden the release did not clear, included alongside the
 // delta so the breakdown tells the whole story (it is always computed when both
-// refs match, independent of whether show-remaining renders it).
+// refs match, independent of whether show-remaining renders it). Suppressed is
+// only shown when VEX statements actually dropped something.
 func vulnMetrics(d *dependency.Diff) []event.Metric {
-	return []event.Metric{
+	m := []event.Metric{
 		event.Count("remediated", d.RemediatedCount),
 		event.Count("introduced", d.IntroducedCount),
 		event.Count("remaining", d.RemainingCount),
 	}
+	if d.SuppressedCount > 0 {
+		m = append(m, event.Count("suppressed", d.SuppressedCount))
+	}
+	return m
 }
 
 // dependencyRenderConfig maps the cmd-layer dependency options onto the core
 // render config consumed by the encoders. Each action is a comma-separated
 // fallback list (e.g. "collapsed,list"); an empty/invalid value leaves nil so
-// ModesFor resolves the per-kind default.
-func dependencyRenderConfig(d options.Dependencies) render.Config {
+// ModesFor resolves the per-kind default — or, for a transitive action, so the
+// kind's transitive changes are not split out at all.
+func dependencyRenderConfig(a, transitive options.DependencyActions) render.Config {
 	return render.Config{
-		Actions: map[dependency.ChangeKind][]render.Mode{
-			dependency.Updated:    render.ParseModes(d.Actions.Updated),
-			dependency.Downgraded: render.ParseModes(d.Actions.Downgraded),
-			dependency.Added:      render.ParseModes(d.Actions.Added),
-			dependency.Removed:    render.ParseModes(d.Actions.Removed),
-		},
+		Actions:    actionModes(a),
+		Transitive: actionModes(transitive),
+	}
+}
+
+func actionModes(a options.DependencyActions) map[dependency.ChangeKind][]render.Mode {
+	return map[dependency.ChangeKind][]render.Mode{
+		dependency.Updated:    render.ParseModes(a.Updated),
+		dependency.Downgraded: render.ParseModes(a.Downgraded),
+		dependency.Added:      render.ParseModes(a.Added),
+		dependency.Removed:    render.ParseModes(a.Removed),
 	}
 }
 
@@ -452,36 +757,42 @@ func toolchainConfig(appConfig *createConfig) toolchain.Config {
 	return toolchain.Config{
 		Enabled:    true,
 		Ecosystems: ecos,
-		Ignore:     append(toolchain.DefaultIgnore(), appConfig.Dependencies.Exclude...),
+		Ignore:     detectionIgnore(appConfig),
 		Recursive:  appConfig.Dependencies.Recursive,
 	}
 }
 
 // toolchainEcosystems maps the activated dependency ecosystems (syft cataloger selectors) to the
-// toolchain ecosystems we have detectors for. The "language" meta-selector expands to every known
-// toolchain ecosystem; a selector that parses to an ecosystem with no detector (e.g. "java") or
-// does not parse at all is dropped. The result is deduplicated and ordered by KnownEcosystems.
+// toolchain ecosystems we have detectors for.
 func toolchainEcosystems(depEcosystems []string) []dependency.Ecosystem {
-	known := make(map[dependency.Ecosystem]bool)
-	for _, e := range toolchain.KnownEcosystems() {
-		known[e] = true
+	return selectEcosystems(depEcosystems, toolchain.KnownEcosystems())
+}
+
+// selectEcosystems maps the activated dependency ecosystems (syft cataloger selectors) to those in
+// known. The "language" meta-selector expands to every known ecosystem; a selector that parses to
+// an ecosystem outside known (e.g. "dotnet") or does not parse at all is dropped. The result is
+// deduplicated and ordered like known.
+func selectEcosystems(depEcosystems []string, known []dependency.Ecosystem) []dependency.Ecosystem {
+	isKnown := make(map[dependency.Ecosystem]bool, len(known))
+	for _, e := range known {
+		isKnown[e] = true
 	}
 
 	want := make(map[dependency.Ecosystem]bool)
 	for _, sel := range depEcosystems {
 		if strings.EqualFold(strings.TrimSpace(sel), "language") {
-			for e := range known {
+			for e := range isKnown {
 				want[e] = true
 			}
 			continue
 		}
-		if e, ok := dependency.ParseEcosystem(sel); ok && known[e] {
+		if e, ok := dependency.ParseEcosystem(sel); ok && isKnown[e] {
 			want[e] = true
 		}
 	}
 
 	var out []dependency.Ecosystem
-	for _, e := range toolchain.KnownEcosystems() {
+	for _, e := range known {
 		if want[e] {
 			out = append(out, e)
 		}
@@ -493,7 +804,7 @@ func toolchainEcosystems(depEcosystems []string) []dependency.Ecosystem {
 // and attaches the result to the description. Detection is best-effort: any failure is logged and
 // does not abort changelog generation. Reconciliation/downgrade warnings are surfaced to the
 // operator log here. The leaf is nil (a no-op) when detection is disabled.
-func resolveToolchain(appConfig *createConfig, gitter git.Interface, startRelease *release.Release, untilTag string, description *release.Description, leaf *event.Leaf) {
+func resolveToolchain(appConfig *createConfig, gitter git.Interface, sinceRef, untilRef string, description *release.Description, leaf *event.Leaf) {
 	cfg := toolchainConfig(appConfig)
 	if !cfg.Enabled || description == nil {
 		return
@@ -501,14 +812,7 @@ func resolveToolchain(appConfig *createConfig, gitter git.Interface, startReleas
 
 	leaf.SetStage("inspecting sources")
 
-	sinceRef := appConfig.SinceTag
-	if sinceRef == "" && startRelease != nil {
-		sinceRef = startRelease.Version
-	}
-
-	untilRef := untilTag
-	if untilRef == "" {
-		untilRef = "HEAD"
+	if untilRef == "HEAD" {
 		// detection diffs committed objects, so working-tree edits to a manifest are invisible.
 		// when ending at HEAD, warn if a toolchain source file is dirty so a bump that only exists
 		// uncommitted isn't mistaken for "no change".
@@ -521,6 +825,7 @@ func resolveToolchain(appConfig *createConfig, gitter git.Interface, startReleas
 		return
 	}
 
+	cfg.Members = discoverWorkspaces(appConfig, gitter, sinceRef, untilRef)
 	data, err := toolchain.Detect(gitter, cfg, sinceRef, untilRef)
 	if err != nil {
 		leaf.Fail(err)
@@ -574,6 +879,222 @@ func warnOnUncommittedToolchainChanges(gitter git.Interface, cfg toolchain.Confi
 		Warn("toolchain detection ends at HEAD but these source files have uncommitted changes; any toolchain version change in them will not appear in the changelog until committed")
 }
 
+// goModConfig derives the go.mod insight config. It shares toolchain detection's discovery
+// settings and ecosystem selection (it only runs when Go is activated), but not its toggle: the
+// directives are selected by the go-mod options instead.
+func goModConfig(appConfig *createConfig) toolchain.Config {
+	opts := appConfig.Dependencies.GoMod
+	if !appConfig.Dependencies.Enabled() || !(opts.Replace || opts.Retract || opts.Godebug || opts.Toolchain) {
+		return toolchain.Config{}
+	}
+	if !slices.Contains(toolchainEcosystems(appConfig.Dependencies.CleanedEcosystems()), dependency.EcosystemGo) {
+		return toolchain.Config{}
+	}
+	return toolchain.Config{
+		Enabled:    true,
+		Ecosystems: []dependency.Ecosystem{dependency.EcosystemGo},
+		Ignore:     detectionIgnore(appConfig),
+		Recursive:  appConfig.Dependencies.Recursive,
+		GoMod: toolchain.GoModConfig{
+			Replace:   opts.Replace,
+			Retract:   opts.Retract,
+			Godebug:   opts.Godebug,
+			Toolchain: opts.Toolchain,
+		},
+	}
+}
+
+// resolveGoMod runs go.mod insight detection (when enabled), drives its row in the evidence tree,
+// and attaches the result to the description. Newly added local-path replaces are logged as
+// warnings: `go install module@version` refuses a module whose go.mod has a replace, and a
+// directory replace can't resolve outside this checkout anyway. The leaf is nil when disabled.
+func resolveGoMod(appConfig *createConfig, gitter git.Interface, sinceRef, untilRef string, description *release.Description, leaf *event.Leaf) {
+	cfg := goModConfig(appConfig)
+	if !cfg.Enabled || description == nil {
+		return
+	}
+
+	leaf.SetStage("inspecting go.mod")
+
+	if sinceRef == "" {
+		leaf.Skip()
+		return
+	}
+
+	cfg.Members = discoverWorkspaces(appConfig, gitter, sinceRef, untilRef)
+	data, err := toolchain.DetectGoMod(gitter, cfg, sinceRef, untilRef)
+	if err != nil {
+		leaf.Fail(err)
+		log.WithFields("error", err).Warn("go.mod insight detection failed")
+		return
+	}
+	if data == nil {
+		leaf.Resolve(event.Count("change", 0))
+		return
+	}
+
+	description.GoMod = data
+	leaf.Resolve(event.Count("change", len(data.Changes)))
+	for _, c := range data.Changes {
+		if c.Directive == release.GoModReplace && c.LocalPath && c.Kind != release.GoModRemoved {
+			log.WithFields("file", c.File, "module", c.Key, "replacement", c.To).
+				Warn("go.mod now replaces a module with a local directory; consumers of the module won't see it")
+		}
+	}
+}
+
+// baseImageConfig derives the base-image detection config. Like toolchain detection it rides on
+// the dependencies feature and shares its discovery settings (recursion and excludes).
+func baseImageConfig(appConfig *createConfig) baseimage.Config {
+	if !appConfig.Dependencies.Enabled() || !appConfig.Dependencies.DetectBaseImages {
+		return baseimage.Config{}
+	}
+	return baseimage.Config{
+		Enabled:   true,
+		Ignore:    detectionIgnore(appConfig),
+		Recursive: appConfig.Dependencies.Recursive,
+	}
+}
+
+// resolveBaseImages runs base-image detection (when enabled), drives its row in the evidence
+// tree, and attaches the result to the description. Like resolveToolchain it is best-effort: a
+// failure is logged and never aborts changelog generation. The leaf is nil when disabled.
+func resolveBaseImages(appConfig *createConfig, gitter git.Interface, sinceRef, untilRef string, description *release.Description, leaf *event.Leaf) {
+	cfg := baseImageConfig(appConfig)
+	if !cfg.Enabled || description == nil {
+		return
+	}
+
+	leaf.SetStage("inspecting dockerfiles")
+
+	if sinceRef == "" {
+		leaf.Skip()
+		return
+	}
+
+	data, err := baseimage.Detect(gitter, cfg, sinceRef, untilRef)
+	if err != nil {
+		leaf.Fail(err)
+		log.WithFields("error", err).Warn("base image detection failed")
+		return
+	}
+	if data == nil {
+		leaf.Resolve(event.Count("change", 0))
+		return
+	}
+
+	description.BaseImages = data
+	leaf.Resolve(event.Count("change", len(data.Updates)))
+	for _, u := range data.Updates {
+		if u.Direction == release.BaseImageDowngrade {
+			log.WithFields("file", u.File, "stage", u.Stage, "from", u.From.String(), "to", u.To.String()).
+				Warn("base image was downgraded")
+		}
+	}
+}
+
+// actionsConfig derives the workflow action detection config. Workflows live at fixed paths, so
+// the dependency discovery settings (recursion, excludes) do not apply.
+func actionsConfig(appConfig *createConfig) actions.Config {
+	if !appConfig.Dependencies.Enabled() || !appConfig.Dependencies.DetectGithubActions {
+		return actions.Config{}
+	}
+	return actions.Config{Enabled: true}
+}
+
+// resolveActions runs workflow action detection (when enabled), drives its row in the evidence
+// tree, and attaches the result to the description. Newly unpinned references are logged as
+// warnings here, since the changelog body only lists the changes. The leaf is nil when disabled.
+func resolveActions(appConfig *createConfig, gitter git.Interface, sinceRef, untilRef string, description *release.Description, leaf *event.Leaf) {
+	cfg := actionsConfig(appConfig)
+	if !cfg.Enabled || description == nil {
+		return
+	}
+
+	leaf.SetStage("inspecting workflows")
+
+	if sinceRef == "" {
+		leaf.Skip()
+		return
+	}
+
+	data, err := actions.Detect(gitter, cfg, sinceRef, untilRef)
+	if err != nil {
+		leaf.Fail(err)
+		log.WithFields("error", err).Warn("workflow action detection failed")
+		return
+	}
+	if data == nil {
+		leaf.Resolve(event.Count("change", 0))
+		return
+	}
+
+	description.Actions = data
+	leaf.Resolve(event.Count("change", len(data.Changes)))
+	for _, w := range data.Warnings {
+		log.Warn(w.Message)
+	}
+}
+
+// timelineConfig derives the dependency timeline config. It reads the manifests the scan would, so
+// it shares the discovery settings. With SBOM or image input there may be no ecosystems at all, and
+// then every lockfile parser runs; ecosystems the timeline has no parser for leave it nothing to do.
+func timelineConfig(appConfig *createConfig) timeline.Config {
+	if !appConfig.Dependencies.Enabled() || !appConfig.Dependencies.Timeline {
+		return timeline.Config{}
+	}
+	depEcosystems := appConfig.Dependencies.CleanedEcosystems()
+	ecos := selectEcosystems(depEcosystems, timeline.KnownEcosystems())
+	if len(depEcosystems) > 0 && len(ecos) == 0 {
+		return timeline.Config{}
+	}
+	return timeline.Config{
+		Enabled:    true,
+		Ecosystems: ecos,
+		Ignore:     detectionIgnore(appConfig),
+		Recursive:  appConfig.Dependencies.Recursive,
+		Exclude:    appConfig.Dependencies.Exclude,
+		Comparer:   scan.NewVersionComparer(),
+	}
+}
+
+// resolveTimeline builds the per-commit dependency timeline (when enabled), drives its row in the
+// evidence tree, and places each commit's events on its trunk commit. Like the other detectors it
+// is best-effort: a failure is logged and the trunk is left as it was.
+func resolveTimeline(appConfig *createConfig, gitter git.Interface, sinceRef, untilRef string, description *release.Description, leaf *event.Leaf) {
+	if appConfig.Dependencies.Timeline && !appConfig.Dependencies.Enabled() {
+		log.Warn("dependencies.timeline has no effect without the dependencies feature; enable it with --dependencies")
+		return
+	}
+	cfg := timelineConfig(appConfig)
+	if !cfg.Enabled || description == nil {
+		return
+	}
+	if description.Trunk == nil {
+		leaf.Skip()
+		return
+	}
+
+	leaf.SetStage("reading commits")
+
+	if sinceRef == "" {
+		leaf.Skip()
+		return
+	}
+
+	// the commits in between may sit on either side of a workspace change, so
+	// a manifest counts when it belongs to a member at either endpoint.
+	cfg.Members = releaseMembers(discoverWorkspaces(appConfig, gitter, sinceRef, untilRef))
+
+	tl, err := timeline.Build(gitter, cfg, sinceRef, untilRef)
+	if err != nil {
+		leaf.Fail(err)
+		log.WithFields("error", err).Warn("dependency timeline failed")
+		return
+	}
+	leaf.Resolve(event.Count("commit", tl.Attach(description.Trunk)))
+}
+
 // buildChangelogConfig assembles the ChangelogInfoConfig, including an
 // optional speculator when --speculate-next-version was set.
 func buildChangelogConfig(appConfig *createConfig, untilTag string, titles []change.TypeTitle, evidence *event.Tree, gitter git.Interface) release.ChangelogInfoConfig {
@@ -584,7 +1105,7 @@ func buildChangelogConfig(appConfig *createConfig, untilTag string, titles []cha
 			NoChangesBumpsPatch: true,
 		})
 	}
-	return release.ChangelogInfoConfig{
+	cfg := release.ChangelogInfoConfig{
 		RepoPath:          appConfig.RepoPath,
 		SinceTag:          appConfig.SinceTag,
 		UntilTag:          untilTag,
@@ -594,6 +1115,10 @@ func buildChangelogConfig(appConfig *createConfig, untilTag string, titles []cha
 		IssuesLeaf:        evidence.Leaf("issues"),
 		PRsLeaf:           evidence.Leaf("pull requests"),
 	}
+	if len(appConfig.Notice.Sources) > 0 {
+		cfg.NoticeResolver = notice.NewResolver(gitter, appConfig.Notice.Config())
+	}
+	return cfg
 }
 
 // publishEvidenceTree builds and publishes the "evidence" tree (commits, issues,
@@ -624,6 +1149,18 @@ func publishEvidenceTree(appConfig *createConfig) *event.Tree {
 			// tree as one more row. It stays pending until detection runs at the end of the flow.
 			evidenceSpecs = append(evidenceSpecs, event.LeafSpec{Name: "toolchain"})
 		}
+		if goModConfig(appConfig).Enabled {
+			evidenceSpecs = append(evidenceSpecs, event.LeafSpec{Name: "go.mod"})
+		}
+		if baseImageConfig(appConfig).Enabled {
+			evidenceSpecs = append(evidenceSpecs, event.LeafSpec{Name: "base images"})
+		}
+		if actionsConfig(appConfig).Enabled {
+			evidenceSpecs = append(evidenceSpecs, event.LeafSpec{Name: "actions"})
+		}
+		if timelineConfig(appConfig).Enabled {
+			evidenceSpecs = append(evidenceSpecs, event.LeafSpec{Name: "timeline"})
+		}
 	}
 	evidence := bus.PublishTreeSpec("evidence", evidenceSpecs)
 	evidence.Leaf("commits").Start()
@@ -688,27 +1225,76 @@ func publishRangeGroup(appConfig *createConfig) *event.Group {
 	})
 }
 
+// rangeSinceTag returns the tag the range starts from: the one given, or the
+// previous release's. It is "" when the range starts at the first commit.
+func rangeSinceTag(sinceTag string, desc *release.Description) string {
+	if sinceTag != "" {
+		return sinceTag
+	}
+	if desc != nil && desc.PreviousRelease != nil {
+		return desc.PreviousRelease.Version
+	}
+	return ""
+}
+
+// verifyTagSignature returns who signed the tag at one end of the range, or ""
+// when tag signatures aren't verified. A tag that is unsigned or whose
+// signature doesn't verify fails the run only when signed tags are required;
+// otherwise it is a warning. With nothing before the first release there is
+// no since tag to require, but an untagged until ref is never accepted.
+func verifyTagSignature(appConfig *createConfig, verifier *git.TagVerifier, gitter git.Interface, end, tag string) (string, error) {
+	if verifier == nil {
+		return "", nil
+	}
+	if tag == "" {
+		if end == "until" && appConfig.SignedTags.Require {
+			return "", errors.New("signed tags are required, but the until ref is not a tag; tag the release (or pass --until-tag) before building its changelog")
+		}
+		return "", nil
+	}
+
+	signer, err := verifyTag(verifier, gitter, tag)
+	if err != nil {
+		if appConfig.SignedTags.Require {
+			return "", err
+		}
+		log.WithFields("tag", tag, "error", err).Warn("unable to verify tag signature")
+		return "", nil
+	}
+	log.WithFields("tag", tag, "signer", signer).Info("verified tag signature")
+	return signer, nil
+}
+
+func verifyTag(verifier *git.TagVerifier, gitter git.Interface, tag string) (string, error) {
+	signed, err := gitter.TagSignature(tag)
+	if err != nil {
+		return "", err
+	}
+	return verifier.Verify(signed)
+}
+
 // resolveRangeSlots populates the since/until slot values from the resolved
 // release/tag information now available post-ChangelogInfo. Any lookup error
 // here is non-fatal — we still want a partially-populated slot rather than a
-// failed one when at least the date or sha is known.
-func resolveRangeSlots(rng *event.Group, gitter git.Interface, sinceTag, untilTag string, desc *release.Description) {
+// failed one when at least the date or sha is known. A verified signer is
+// shown after the tag's date.
+func resolveRangeSlots(rng *event.Group, gitter git.Interface, sinceTag, untilTag string, desc *release.Description, sinceSigner, untilSigner string) {
 	// since: prefer a tag lookup; fall back to whatever PreviousRelease carries.
 	// Values are raw (tag, full sha, timestamp); the UI shortens the sha and
 	// formats the date.
 	switch {
 	case sinceTag != "":
 		if t, err := gitter.SearchForTag(sinceTag); err == nil && t != nil {
-			rng.Slot("since").Resolve(event.Text(t.Name), event.SHA(t.Commit), event.Date(t.Timestamp))
+			rng.Slot("since").Resolve(withSigner(sinceSigner, event.Text(t.Name), event.SHA(t.Commit), event.Date(t.Timestamp))...)
 		} else {
-			rng.Slot("since").Resolve(event.Text(sinceTag))
+			rng.Slot("since").Resolve(withSigner(sinceSigner, event.Text(sinceTag))...)
 		}
 	case desc != nil && desc.PreviousRelease != nil:
 		ver := desc.PreviousRelease.Version
 		if t, err := gitter.SearchForTag(ver); err == nil && t != nil {
-			rng.Slot("since").Resolve(event.Text(ver), event.SHA(t.Commit), event.Date(desc.PreviousRelease.Date))
+			rng.Slot("since").Resolve(withSigner(sinceSigner, event.Text(ver), event.SHA(t.Commit), event.Date(desc.PreviousRelease.Date))...)
 		} else {
-			rng.Slot("since").Resolve(event.Text(ver), event.Date(desc.PreviousRelease.Date))
+			rng.Slot("since").Resolve(withSigner(sinceSigner, event.Text(ver), event.Date(desc.PreviousRelease.Date))...)
 		}
 	default:
 		// no prior release: since the beginning of git history.
@@ -720,15 +1306,24 @@ func resolveRangeSlots(rng *event.Group, gitter git.Interface, sinceTag, untilTa
 	// until: prefer the resolved tag; otherwise show HEAD.
 	if untilTag != "" {
 		if t, err := gitter.SearchForTag(untilTag); err == nil && t != nil {
-			rng.Slot("until").Resolve(event.Text(t.Name), event.SHA(t.Commit), event.Date(t.Timestamp))
+			rng.Slot("until").Resolve(withSigner(untilSigner, event.Text(t.Name), event.SHA(t.Commit), event.Date(t.Timestamp))...)
 		} else {
-			rng.Slot("until").Resolve(event.Text(untilTag))
+			rng.Slot("until").Resolve(withSigner(untilSigner, event.Text(untilTag))...)
 		}
 	} else if sha, err := gitter.HeadTagOrCommit(); err == nil {
 		rng.Slot("until").Resolve(event.SHA(sha))
 	}
 }
 
+// withSigner appends a "signed by" segment to a tag's slot values when its
+// signature was verified.
+func withSigner(signer string, values ...event.Value) []event.Value {
+	if signer == "" {
+		return values
+	}
+	return append(values, event.Text("signed by "+signer))
+}
+
 // checkTrunkPrerequisites returns an error when the trunk output format is
 // selected but consider-pr-merge-commits is disabled. The trunk encoder
 // requires commit-level data that is only collected when that setting is on.
@@ -0,0 +1,111 @@
+package commands
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/chronicle/chronicle/dependency"
+)
+
+func TestCheckVulnGate(t *testing.T) {
+	gate := dependency.VulnGate{IntroducedSeverity: "high"}
+	matched := map[dependency.VulnKey][]dependency.Vulnerability{}
+	offending := dependency.PackageChange{
+		Name: "lib", Type: "go-module", FromVersion: "1", ToVersion: "2", Kind: dependency.Updated,
+		Vuln: &dependency.VulnDelta{Introduced: []dependency.Vulnerability{{ID: "CVE-2026-0001", Severity: "high"}}},
+	}
+	diff := func(since, until map[dependency.VulnKey][]dependency.Vulnerability, violations ...dependency.PackageChange) *dependency.Diff {
+		d := dependency.NewDiff(nil)
+		d.Since.Vulns = since
+		d.Until.Vulns = until
+		d.GateViolations = violations
+		return &d
+	}
+
+	tests := []struct {
+		name     string
+		gate     dependency.VulnGate
+		diff     *dependency.Diff
+		wantErr  require.ErrorAssertionFunc
+		wantCode int
+	}{
+		{
+			name:    "no gate, no diff",
+			diff:    nil,
+			wantErr: require.NoError,
+		},
+		{
+			name:    "matched and clean",
+			gate:    gate,
+			diff:    diff(matched, matched),
+			wantErr: require.NoError,
+		},
+		{
+			name:     "matched with violations",
+			gate:     gate,
+			diff:     diff(matched, matched, offending),
+			wantErr:  require.Error,
+			wantCode: ExitVulnGate,
+		},
+		{
+			// the diff couldn't be computed (or the scan range resolved), so
+			// there is nothing to judge
+			name:     "no diff",
+			gate:     gate,
+			diff:     nil,
+			wantErr:  require.Error,
+			wantCode: ExitVulnGateUnevaluated,
+		},
+		{
+			// no usable DB: both refs were scanned packages-only
+			name:     "db unavailable",
+			gate:     gate,
+			diff:     diff(nil, nil),
+			wantErr:  require.Error,
+			wantCode: ExitVulnGateUnevaluated,
+		},
+		{
+			// matching failed at until, so nothing reads as introduced
+			name:     "until match failed",
+			gate:     gate,
+			diff:     diff(matched, nil),
+			wantErr:  require.Error,
+			wantCode: ExitVulnGateUnevaluated,
+		},
+		{
+			// matching failed at since, so everything reads as introduced
+			name:     "since match failed",
+			gate:     gate,
+			diff:     diff(nil, matched),
+			wantErr:  require.Error,
+			wantCode: ExitVulnGateUnevaluated,
+		},
+		{
+			name:     "known exploited gate alone",
+			gate:     dependency.VulnGate{KnownExploited: true},
+			diff:     diff(nil, nil),
+			wantErr:  require.Error,
+			wantCode: ExitVulnGateUnevaluated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkVulnGate(tt.gate, tt.diff)
+			tt.wantErr(t, err)
+			if err == nil {
+				return
+			}
+			assert.Equal(t, tt.wantCode, ExitCode(err))
+			// the gate's code survives being joined with a license policy failure
+			assert.Equal(t, tt.wantCode, ExitCode(errors.Join(errors.New("denied license"), err)))
+		})
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	assert.Equal(t, 1, ExitCode(errors.New("broken run")))
+}
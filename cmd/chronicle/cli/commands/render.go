@@ -0,0 +1,148 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/anchore/chronicle/chronicle/release"
+	jsonenc "github.com/anchore/chronicle/chronicle/release/output/encoders/json"
+	"github.com/anchore/chronicle/cmd/chronicle/cli/options"
+	"github.com/anchore/clio"
+)
+
+// renderConfig is the config for `chronicle render`. It shares the output and
+// title settings with create, and the display-only subset of the dependencies
+// settings (under the same yaml keys, so one config file drives both
+// commands). Scan settings are meaningless here — the diff is already in the
+// document — so they are deliberately not exposed.
+type renderConfig struct {
+	options.Output `yaml:",inline" json:",inline" mapstructure:",squash"`
+	From           string             `yaml:"-" json:"-" mapstructure:"-"`
+	Title          string             `yaml:"title" json:"title" mapstructure:"title"`
+	Dependencies   renderDependencies `yaml:"dependencies" json:"dependencies" mapstructure:"dependencies"`
+}
+
+// renderDependencies mirrors the presentation fields of options.Dependencies.
+type renderDependencies struct {
+	OnlyVulnerable               bool                      `yaml:"only-vulnerable" json:"only-vulnerable" mapstructure:"only-vulnerable"`
+	ShowRemainingVulnerabilities bool                      `yaml:"show-remaining-vulnerabilities" json:"show-remaining-vulnerabilities" mapstructure:"show-remaining-vulnerabilities"`
+	Actions                      options.DependencyActions `yaml:"actions" json:"actions" mapstructure:"actions"`
+	TransitiveActions            options.DependencyActions `yaml:"transitive-actions" json:"transitive-actions" mapstructure:"transitive-actions"`
+}
+
+var _ clio.FlagAdder = (*renderConfig)(nil)
+var _ clio.FieldDescriber = (*renderConfig)(nil)
+
+func (c *renderConfig) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&c.Title, "title template for the changelog output")
+	descriptions.Add(&c.Dependencies.OnlyVulnerable, "only show dependency changes that remediated or introduced a vulnerability (requires a document with vulnerability annotations)")
+	descriptions.Add(&c.Dependencies.ShowRemainingVulnerabilities, "show the remaining (carried-over) vulnerabilities rollup (requires a document with vulnerability annotations)")
+}
+
+func (c *renderConfig) AddFlags(flags clio.FlagSet) {
+	flags.StringVarP(
+		&c.From,
+		"from", "f",
+		"path to a changelog document written by `chronicle -o json` (use - for stdin)",
+	)
+
+	flags.StringVarP(
+		&c.Title,
+		"title", "t",
+		"The title of the changelog output",
+	)
+}
+
+func defaultRenderConfig() *renderConfig {
+	deps := options.DefaultDependencies()
+	return &renderConfig{
+		Output: options.DefaultOutput(),
+		Title:  `{{ .Version }}`,
+		Dependencies: renderDependencies{
+			OnlyVulnerable:               deps.OnlyVulnerable,
+			ShowRemainingVulnerabilities: deps.ShowRemainingVulnerabilities,
+			Actions:                      deps.Actions,
+		},
+	}
+}
+
+func Render(app clio.Application) *cobra.Command {
+	cfg := defaultRenderConfig()
+
+	return app.SetupCommand(&cobra.Command{
+		Use:   "render --from FILE",
+		Short: "Render a saved json changelog into other output formats",
+		Long: `Render a changelog document previously written with "-o json" into any other output format,
+without contacting GitHub or rescanning dependencies.
+
+Write markdown and slack from a saved changelog
+	chronicle -o json=changelog.json
+	chronicle render --from changelog.json -o md=CHANGELOG.md -o slack
+`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			// ensure errors are printed to stderr since most output is redirected to CHANGELOG.md more often than not
+			cmd.SetErr(os.Stderr)
+			return runRender(cmd.Context(), cfg, cmd.InOrStdin())
+		},
+	}, cfg)
+}
+
+func runRender(_ context.Context, cfg *renderConfig, stdin io.Reader) error {
+	if cfg.From == "" {
+		return errors.New("--from is required (the path to a document written by `chronicle -o json`)")
+	}
+	if err := cfg.Check(); err != nil {
+		return err
+	}
+
+	description, err := loadDescription(cfg.From, stdin)
+	if err != nil {
+		return err
+	}
+
+	// the document says whether the diff was vuln-annotated; the vulnerability
+	// display options only apply when it was, exactly as in create.
+	annotated := description.DependencyDiff != nil && description.DependencyDiff.Until.Vulns != nil
+	rc := dependencyRenderConfig(cfg.Dependencies.Actions, cfg.Dependencies.TransitiveActions)
+	rc.OnlyVulnerable = cfg.Dependencies.OnlyVulnerable && annotated
+	rc.ShowRemaining = cfg.Dependencies.ShowRemainingVulnerabilities && annotated
+	description.DependencyRender = &rc
+
+	w, err := cfg.Writer()
+	if err != nil {
+		return err
+	}
+	if err := w.Write(cfg.Title, *description); err != nil {
+		_ = w.Close()
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	notifyFileSinks(&cfg.Output, description)
+	return nil
+}
+
+func loadDescription(path string, stdin io.Reader) (*release.Description, error) {
+	if path == "-" {
+		return jsonenc.Decode(stdin)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open changelog document: %w", err)
+	}
+	defer f.Close()
+
+	d, err := jsonenc.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return d, nil
+}
@@ -0,0 +1,27 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	jsonenc "github.com/anchore/chronicle/chronicle/release/output/encoders/json"
+)
+
+// Schema prints the JSON Schema for the `-o json` document. It is a plain
+// cobra command (no app config, no UI): the schema is static for a given
+// build, and piping it to a file should not require a repository.
+func Schema() *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema",
+		Short: "Print the JSON Schema describing the json output format",
+		Long:  "Print the JSON Schema (schema version " + jsonenc.SchemaVersion + ") describing the document written by `chronicle -o json`.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			s, err := jsonenc.Schema()
+			if err != nil {
+				return err
+			}
+			_, err = cmd.OutOrStdout().Write(s)
+			return err
+		},
+	}
+}
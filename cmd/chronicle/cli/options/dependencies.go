@@ -1,8 +1,14 @@
 package options
 
 import (
+	"errors"
+	"fmt"
 	"strings"
+	"time"
 
+	"github.com/anchore/chronicle/chronicle/dependency"
+	"github.com/anchore/chronicle/chronicle/dependency/scan"
+	"github.com/anchore/chronicle/chronicle/dependency/vex"
 	"github.com/anchore/clio"
 )
 
@@ -11,16 +17,226 @@ import (
 // ecosystem value is a syft cataloger selection expression (e.g. "language",
 // "go", "python").
 type Dependencies struct {
-	Ecosystems                   []string          `yaml:"ecosystems" json:"ecosystems" mapstructure:"ecosystems"`
-	Exclude                      []string          `yaml:"exclude" json:"exclude" mapstructure:"exclude"`
-	Recursive                    bool              `yaml:"recursive" json:"recursive" mapstructure:"recursive"`
-	AnnotateVulnerabilities      bool              `yaml:"annotate-vulnerabilities" json:"annotate-vulnerabilities" mapstructure:"annotate-vulnerabilities"`
-	UpdateVulnerabilityDB        bool              `yaml:"update-vulnerability-db" json:"update-vulnerability-db" mapstructure:"update-vulnerability-db"`
-	OnlyVulnerable               bool              `yaml:"only-vulnerable" json:"only-vulnerable" mapstructure:"only-vulnerable"`
-	ShowRemainingVulnerabilities bool              `yaml:"show-remaining-vulnerabilities" json:"show-remaining-vulnerabilities" mapstructure:"show-remaining-vulnerabilities"`
-	MinSeverity                  string            `yaml:"min-severity" json:"min-severity" mapstructure:"min-severity"`
-	DetectToolchain              bool              `yaml:"detect-toolchain" json:"detect-toolchain" mapstructure:"detect-toolchain"`
-	Actions                      DependencyActions `yaml:"actions" json:"actions" mapstructure:"actions"`
+	Ecosystems                   []string            `yaml:"ecosystems" json:"ecosystems" mapstructure:"ecosystems"`
+	Exclude                      []string            `yaml:"exclude" json:"exclude" mapstructure:"exclude"`
+	Recursive                    bool                `yaml:"recursive" json:"recursive" mapstructure:"recursive"`
+	Workspaces                   bool                `yaml:"workspaces" json:"workspaces" mapstructure:"workspaces"`
+	AnnotateVulnerabilities      bool                `yaml:"annotate-vulnerabilities" json:"annotate-vulnerabilities" mapstructure:"annotate-vulnerabilities"`
+	UpdateVulnerabilityDB        bool                `yaml:"update-vulnerability-db" json:"update-vulnerability-db" mapstructure:"update-vulnerability-db"`
+	VulnerabilityDB              DependencyVulnDB    `yaml:"vulnerability-db" json:"vulnerability-db" mapstructure:"vulnerability-db"`
+	OnlyVulnerable               bool                `yaml:"only-vulnerable" json:"only-vulnerable" mapstructure:"only-vulnerable"`
+	ShowRemainingVulnerabilities bool                `yaml:"show-remaining-vulnerabilities" json:"show-remaining-vulnerabilities" mapstructure:"show-remaining-vulnerabilities"`
+	MinSeverity                  string              `yaml:"min-severity" json:"min-severity" mapstructure:"min-severity"`
+	MinEPSS                      float64             `yaml:"min-epss" json:"min-epss" mapstructure:"min-epss"`
+	FailOn                       DependencyFailOn    `yaml:"fail-on" json:"fail-on" mapstructure:"fail-on"`
+	DetectToolchain              bool                `yaml:"detect-toolchain" json:"detect-toolchain" mapstructure:"detect-toolchain"`
+	DetectBaseImages             bool                `yaml:"detect-base-images" json:"detect-base-images" mapstructure:"detect-base-images"`
+	DetectGithubActions          bool                `yaml:"detect-github-actions" json:"detect-github-actions" mapstructure:"detect-github-actions"`
+	AttributePullRequests        bool                `yaml:"attribute-pull-requests" json:"attribute-pull-requests" mapstructure:"attribute-pull-requests"`
+	Timeline                     bool                `yaml:"timeline" json:"timeline" mapstructure:"timeline"`
+	GoMod                        DependencyGoMod     `yaml:"go-mod" json:"go-mod" mapstructure:"go-mod"`
+	Actions                      DependencyActions   `yaml:"actions" json:"actions" mapstructure:"actions"`
+	TransitiveActions            DependencyActions   `yaml:"transitive-actions" json:"transitive-actions" mapstructure:"transitive-actions"`
+	SBOM                         DependencySBOM      `yaml:"sbom" json:"sbom" mapstructure:"sbom"`
+	SBOMInput                    DependencySBOMInput `yaml:"sbom-input" json:"sbom-input" mapstructure:"sbom-input"`
+	Image                        DependencyImage     `yaml:"image" json:"image" mapstructure:"image"`
+	Licenses                     DependencyLicenses  `yaml:"licenses" json:"licenses" mapstructure:"licenses"`
+	Cache                        DependencyCache     `yaml:"cache" json:"cache" mapstructure:"cache"`
+	VEXInput                     DependencyVEXInput  `yaml:"vex-input" json:"vex-input" mapstructure:"vex-input"`
+}
+
+// DependencySBOMInput reads each endpoint's packages from an SBOM the build
+// already produced instead of scanning the source tree: an explicit file per
+// endpoint, or a path template expanded with the endpoint's ref.
+type DependencySBOMInput struct {
+	Since    string `yaml:"since" json:"since" mapstructure:"since"`
+	Until    string `yaml:"until" json:"until" mapstructure:"until"`
+	Template string `yaml:"template" json:"template" mapstructure:"template"`
+}
+
+// Requested reports whether any SBOM input was configured.
+func (c DependencySBOMInput) Requested() bool {
+	return c.Since != "" || c.Until != "" || c.Template != ""
+}
+
+// Check validates that both endpoints resolve to a file: each needs an explicit
+// path unless a template covers it.
+func (c DependencySBOMInput) Check() error {
+	if !c.Requested() || c.Template != "" {
+		return nil
+	}
+	if c.Since == "" || c.Until == "" {
+		return errors.New("sbom-input needs a file for both endpoints: set both since and until, or a template")
+	}
+	return nil
+}
+
+// Input returns the scanner's view of the configuration, keyed by the refs the
+// endpoints resolved to.
+func (c DependencySBOMInput) Input(sinceRef, untilRef string) scan.SBOMInput {
+	in := scan.SBOMInput{Paths: map[string]string{}, Template: c.Template}
+	if c.Since != "" {
+		in.Paths[sinceRef] = c.Since
+	}
+	if c.Until != "" {
+		in.Paths[untilRef] = c.Until
+	}
+	return in
+}
+
+// DependencyImage catalogs the container image each endpoint was released as
+// instead of scanning the source tree: an explicit image per endpoint, or a
+// template expanded with the endpoint's ref and release version.
+type DependencyImage struct {
+	Since    string `yaml:"since" json:"since" mapstructure:"since"`
+	Until    string `yaml:"until" json:"until" mapstructure:"until"`
+	Template string `yaml:"template" json:"template" mapstructure:"template"`
+}
+
+// Requested reports whether any image was configured.
+func (c DependencyImage) Requested() bool {
+	return c.Since != "" || c.Until != "" || c.Template != ""
+}
+
+// Check validates that both endpoints resolve to an image: each needs an
+// explicit one unless a template covers it.
+func (c DependencyImage) Check() error {
+	if !c.Requested() || c.Template != "" {
+		return nil
+	}
+	if c.Since == "" || c.Until == "" {
+		return errors.New("image needs an image for both endpoints: set both since and until, or a template")
+	}
+	return nil
+}
+
+// Input returns the scanner's view of the configuration, keyed by the refs the
+// endpoints resolved to. The versions fill the template's {version}
+// placeholder; a leading "v" is dropped, as image tags rarely carry one.
+func (c DependencyImage) Input(sinceRef, sinceVersion, untilRef, untilVersion string) scan.ImageInput {
+	in := scan.ImageInput{Images: map[string]string{}, Template: c.Template, Versions: map[string]string{}}
+	if c.Since != "" {
+		in.Images[sinceRef] = c.Since
+	}
+	if c.Until != "" {
+		in.Images[untilRef] = c.Until
+	}
+	if v := strings.TrimPrefix(sinceVersion, "v"); v != "" {
+		in.Versions[sinceRef] = v
+	}
+	if v := strings.TrimPrefix(untilVersion, "v"); v != "" {
+		in.Versions[untilRef] = v
+	}
+	return in
+}
+
+// DependencyLicenses is the license policy checked against the dependency diff:
+// the licenses the project does not accept, and whether bringing one in should
+// fail the run rather than only warn.
+type DependencyLicenses struct {
+	Deny         []string `yaml:"deny" json:"deny" mapstructure:"deny"`
+	FailOnDenied bool     `yaml:"fail-on-denied" json:"fail-on-denied" mapstructure:"fail-on-denied"`
+}
+
+// DependencyGoMod selects the go.mod directives reported in the Go modules
+// rollup. They only apply when a module is built as the main module, so each is
+// a change a consumer of the module doesn't get.
+type DependencyGoMod struct {
+	Replace   bool `yaml:"replace" json:"replace" mapstructure:"replace"`
+	Retract   bool `yaml:"retract" json:"retract" mapstructure:"retract"`
+	Godebug   bool `yaml:"godebug" json:"godebug" mapstructure:"godebug"`
+	Toolchain bool `yaml:"toolchain" json:"toolchain" mapstructure:"toolchain"`
+}
+
+// DependencyFailOn is the release gate on the vulnerabilities a release
+// introduces: when a change introduces one it forbids, the run exits with a
+// distinct non-zero code once the changelog is written.
+type DependencyFailOn struct {
+	IntroducedSeverity string `yaml:"introduced-severity" json:"introduced-severity" mapstructure:"introduced-severity"`
+	KEV                bool   `yaml:"kev" json:"kev" mapstructure:"kev"`
+}
+
+// DependencyVulnDB chooses the exact vulnerability DB annotation matches
+// against: one imported from an archive (for builds that can't reach grype's
+// listing), and/or a pinned build the loaded DB must be.
+type DependencyVulnDB struct {
+	Import string `yaml:"import" json:"import" mapstructure:"import"`
+	Pin    string `yaml:"pin" json:"pin" mapstructure:"pin"`
+}
+
+// Requested reports whether the run asked for a specific DB, which makes a DB
+// that can't be loaded an error rather than a reason to skip annotation.
+func (c DependencyVulnDB) Requested() bool {
+	return c.Import != "" || c.Pin != ""
+}
+
+func (c DependencyVulnDB) Check() error {
+	if c.Pin == "" {
+		return nil
+	}
+	if _, err := time.Parse(time.RFC3339, c.Pin); err != nil {
+		return fmt.Errorf("vulnerability-db.pin must be the DB's build time in RFC 3339 (e.g. 2025-01-15T01:31:22Z): %w", err)
+	}
+	return nil
+}
+
+// Config returns what the DB load should use. update is the
+// update-vulnerability-db decision for grype's shared DB; a pinned build is
+// never downloaded over, since the latest build is almost never the pinned one.
+func (c DependencyVulnDB) Config(update bool) scan.DBConfig {
+	cfg := scan.DBConfig{Update: update && c.Pin == "", Import: c.Import}
+	// Check has already vetted the format.
+	cfg.Pin, _ = time.Parse(time.RFC3339, c.Pin)
+	return cfg
+}
+
+// DependencyCache controls the on-disk cache of source scans, which lets a run
+// skip cataloging a ref whose tree was scanned before (usually the previous
+// release).
+type DependencyCache struct {
+	Enabled bool   `yaml:"enabled" json:"enabled" mapstructure:"enabled"`
+	Dir     string `yaml:"dir" json:"dir" mapstructure:"dir"`
+}
+
+// Cache returns the scan cache to use, or nil when caching is disabled or no
+// directory can be found for it.
+func (c DependencyCache) Cache() (*scan.Cache, error) {
+	if !c.Enabled {
+		return nil, nil
+	}
+	dir := c.Dir
+	if dir == "" {
+		var err error
+		if dir, err = scan.DefaultCacheDir(); err != nil {
+			return nil, err
+		}
+	}
+	return scan.NewCache(dir), nil
+}
+
+// DependencyVEXInput names the OpenVEX documents whose not_affected statements
+// suppress vulnerability annotations: explicit files, plus the documents
+// discovered in the repository at the until ref.
+type DependencyVEXInput struct {
+	Documents []string `yaml:"documents" json:"documents" mapstructure:"documents"`
+	Discover  bool     `yaml:"discover" json:"discover" mapstructure:"discover"`
+	Paths     []string `yaml:"paths" json:"paths" mapstructure:"paths"`
+}
+
+// DiscoveryPaths returns the globs discovery searches, defaulting to the vex
+// package's.
+func (c DependencyVEXInput) DiscoveryPaths() []string {
+	if len(c.Paths) > 0 {
+		return c.Paths
+	}
+	return vex.DefaultPaths()
+}
+
+// DependencySBOM requests the SBOMs the dependency scan catalogs be written out,
+// as FORMAT=PATH entries per changelog endpoint.
+type DependencySBOM struct {
+	Since []string `yaml:"since" json:"since" mapstructure:"since"`
+	Until []string `yaml:"until" json:"until" mapstructure:"until"`
 }
 
 // DependencyActions sets how each kind of dependency change is displayed. Each
@@ -49,23 +265,93 @@ func (c Dependencies) CleanedEcosystems() []string {
 	return out
 }
 
+// Exports parses the requested SBOM outputs, keyed by the ref each endpoint
+// resolved to, for the scanner. It returns nil when none were requested.
+func (c DependencySBOM) Exports(sinceRef, untilRef string) (map[string][]scan.SBOMExport, error) {
+	if !c.Requested() {
+		return nil, nil
+	}
+	out := map[string][]scan.SBOMExport{}
+	add := func(ref string, values []string) error {
+		for _, v := range values {
+			e, err := scan.ParseSBOMExport(v)
+			if err != nil {
+				return err
+			}
+			out[ref] = append(out[ref], e)
+		}
+		return nil
+	}
+	if err := add(sinceRef, c.Since); err != nil {
+		return nil, err
+	}
+	if err := add(untilRef, c.Until); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Requested reports whether any SBOM output was configured.
+func (c DependencySBOM) Requested() bool {
+	return len(c.Since) > 0 || len(c.Until) > 0
+}
+
+// Check validates every configured SBOM output without writing anything.
+func (c DependencySBOM) Check() error {
+	_, err := c.Exports("since", "until")
+	return err
+}
+
 // Enabled reports whether the dependency diff will run — i.e. at least one
-// ecosystem was requested.
+// ecosystem was requested to scan, or SBOMs or images were given to read
+// instead.
 func (c Dependencies) Enabled() bool {
-	return len(c.CleanedEcosystems()) > 0
+	return len(c.CleanedEcosystems()) > 0 || c.SBOMInput.Requested() || c.Image.Requested()
+}
+
+// Check validates the dependency sources: at most one of the SBOM input and
+// the image may replace the source scan, and whichever is set must cover both
+// endpoints.
+func (c Dependencies) Check() error {
+	if c.SBOMInput.Requested() && c.Image.Requested() {
+		return errors.New("sbom-input and image are mutually exclusive; choose one source for the dependency diff")
+	}
+	if err := c.SBOMInput.Check(); err != nil {
+		return err
+	}
+	if err := c.VulnerabilityDB.Check(); err != nil {
+		return err
+	}
+	return c.Image.Check()
 }
 
 func (c *Dependencies) DescribeFields(descriptions clio.FieldDescriptionSet) {
 	descriptions.Add(&c.Ecosystems, "ecosystems to scan (syft cataloger selection, e.g. language, go, python); 'auto' detects ecosystems from root manifests, 'none' disables (wins over all); enables the feature when set")
 	descriptions.Add(&c.Exclude, "paths to exclude from dependency scanning (syft exclude patterns; each must start with ./, */, or **/, e.g. ./vendor, **/testdata)")
 	descriptions.Add(&c.Recursive, "recurse into subdirectories when scanning for dependencies and toolchain manifests; when false (default) only the repository root is scanned")
+	descriptions.Add(&c.Workspaces, "scan exactly the members a go.work, npm/yarn/pnpm or Cargo workspace declares at each ref (plus the root), in place of the recursive setting, and group dependency changes per member; repositories without a workspace are unaffected")
 	descriptions.Add(&c.AnnotateVulnerabilities, "annotate dependency changes with known vulnerability information")
 	descriptions.Add(&c.UpdateVulnerabilityDB, "download the latest grype vulnerability DB when the installed one is missing or older than 5 days; when disabled, use whatever DB is installed (warning if it is stale) and skip vulnerability annotation entirely if none is usable")
+	descriptions.Add(&c.VulnerabilityDB, "use a specific vulnerability DB: import one from an archive instead of grype's shared cache, and/or pin the DB build annotations must be matched against")
 	descriptions.Add(&c.OnlyVulnerable, "only show dependency changes that remediated or introduced a vulnerability (requires annotate-vulnerabilities)")
 	descriptions.Add(&c.ShowRemainingVulnerabilities, "show the remaining (carried-over) vulnerabilities still present in the latest scan that this release did not remediate, as a rollup (requires annotate-vulnerabilities)")
 	descriptions.Add(&c.MinSeverity, "minimum vulnerability severity to include in annotations (e.g. low, medium, high, critical)")
+	descriptions.Add(&c.MinEPSS, "minimum EPSS score (0-1, the probability of exploitation in the next 30 days) to include in annotations; known-exploited (KEV) vulnerabilities are always included")
 	descriptions.Add(&c.DetectToolchain, "detect declared toolchain minimum-version changes (e.g. the go directive in go.mod) for the activated ecosystems, shown as a Toolchains rollup under Dependencies")
+	descriptions.Add(&c.DetectBaseImages, "detect container base-image changes (FROM lines in Dockerfiles and Containerfiles), shown as a Base images rollup under Dependencies")
+	descriptions.Add(&c.AttributePullRequests, "credit each dependency change to the merged pull requests whose manifest or lockfile edits made it, linked next to its version transition")
+	descriptions.Add(&c.Timeline, "diff the lockfiles and manifests each commit in the release edited and show the resulting dependency events on those commits in the trunk output")
+	descriptions.Add(&c.GoMod, "report go.mod directive changes beyond requirements (replace, retract, godebug, toolchain), shown as a Go modules rollup under Dependencies for the activated Go ecosystem")
+	descriptions.Add(&c.DetectGithubActions, "detect changes to the GitHub Actions referenced by workflow `uses:` lines (including moves to and from commit-SHA pins), shown as a GitHub Actions rollup under Dependencies; newly unpinned references are logged as warnings")
+	descriptions.Add(&c.SBOMInput, "read each endpoint's packages from an SBOM file (SPDX, CycloneDX, or syft JSON) instead of scanning the source tree; enables the feature when set")
+	descriptions.Add(&c.Image, "catalog the container image each endpoint was released as (registry reference, OCI layout directory, or image archive) instead of scanning the source tree; enables the feature when set")
+	descriptions.Add(&c.FailOn, "fail the run (after writing the changelog) when a dependency change introduces a forbidden vulnerability (requires annotate-vulnerabilities)")
+	descriptions.Add(&c.Licenses, "license policy for dependency changes; added or updated packages that bring in a denied license are reported in a License policy warning")
+	descriptions.Add(&c.VEXInput, "OpenVEX documents whose not_affected statements keep vulnerabilities out of the introduced and remaining annotations (requires annotate-vulnerabilities)")
+	descriptions.Add(&c.Cache, "cache source scans by git tree, so a ref whose content was scanned before (typically the previous release) is not cataloged again")
+	descriptions.Add(&c.SBOM, "write the SBOM cataloged for each changelog endpoint (FORMAT=PATH entries; formats: "+strings.Join(scan.SBOMFormats(), ", ")+")")
 	descriptions.Add(&c.Actions, "how each change kind is displayed: hide, summary (count only), list (bullet list), or collapsed (bullet list in a <details> block)")
+	descriptions.Add(&c.TransitiveActions, "how transitive dependency changes of each kind are displayed, split out from the direct ones (same modes as actions); a kind left empty keeps its direct and transitive changes together")
 }
 
 var _ clio.FieldDescriber = (*Dependencies)(nil)
@@ -80,6 +366,84 @@ func (c *DependencyActions) DescribeFields(descriptions clio.FieldDescriptionSet
 
 var _ clio.FieldDescriber = (*DependencyActions)(nil)
 
+func (c *DependencySBOM) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&c.Since, "SBOM outputs for the since ref (the previous release), e.g. spdx-json=since.spdx.json")
+	descriptions.Add(&c.Until, "SBOM outputs for the until ref (this release), e.g. cyclonedx-json=sbom.cdx.json")
+}
+
+var _ clio.FieldDescriber = (*DependencySBOM)(nil)
+
+func (c *DependencySBOMInput) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&c.Since, "SBOM file for the since ref (the previous release)")
+	descriptions.Add(&c.Until, "SBOM file for the until ref (this release)")
+	descriptions.Add(&c.Template, "SBOM file path for any endpoint without an explicit file, with {ref} replaced by the endpoint's ref (e.g. sboms/{ref}.spdx.json)")
+}
+
+var _ clio.FieldDescriber = (*DependencySBOMInput)(nil)
+
+func (c *DependencyImage) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&c.Since, "image for the since ref (the previous release)")
+	descriptions.Add(&c.Until, "image for the until ref (this release)")
+	descriptions.Add(&c.Template, "image for any endpoint without an explicit one, with {ref} replaced by the endpoint's ref and {version} by its release version without a leading v (e.g. ghcr.io/org/app:{version})")
+}
+
+var _ clio.FieldDescriber = (*DependencyImage)(nil)
+
+func (c *DependencyLicenses) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&c.Deny, "SPDX license IDs not accepted in dependencies, matched case-insensitively; an ID also covers its versions and variants (e.g. AGPL covers AGPL-3.0-only)")
+	descriptions.Add(&c.FailOnDenied, "exit non-zero when a dependency change brings in a denied license (the changelog is still written)")
+}
+
+var _ clio.FieldDescriber = (*DependencyLicenses)(nil)
+
+func (c *DependencyGoMod) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&c.Replace, "report replace directives that were added, removed or retargeted, marking replaces that point at a local directory")
+	descriptions.Add(&c.Retract, "report retract statements newly added for the module's own versions")
+	descriptions.Add(&c.Godebug, "report godebug settings that were added, removed or changed")
+	descriptions.Add(&c.Toolchain, "report changes to the toolchain directive (the pinned toolchain, as opposed to the go directive's minimum version)")
+}
+
+var _ clio.FieldDescriber = (*DependencyGoMod)(nil)
+
+func (c *DependencyFailOn) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&c.IntroducedSeverity, "fail when an introduced vulnerability is at least this severe (negligible, low, medium, high, critical); empty disables the check")
+	descriptions.Add(&c.KEV, "fail when an introduced vulnerability is listed by CISA as known exploited, whatever its severity")
+}
+
+var _ clio.FieldDescriber = (*DependencyFailOn)(nil)
+
+func (c *DependencyVEXInput) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&c.Documents, "OpenVEX document files to apply")
+	descriptions.Add(&c.Discover, "also apply the OpenVEX documents found in the repository at the until ref, outside vendored, test and excluded paths")
+	descriptions.Add(&c.Paths, "globs discovery searches (default: **/*.openvex.json and .openvex/**/*.json)")
+}
+
+var _ clio.FieldDescriber = (*DependencyVEXInput)(nil)
+
+func (c *DependencyCache) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&c.Enabled, "reuse cached catalogs (keyed by tree hash and scan scope) and vulnerability matches (also keyed by the grype DB build)")
+	descriptions.Add(&c.Dir, "directory for the scan cache; defaults to chronicle/dependency-scans under the user cache dir")
+}
+
+var _ clio.FieldDescriber = (*DependencyCache)(nil)
+
+func (c *DependencyVulnDB) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&c.Import, "grype DB archive (.tar.zst, .tar.gz), raw .db file, or URL to import into chronicle's own DB directory and match against instead of grype's shared cache (same as --vulnerability-db); failing to import it fails the run")
+	descriptions.Add(&c.Pin, "the build time (RFC 3339) the vulnerability DB must have, as recorded in the JSON output; any other build fails the run, and no DB update is downloaded")
+}
+
+var _ clio.FieldDescriber = (*DependencyVulnDB)(nil)
+
+// Policy returns the license policy the dependency diff checks.
+func (c DependencyLicenses) Policy() dependency.LicensePolicy {
+	return dependency.LicensePolicy{Deny: c.Deny}
+}
+
+// Gate returns the vulnerability gate the run checks once the changelog is out.
+func (c DependencyFailOn) Gate() dependency.VulnGate {
+	return dependency.VulnGate{IntroducedSeverity: c.IntroducedSeverity, KnownExploited: c.KEV}
+}
+
 // DefaultDependencies returns the default configuration for dependency scanning.
 // Ecosystems is empty (feature off); when enabled, "language" is the
 // recommended value. Every change kind defaults to collapsed — a count that
@@ -91,7 +455,10 @@ func DefaultDependencies() Dependencies {
 		Exclude:    nil,
 		// scan only the repository root by default; subdir manifests (e.g. a
 		// vendored or tooling .make/go.mod) would otherwise leak into the diff.
-		Recursive:               false,
+		Recursive: false,
+		// a declared workspace says exactly which subdirectories are the
+		// project's, so follow it; without one this changes nothing.
+		Workspaces:              true,
 		AnnotateVulnerabilities: false,
 		// updates ride on annotation; on by default so a stale/missing DB is refreshed
 		// without extra flags. Disable to use whatever DB is installed (warn if stale).
@@ -99,9 +466,28 @@ func DefaultDependencies() Dependencies {
 		OnlyVulnerable:               false,
 		ShowRemainingVulnerabilities: false,
 		MinSeverity:                  "",
+		MinEPSS:                      0,
 		// toolchain detection rides on the dependencies feature for the activated
 		// ecosystems; on by default so a go-directive bump surfaces without extra flags.
 		DetectToolchain: true,
+		// base images are read from Dockerfiles at the same two refs; a base-image
+		// bump is often what clears OS-level CVEs the source scan can't see.
+		DetectBaseImages: true,
+		// workflow actions run with the repository's credentials, so how they are
+		// pinned is worth a line in the changelog.
+		DetectGithubActions: true,
+		// replaces and retractions change what consumers get without touching a
+		// requirement; the pinned toolchain is mostly CI housekeeping, so its
+		// line is opt-in.
+		GoMod: DependencyGoMod{Replace: true, Retract: true, Godebug: true},
+		// reading the release's merge commits is cheap next to the scan.
+		AttributePullRequests: true,
+		// the previous release's tree never changes, so re-cataloging it on every
+		// run is wasted work.
+		Cache: DependencyCache{Enabled: true},
+		// statements the project committed about its own exposure are meant to
+		// be honored wherever it is reported.
+		VEXInput: DependencyVEXInput{Discover: true},
 		Actions: DependencyActions{
 			Updated:    "collapsed,list",
 			Downgraded: "collapsed,list",
@@ -2,8 +2,14 @@ package options
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/chronicle/chronicle/dependency"
+	"github.com/anchore/chronicle/chronicle/dependency/scan"
+	"github.com/anchore/chronicle/chronicle/dependency/vex"
 )
 
 func TestDefaultDependencies_RootOnlyByDefault(t *testing.T) {
@@ -11,3 +17,103 @@ func TestDefaultDependencies_RootOnlyByDefault(t *testing.T) {
 	// subdirs (e.g. a tooling .make/go.mod) is opt-in via recursive: true.
 	assert.False(t, DefaultDependencies().Recursive)
 }
+
+func TestDefaultDependencies_FollowsWorkspaces(t *testing.T) {
+	// a declared workspace names the project's own modules, so it is followed by
+	// default; repositories without one still scan by recursive.
+	assert.True(t, DefaultDependencies().Workspaces)
+}
+
+func TestDependencySBOM_Exports(t *testing.T) {
+	none, err := DependencySBOM{}.Exports("v1.0.0", "HEAD")
+	require.NoError(t, err)
+	assert.Nil(t, none)
+
+	c := DependencySBOM{
+		Since: []string{"spdx-json=since.spdx.json"},
+		Until: []string{"spdx-json=sbom.spdx.json", "cyclonedx-json=sbom.cdx.json"},
+	}
+	got, err := c.Exports("v1.0.0", "HEAD")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]scan.SBOMExport{
+		"v1.0.0": {{Format: "spdx-json", Path: "since.spdx.json"}},
+		"HEAD":   {{Format: "spdx-json", Path: "sbom.spdx.json"}, {Format: "cyclonedx-json", Path: "sbom.cdx.json"}},
+	}, got)
+
+	// both endpoints on the same ref keep every export
+	got, err = c.Exports("HEAD", "HEAD")
+	require.NoError(t, err)
+	assert.Len(t, got["HEAD"], 3)
+
+	assert.ErrorContains(t, DependencySBOM{Until: []string{"spdx=sbom.json"}}.Check(), "unsupported sbom format")
+}
+
+func TestDependencySBOMInput(t *testing.T) {
+	assert.False(t, DependencySBOMInput{}.Requested())
+	assert.NoError(t, DependencySBOMInput{}.Check())
+	assert.NoError(t, DependencySBOMInput{Template: "sboms/{ref}.spdx.json"}.Check())
+	assert.NoError(t, DependencySBOMInput{Since: "a.json", Until: "b.json"}.Check())
+	assert.ErrorContains(t, DependencySBOMInput{Until: "b.json"}.Check(), "both endpoints")
+
+	assert.True(t, Dependencies{SBOMInput: DependencySBOMInput{Until: "b.json"}}.Enabled(), "sbom input enables the feature without an ecosystem")
+
+	c := DependencySBOMInput{Until: "build/sbom.cdx.json", Template: "sboms/{ref}.spdx.json"}
+	assert.Equal(t, scan.SBOMInput{
+		Paths:    map[string]string{"HEAD": "build/sbom.cdx.json"},
+		Template: "sboms/{ref}.spdx.json",
+	}, c.Input("v1.0.0", "HEAD"))
+}
+
+func TestDependencyImage(t *testing.T) {
+	assert.NoError(t, DependencyImage{Template: "ghcr.io/org/app:{version}"}.Check())
+	assert.ErrorContains(t, DependencyImage{Since: "app:1.0.0"}.Check(), "both endpoints")
+	assert.True(t, Dependencies{Image: DependencyImage{Template: "app:{version}"}}.Enabled(), "an image enables the feature without an ecosystem")
+	assert.ErrorContains(t, Dependencies{
+		Image:     DependencyImage{Template: "app:{version}"},
+		SBOMInput: DependencySBOMInput{Template: "sboms/{ref}.spdx.json"},
+	}.Check(), "mutually exclusive")
+
+	c := DependencyImage{Until: "oci-dir:build/image", Template: "ghcr.io/org/app:{version}"}
+	assert.Equal(t, scan.ImageInput{
+		Images:   map[string]string{"HEAD": "oci-dir:build/image"},
+		Template: "ghcr.io/org/app:{version}",
+		Versions: map[string]string{"v1.0.0": "1.0.0", "HEAD": "1.1.0"},
+	}, c.Input("v1.0.0", "v1.0.0", "HEAD", "v1.1.0"))
+}
+
+func TestDependencyCache(t *testing.T) {
+	assert.True(t, DefaultDependencies().Cache.Enabled, "scans are cached by default")
+
+	c, err := DependencyCache{}.Cache()
+	require.NoError(t, err)
+	assert.Nil(t, c, "a disabled cache is nil")
+
+	c, err = DependencyCache{Enabled: true, Dir: t.TempDir()}.Cache()
+	require.NoError(t, err)
+	assert.NotNil(t, c)
+}
+
+func TestDependencyVEXInput(t *testing.T) {
+	assert.True(t, DefaultDependencies().VEXInput.Discover, "committed vex documents are honored by default")
+	assert.Equal(t, vex.DefaultPaths(), DependencyVEXInput{}.DiscoveryPaths())
+	assert.Equal(t, []string{"sec/*.json"}, DependencyVEXInput{Paths: []string{"sec/*.json"}}.DiscoveryPaths())
+}
+
+func TestDependencyFailOn_Gate(t *testing.T) {
+	assert.False(t, DefaultDependencies().FailOn.Gate().Enabled(), "no release is gated by default")
+	assert.Equal(t, dependency.VulnGate{IntroducedSeverity: "high", KnownExploited: true}, DependencyFailOn{IntroducedSeverity: "high", KEV: true}.Gate())
+}
+
+func TestDependencyVulnDB(t *testing.T) {
+	assert.False(t, DependencyVulnDB{}.Requested())
+	require.NoError(t, DependencyVulnDB{}.Check())
+	require.Error(t, DependencyVulnDB{Pin: "2025-01-15"}.Check(), "a pin is a full build time")
+
+	assert.Equal(t, scan.DBConfig{Update: true}, DependencyVulnDB{}.Config(true))
+	assert.Equal(t, scan.DBConfig{Import: "vulndb.tar.zst"}, DependencyVulnDB{Import: "vulndb.tar.zst"}.Config(false))
+
+	pinned := DependencyVulnDB{Pin: "2025-01-15T01:31:22Z"}
+	require.NoError(t, pinned.Check())
+	assert.True(t, pinned.Requested())
+	assert.Equal(t, scan.DBConfig{Pin: time.Date(2025, 1, 15, 1, 31, 22, 0, time.UTC)}, pinned.Config(true), "a pinned DB is never updated over")
+}
@@ -4,24 +4,26 @@ import (
 	"strings"
 
 	"github.com/anchore/chronicle/chronicle/release/change"
+	"github.com/anchore/chronicle/chronicle/release/releasenote"
 	"github.com/anchore/chronicle/chronicle/release/releasers/github"
 	"github.com/anchore/clio"
 )
 
 type GithubSummarizer struct {
-	Host                            string         `yaml:"host" json:"host" mapstructure:"host"`
-	ExcludeLabels                   []string       `yaml:"exclude-labels" json:"exclude-labels" mapstructure:"exclude-labels"`
-	IncludeIssuePRAuthors           bool           `yaml:"include-issue-pr-authors" json:"include-issue-pr-authors" mapstructure:"include-issue-pr-authors"`
-	IncludeIssuePRs                 bool           `yaml:"include-issue-prs" json:"include-issue-prs" mapstructure:"include-issue-prs"`
-	IncludeIssuesClosedAsNotPlanned bool           `yaml:"include-issues-not-planned" json:"include-issues-not-planned" mapstructure:"include-issues-not-planned"`
-	IncludePRs                      bool           `yaml:"include-prs" json:"include-prs" mapstructure:"include-prs"`
-	IncludeIssues                   bool           `yaml:"include-issues" json:"include-issues" mapstructure:"include-issues"`
-	IncludeUnlabeledIssues          bool           `yaml:"include-unlabeled-issues" json:"include-unlabeled-issues" mapstructure:"include-unlabeled-issues"`
-	IncludeUnlabeledPRs             bool           `yaml:"include-unlabeled-prs" json:"include-unlabeled-prs" mapstructure:"include-unlabeled-prs"`
-	IssuesRequireLinkedPR           bool           `yaml:"issues-require-linked-prs" json:"issues-require-linked-prs" mapstructure:"issues-require-linked-prs"`
-	ConsiderPRMergeCommits          bool           `yaml:"consider-pr-merge-commits" json:"consider-pr-merge-commits" mapstructure:"consider-pr-merge-commits"`
-	InferChangeTypeFromTitle        bool           `yaml:"infer-change-type-from-title" json:"infer-change-type-from-title" mapstructure:"infer-change-type-from-title"`
-	Changes                         []GithubChange `yaml:"changes" json:"changes" mapstructure:"changes"`
+	Host                            string             `yaml:"host" json:"host" mapstructure:"host"`
+	ExcludeLabels                   []string           `yaml:"exclude-labels" json:"exclude-labels" mapstructure:"exclude-labels"`
+	IncludeIssuePRAuthors           bool               `yaml:"include-issue-pr-authors" json:"include-issue-pr-authors" mapstructure:"include-issue-pr-authors"`
+	IncludeIssuePRs                 bool               `yaml:"include-issue-prs" json:"include-issue-prs" mapstructure:"include-issue-prs"`
+	IncludeIssuesClosedAsNotPlanned bool               `yaml:"include-issues-not-planned" json:"include-issues-not-planned" mapstructure:"include-issues-not-planned"`
+	IncludePRs                      bool               `yaml:"include-prs" json:"include-prs" mapstructure:"include-prs"`
+	IncludeIssues                   bool               `yaml:"include-issues" json:"include-issues" mapstructure:"include-issues"`
+	IncludeUnlabeledIssues          bool               `yaml:"include-unlabeled-issues" json:"include-unlabeled-issues" mapstructure:"include-unlabeled-issues"`
+	IncludeUnlabeledPRs             bool               `yaml:"include-unlabeled-prs" json:"include-unlabeled-prs" mapstructure:"include-unlabeled-prs"`
+	IssuesRequireLinkedPR           bool               `yaml:"issues-require-linked-prs" json:"issues-require-linked-prs" mapstructure:"issues-require-linked-prs"`
+	ConsiderPRMergeCommits          bool               `yaml:"consider-pr-merge-commits" json:"consider-pr-merge-commits" mapstructure:"consider-pr-merge-commits"`
+	InferChangeTypeFromTitle        bool               `yaml:"infer-change-type-from-title" json:"infer-change-type-from-title" mapstructure:"infer-change-type-from-title"`
+	ReleaseNotes                    GithubReleaseNotes `yaml:"release-notes" json:"release-notes" mapstructure:"release-notes"`
+	Changes                         []GithubChange     `yaml:"changes" json:"changes" mapstructure:"changes"`
 }
 
 func (c *GithubSummarizer) DescribeFields(descriptions clio.FieldDescriptionSet) {
@@ -42,6 +44,30 @@ func (c *GithubSummarizer) DescribeFields(descriptions clio.FieldDescriptionSet)
 
 var _ clio.FieldDescriber = (*GithubSummarizer)(nil)
 
+// GithubReleaseNotes configures reading a release note from each PR body
+// (what a "### Release note" template section asks for) to use in place of
+// the PR title.
+type GithubReleaseNotes struct {
+	Enabled  bool     `yaml:"enabled" json:"enabled" mapstructure:"enabled"`
+	Fence    string   `yaml:"fence" json:"fence" mapstructure:"fence"`
+	Headings []string `yaml:"headings" json:"headings" mapstructure:"headings"`
+}
+
+func (c *GithubReleaseNotes) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&c.Enabled, "use the release note written in a PR body in place of the PR title; a note of NONE excludes the PR from the changelog")
+	descriptions.Add(&c.Fence, "info string of a fenced code block holding the note (e.g. ```release-note); empty skips fenced blocks")
+	descriptions.Add(&c.Headings, "markdown headings whose section holds the note (case-insensitive), tried after the fenced block")
+}
+
+var _ clio.FieldDescriber = (*GithubReleaseNotes)(nil)
+
+func (c GithubReleaseNotes) extractor() releasenote.Extractor {
+	if !c.Enabled {
+		return releasenote.Extractor{}
+	}
+	return releasenote.Extractor{Fence: strings.TrimSpace(c.Fence), Headings: c.Headings}
+}
+
 type GithubChange struct {
 	Type       string   `yaml:"name" json:"name" mapstructure:"name"`
 	Title      string   `yaml:"title" json:"title" mapstructure:"title"`
@@ -90,6 +116,7 @@ func (c GithubSummarizer) ToGithubConfig() github.Config {
 		InferChangeTypeFromTitle:            c.InferChangeTypeFromTitle,
 		ChangeTypesByLabel:                  typeSet,
 		ChangeTypesByConventionalCommitType: prefixSet,
+		ReleaseNotes:                        c.ReleaseNotes.extractor(),
 	}
 }
 
@@ -106,7 +133,14 @@ func DefaultGithubSimmarizer() GithubSummarizer {
 		IncludeIssuesClosedAsNotPlanned: false,
 		IncludeUnlabeledIssues:          true,
 		IncludeUnlabeledPRs:             true,
-		ExcludeLabels:                   []string{"duplicate", "question", "invalid", "wontfix", "wont-fix", "release-ignore", "changelog-ignore", "ignore"},
+		ReleaseNotes: GithubReleaseNotes{
+			// opt-in: it changes the text of every PR whose template has the
+			// section, and a NONE drops the PR outright.
+			Enabled:  false,
+			Fence:    "release-note",
+			Headings: []string{"Release note", "Release notes"},
+		},
+		ExcludeLabels: []string{"duplicate", "question", "invalid", "wontfix", "wont-fix", "release-ignore", "changelog-ignore", "ignore"},
 		Changes: []GithubChange{
 			{
 				Type:       "security-fixes",
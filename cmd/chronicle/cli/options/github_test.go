@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/anchore/chronicle/chronicle/release/change"
+	"github.com/anchore/chronicle/chronicle/release/releasenote"
 )
 
 func TestGithubSummarizer_ToGithubConfig_prefixes(t *testing.T) {
@@ -43,3 +44,16 @@ func TestGithubSummarizer_ToGithubConfig_prefixes(t *testing.T) {
 	}
 	assert.Equal(t, want, cfg.ChangeTypesByConventionalCommitType)
 }
+
+func TestGithubSummarizer_ToGithubConfig_releaseNotes(t *testing.T) {
+	summarizer := DefaultGithubSimmarizer()
+
+	// off by default: titles are left alone.
+	assert.Equal(t, releasenote.Extractor{}, summarizer.ToGithubConfig().ReleaseNotes)
+
+	summarizer.ReleaseNotes.Enabled = true
+	assert.Equal(t, releasenote.Extractor{
+		Fence:    "release-note",
+		Headings: []string{"Release note", "Release notes"},
+	}, summarizer.ToGithubConfig().ReleaseNotes)
+}
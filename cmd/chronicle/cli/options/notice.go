@@ -0,0 +1,55 @@
+package options
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/anchore/chronicle/chronicle/release/notice"
+	"github.com/anchore/clio"
+)
+
+// Notice configures where the release's notice (the highlight paragraph above
+// the changes) is read from.
+type Notice struct {
+	Sources  []string `yaml:"sources" json:"sources" mapstructure:"sources"`
+	NotesDir string   `yaml:"notes-dir" json:"notes-dir" mapstructure:"notes-dir"`
+	PRLabel  string   `yaml:"pr-label" json:"pr-label" mapstructure:"pr-label"`
+}
+
+var _ clio.FieldDescriber = (*Notice)(nil)
+
+func (c *Notice) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&c.Sources, "where to look for the release notice, in order; the first that has one wins: 'tag' (the annotated until tag's message), 'file' (<notes-dir>/<version>.md at the until ref), 'pr' (a \"Release notes\" section in the body of a PR labelled pr-label); empty disables the notice")
+	descriptions.Add(&c.NotesDir, "directory holding per-release notes files named after the version (e.g. v1.2.0.md)")
+	descriptions.Add(&c.PRLabel, "label of the pull request whose \"Release notes\" section is the notice (with the 'pr' source)")
+}
+
+// Check validates the source names.
+func (c Notice) Check() error {
+	for _, s := range c.Sources {
+		if !slices.Contains(notice.Sources, notice.Source(strings.TrimSpace(s))) {
+			return fmt.Errorf("unknown notice source %q (expected one of: tag, file, pr)", s)
+		}
+	}
+	return nil
+}
+
+// Config returns the resolver configuration.
+func (c Notice) Config() notice.Config {
+	cfg := notice.Config{NotesDir: c.NotesDir, PRLabel: c.PRLabel}
+	for _, s := range c.Sources {
+		cfg.Sources = append(cfg.Sources, notice.Source(strings.TrimSpace(s)))
+	}
+	return cfg
+}
+
+func DefaultNotice() Notice {
+	return Notice{
+		// a PR body is opt-in: it's written by whoever opened the PR, not
+		// necessarily whoever cuts the release.
+		Sources:  []string{string(notice.SourceTag), string(notice.SourceFile)},
+		NotesDir: ".chronicle/notes",
+		PRLabel:  "release-notice",
+	}
+}
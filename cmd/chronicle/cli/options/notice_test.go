@@ -0,0 +1,19 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/chronicle/chronicle/release/notice"
+)
+
+func TestNotice(t *testing.T) {
+	def := DefaultNotice()
+	require.NoError(t, def.Check())
+	assert.Equal(t, []notice.Source{notice.SourceTag, notice.SourceFile}, def.Config().Sources, "PR bodies are opt-in")
+
+	require.NoError(t, Notice{Sources: []string{"pr", " tag"}}.Check())
+	require.ErrorContains(t, Notice{Sources: []string{"tag", "wiki"}}.Check(), `"wiki"`)
+}
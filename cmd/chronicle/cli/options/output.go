@@ -13,6 +13,7 @@ import (
 	slackenc "github.com/anchore/chronicle/chronicle/release/output/encoders/slack"
 	trunkenc "github.com/anchore/chronicle/chronicle/release/output/encoders/trunk"
 	versionenc "github.com/anchore/chronicle/chronicle/release/output/encoders/version"
+	vexenc "github.com/anchore/chronicle/chronicle/release/output/encoders/vex"
 	"github.com/anchore/chronicle/internal/log"
 	"github.com/anchore/clio"
 	"github.com/anchore/fangs"
@@ -24,6 +25,26 @@ type TrunkOptions struct {
 	ShowFiltered bool `yaml:"show-filtered" json:"show-filtered" mapstructure:"show-filtered"`
 }
 
+// WebhookOptions holds delivery settings for outputs whose destination is an
+// http(s) URL (e.g. `-o slack=https://hooks.slack.com/...`).
+type WebhookOptions struct {
+	DryRun  bool `yaml:"dry-run" json:"dry-run" mapstructure:"dry-run"`
+	Retries int  `yaml:"retries" json:"retries" mapstructure:"retries"`
+}
+
+// VexOptions holds settings shared by the vex and cyclonedx-vex output formats.
+type VexOptions struct {
+	Author  string `yaml:"author" json:"author" mapstructure:"author"`
+	Product string `yaml:"product" json:"product" mapstructure:"product"`
+}
+
+var _ clio.FieldDescriber = (*VexOptions)(nil)
+
+func (c *VexOptions) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&c.Author, "author recorded in vex and cyclonedx-vex documents")
+	descriptions.Add(&c.Product, "product identifier for vex and cyclonedx-vex statements (default: pkg:github/OWNER/REPO@VERSION for GitHub repositories; a purl without a version gets the release version appended)")
+}
+
 // Output configures one or more `-o NAME[=PATH]` outputs for a command.
 // Embed this in a command's config (squashed) to expose the standard set
 // of output flags and decoding behavior.
@@ -41,12 +62,18 @@ type Output struct {
 
 	// Trunk holds format-specific options for the trunk encoder.
 	Trunk TrunkOptions `yaml:"trunk" json:"trunk" mapstructure:"trunk"`
+
+	// Webhook holds delivery settings for URL destinations.
+	Webhook WebhookOptions `yaml:"webhook" json:"webhook" mapstructure:"webhook"`
+
+	// Vex holds format-specific options for the vex encoders.
+	Vex VexOptions `yaml:"vex" json:"vex" mapstructure:"vex"`
 }
 
 var _ clio.FlagAdder = (*Output)(nil)
 
 // DefaultOutput returns an Output with the standard chronicle encoder set
-// (md, json, version, md-pretty, trunk) wired up and a default of markdown-on-stdout.
+// (md, json, version, slack, md-pretty, trunk, vex, cyclonedx-vex) wired up and a default of markdown-on-stdout.
 // TTY detection for md-pretty and trunk happens once at construction time; if stdout
 // later turns out to be piped, those encoders fall back gracefully.
 func DefaultOutput() Output {
@@ -62,9 +89,13 @@ func DefaultOutput() Output {
 				Condensed:    true,
 				ShowFiltered: true,
 			},
+			&vexenc.Encoder{Author: vexenc.DefaultAuthor},
+			&vexenc.CycloneDXEncoder{Author: vexenc.DefaultAuthor},
 		),
 		Outputs: []string{mdenc.ID},
 		Trunk:   TrunkOptions{Condensed: true, ShowFiltered: true},
+		Webhook: WebhookOptions{Retries: output.DefaultWebhookConfig().Retries},
+		Vex:     VexOptions{Author: vexenc.DefaultAuthor},
 	}
 }
 
@@ -97,6 +128,12 @@ func (o *Output) AddFlags(flags clio.FlagSet) {
 		"trunk format: show non-contributing commits and filtered PRs/issues dimmed",
 	)
 
+	flags.BoolVarP(
+		&o.Webhook.DryRun,
+		"webhook-dry-run", "",
+		"print the request for each URL output (-o NAME=https://...) instead of sending it",
+	)
+
 	// MarkDeprecated both hides the flag from help and prints a one-time
 	// notice on stderr whenever the flag is used on the command line. The
 	// runtime log.Warn in Specs() covers the yaml/env path that pflag never
@@ -132,9 +169,9 @@ func (o *Output) Check() error {
 }
 
 // Writer constructs the output writer for the configured specs, validated
-// against this Output's available encoder set. The trunk encoder is refreshed
-// from the current TrunkOptions so that flag-parsed values take effect even
-// though the encoder was constructed before flag parsing ran.
+// against this Output's available encoder set. The trunk and vex encoders are
+// refreshed from the current options so that flag-parsed values take effect
+// even though the encoders were constructed before flag parsing ran.
 func (o *Output) Writer() (output.Writer, error) {
 	// refresh trunk encoder fields from the (now flag-parsed) TrunkOptions
 	if enc, ok := o.Available[trunkenc.ID]; ok {
@@ -143,10 +180,19 @@ func (o *Output) Writer() (output.Writer, error) {
 			te.ShowFiltered = o.Trunk.ShowFiltered
 		}
 	}
+	if ve, ok := o.Available[vexenc.ID].(*vexenc.Encoder); ok {
+		ve.Author, ve.Product = o.Vex.Author, o.Vex.Product
+	}
+	if ce, ok := o.Available[vexenc.CycloneDXID].(*vexenc.CycloneDXEncoder); ok {
+		ce.Author, ce.Product = o.Vex.Author, o.Vex.Product
+	}
 
 	specs, err := o.Specs()
 	if err != nil {
 		return nil, err
 	}
-	return output.New(specs, o.Available)
+	wh := output.DefaultWebhookConfig()
+	wh.DryRun = o.Webhook.DryRun
+	wh.Retries = o.Webhook.Retries
+	return output.NewWithWebhook(specs, o.Available, wh)
 }
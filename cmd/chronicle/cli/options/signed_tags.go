@@ -0,0 +1,46 @@
+package options
+
+import (
+	"errors"
+
+	"github.com/anchore/chronicle/internal/git"
+	"github.com/anchore/clio"
+)
+
+// SignedTags configures verification of the since and until tags' signatures
+// against the keys trusted to cut releases.
+type SignedTags struct {
+	Require        bool   `yaml:"require" json:"require" mapstructure:"require"`
+	AllowedSigners string `yaml:"allowed-signers" json:"allowed-signers" mapstructure:"allowed-signers"`
+	Keyring        string `yaml:"keyring" json:"keyring" mapstructure:"keyring"`
+}
+
+var _ clio.FieldDescriber = (*SignedTags)(nil)
+
+func (c *SignedTags) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&c.Require, "fail unless the since and until refs are tags with a valid signature from a trusted key")
+	descriptions.Add(&c.AllowedSigners, "SSH allowed signers file (the format of git's gpg.ssh.allowedSignersFile) whose keys may sign release tags")
+	descriptions.Add(&c.Keyring, "OpenPGP keyring (armored or binary) whose keys may sign release tags")
+}
+
+// Enabled reports whether tag signatures are verified at all: requiring them,
+// or just having keys to show who signed.
+func (c SignedTags) Enabled() bool {
+	return c.Require || c.AllowedSigners != "" || c.Keyring != ""
+}
+
+// Check validates that a requirement has keys to verify against.
+func (c SignedTags) Check() error {
+	if c.Require && c.AllowedSigners == "" && c.Keyring == "" {
+		return errors.New("require-signed-tags needs keys to verify against; set signed-tags.allowed-signers and/or signed-tags.keyring")
+	}
+	return nil
+}
+
+// Verifier loads the trusted keys, or returns nil when tags aren't verified.
+func (c SignedTags) Verifier() (*git.TagVerifier, error) {
+	if !c.Enabled() {
+		return nil, nil
+	}
+	return git.NewTagVerifier(c.AllowedSigners, c.Keyring)
+}
@@ -0,0 +1,20 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignedTags(t *testing.T) {
+	off := SignedTags{}
+	assert.False(t, off.Enabled())
+	v, err := off.Verifier()
+	require.NoError(t, err)
+	assert.Nil(t, v, "tags aren't verified unless asked to")
+
+	require.Error(t, SignedTags{Require: true}.Check(), "a requirement needs keys to verify against")
+	require.NoError(t, SignedTags{Require: true, Keyring: "release-keys.asc"}.Check())
+	assert.True(t, SignedTags{AllowedSigners: "allowed_signers"}.Enabled(), "configured keys show who signed even when not required")
+}
@@ -38,6 +38,9 @@ func RenderSummary(groups []*event.Group, trees []*event.Tree, s event.Summary)
 	if vt := renderVersionTransition(s); vt != "" {
 		sections = append(sections, vt)
 	}
+	if gv := renderGateViolations(s.GateViolations); gv != "" {
+		sections = append(sections, gv)
+	}
 
 	return strings.Join(sections, "\n\n")
 }
@@ -342,6 +345,59 @@ func renderVersionTransition(s event.Summary) string {
 	)
 }
 
+// renderGateViolations builds the section listing the dependency changes that
+// failed the vulnerability gate, last in the recap so it sits right above the
+// error it explains: one row per package, with the forbidden vulnerabilities it
+// introduced and what made each one forbidden.
+func renderGateViolations(violations []event.SummaryGateViolation) string {
+	if len(violations) == 0 {
+		return ""
+	}
+
+	names := make([]string, len(violations))
+	nameWidth := 0
+	for i, v := range violations {
+		names[i] = v.Package
+		if v.Version != "" {
+			names[i] += "@" + v.Version
+		}
+		if l := len(names[i]); l > nameWidth {
+			nameWidth = l
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(failStyle.Bold(true).Render("Vulnerability gate failed"))
+	for i, v := range violations {
+		prefix := branchMid
+		if i == len(violations)-1 {
+			prefix = branchLast
+		}
+		vulns := make([]string, len(v.Vulnerabilities))
+		for j, vuln := range v.Vulnerabilities {
+			vulns[j] = vuln.ID + dimStyle.Render(" ("+gateVulnDetail(vuln)+")")
+		}
+		fmt.Fprintf(&b, "\n%s %s %s   %s", dimStyle.Render(prefix), failStyle.Render(xMark), padRight(names[i], nameWidth), strings.Join(vulns, ", "))
+	}
+	return b.String()
+}
+
+// gateVulnDetail is the parenthetical after a forbidden vulnerability's ID: its
+// severity and, when it applies, the KEV marker.
+func gateVulnDetail(v event.SummaryGateVuln) string {
+	var parts []string
+	if v.Severity != "" {
+		parts = append(parts, strings.ToLower(v.Severity))
+	}
+	if v.KnownExploited {
+		parts = append(parts, "KEV")
+	}
+	if len(parts) == 0 {
+		return "unknown severity"
+	}
+	return strings.Join(parts, ", ")
+}
+
 // highlightBumpedElement returns next with its major/minor/patch element styled
 // per the bump kind, leaving the rest at default fg.
 func highlightBumpedElement(prev, next string, kind change.SemVerKind) string {
@@ -187,6 +187,30 @@ func TestRenderSummary_PatchBump(t *testing.T) {
 	snaps.MatchSnapshot(t, out)
 }
 
+func TestRenderSummary_GateViolations(t *testing.T) {
+	out := RenderSummary(nil, nil, event.Summary{
+		Changes:         sampleChanges(),
+		PreviousVersion: "v0.18.0",
+		NextVersion:     "v0.18.1",
+		BumpKind:        change.SemVerPatch,
+		GateViolations: []event.SummaryGateViolation{
+			{
+				Package: "golang.org/x/net", Version: "v0.2.0",
+				Vulnerabilities: []event.SummaryGateVuln{
+					{ID: "CVE-2026-0001", Severity: "Critical"},
+					{ID: "CVE-2026-0002", Severity: "Medium", KnownExploited: true},
+				},
+			},
+			{
+				Package: "requests", Version: "2.31.0",
+				Vulnerabilities: []event.SummaryGateVuln{{ID: "GHSA-aaaa-bbbb-cccc", KnownExploited: true}},
+			},
+		},
+	})
+	require.Contains(t, out, "Vulnerability gate failed")
+	snaps.MatchSnapshot(t, out)
+}
+
 func TestHighlightBumpedElement_NotSemver(t *testing.T) {
 	got := highlightBumpedElement("not", "semver", change.SemVerMinor)
 	// must not panic; returns the next version (possibly styled).
@@ -54,3 +54,84 @@ func ListFilesAtRef(repoPath, ref string, match func(path string) bool) ([]FileB
 	}
 	return out, nil
 }
+
+// FileChange is a file a commit changed, with its content before (at the commit's first parent) and
+// after. Before is nil for a file the commit added, After for one it deleted; a rename shows up
+// under its new path with both sides set.
+type FileChange struct {
+	Path   string // path relative to the repo root, slash-separated
+	Before []byte
+	After  []byte
+}
+
+// FileChangesInCommit returns the files the commit at hash changed relative to its first parent (or
+// to an empty tree, for a root commit) for which match returns true (a nil match selects all). A
+// merge commit is diffed against its first parent, so it reports everything the merged branch
+// brought in.
+func FileChangesInCommit(repoPath, hash string, match func(path string) bool) ([]FileChange, error) {
+	r, err := openRepo(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open repo %q: %w", repoPath, err)
+	}
+
+	commit, err := r.CommitObject(plumbing.NewHash(hash))
+	if err != nil {
+		return nil, fmt.Errorf("unable to load commit %q: %w", hash, err)
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("unable to load tree for commit %q: %w", hash, err)
+	}
+
+	var parentTree *object.Tree
+	if commit.NumParents() > 0 {
+		parent, err := commit.Parent(0)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load parent of commit %q: %w", hash, err)
+		}
+		if parentTree, err = parent.Tree(); err != nil {
+			return nil, fmt.Errorf("unable to load tree for the parent of commit %q: %w", hash, err)
+		}
+	}
+
+	changes, err := object.DiffTree(parentTree, tree)
+	if err != nil {
+		return nil, fmt.Errorf("unable to diff commit %q against its parent: %w", hash, err)
+	}
+
+	var out []FileChange
+	for _, ch := range changes {
+		path := ch.To.Name
+		if path == "" {
+			path = ch.From.Name
+		}
+		if match != nil && !match(path) {
+			continue
+		}
+		from, to, err := ch.Files()
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %q in commit %q: %w", path, hash, err)
+		}
+		fc := FileChange{Path: path}
+		if fc.Before, err = fileContent(from); err != nil {
+			return nil, fmt.Errorf("unable to read %q before commit %q: %w", path, hash, err)
+		}
+		if fc.After, err = fileContent(to); err != nil {
+			return nil, fmt.Errorf("unable to read %q at commit %q: %w", path, hash, err)
+		}
+		out = append(out, fc)
+	}
+	return out, nil
+}
+
+// fileContent reads a file's content; a nil file (the absent side of an add or delete) is nil.
+func fileContent(f *object.File) ([]byte, error) {
+	if f == nil {
+		return nil, nil
+	}
+	content, err := f.Contents()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(content), nil
+}
@@ -11,11 +11,12 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func TestListFilesAtRef(t *testing.T) {
-	// build a throwaway repo with go.mod changing between two tags, plus a nested module, so we can
-	// exercise both content-at-ref reads and the recursive tree walk.
+// scratchRepo creates an empty repo on a main branch and returns its path, a git runner (returning
+// trimmed output) and a file writer for it.
+func scratchRepo(t *testing.T) (string, func(args ...string) string, func(rel, content string)) {
+	t.Helper()
 	repo := t.TempDir()
-	runGit := func(args ...string) {
+	runGit := func(args ...string) string {
 		t.Helper()
 		cmd := exec.Command("git", args...)
 		cmd.Dir = repo
@@ -28,6 +29,7 @@ func TestListFilesAtRef(t *testing.T) {
 		)
 		out, err := cmd.CombinedOutput()
 		require.NoErrorf(t, err, "git %s: %s", strings.Join(args, " "), out)
+		return strings.TrimSpace(string(out))
 	}
 	write := func(rel, content string) {
 		t.Helper()
@@ -35,9 +37,15 @@ func TestListFilesAtRef(t *testing.T) {
 		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o755))
 		require.NoError(t, os.WriteFile(full, []byte(content), 0o644))
 	}
-
 	runGit("init")
 	runGit("checkout", "-b", "main")
+	return repo, runGit, write
+}
+
+func TestListFilesAtRef(t *testing.T) {
+	// build a throwaway repo with go.mod changing between two tags, plus a nested module, so we can
+	// exercise both content-at-ref reads and the recursive tree walk.
+	repo, runGit, write := scratchRepo(t)
 
 	write("go.mod", "module example.com/foo\n\ngo 1.21\n")
 	write("tools/go.mod", "module example.com/foo/tools\n\ngo 1.21\n")
@@ -104,3 +112,73 @@ func TestListFilesAtRef(t *testing.T) {
 		assert.Equal(t, []string{"go.mod"}, dirty)
 	})
 }
+
+func TestFileChangesInCommit(t *testing.T) {
+	repo, runGit, write := scratchRepo(t)
+
+	write("go.mod", "module example.com/foo\n\nrequire golang.org/x/net v0.1.0\n")
+	write("README.md", "hello\n")
+	runGit("add", ".")
+	runGit("commit", "-m", "root")
+	root := runGit("rev-parse", "HEAD")
+
+	// a branch that bumps a dependency and adds a lockfile, merged with a merge commit
+	runGit("checkout", "-b", "bump")
+	write("go.mod", "module example.com/foo\n\nrequire golang.org/x/net v0.2.0\n")
+	write("go.sum", "golang.org/x/net v0.2.0 h1:abc\n")
+	runGit("add", ".")
+	runGit("commit", "-m", "bump x/net")
+	runGit("checkout", "main")
+	runGit("rm", "README.md")
+	runGit("commit", "-m", "drop readme")
+	runGit("merge", "--no-ff", "-m", "merge bump", "bump")
+	merge := runGit("rev-parse", "HEAD")
+	dropped := runGit("rev-parse", "HEAD^1")
+
+	byPath := func(changes []FileChange) map[string]FileChange {
+		out := map[string]FileChange{}
+		for _, c := range changes {
+			out[c.Path] = c
+		}
+		return out
+	}
+
+	t.Run("root commit is diffed against an empty tree", func(t *testing.T) {
+		changes, err := FileChangesInCommit(repo, root, nil)
+		require.NoError(t, err)
+		got := byPath(changes)
+		require.Len(t, got, 2)
+		assert.Nil(t, got["go.mod"].Before)
+		assert.Equal(t, "hello\n", string(got["README.md"].After))
+	})
+
+	t.Run("merge commit reports the merged branch against its first parent", func(t *testing.T) {
+		changes, err := FileChangesInCommit(repo, merge, nil)
+		require.NoError(t, err)
+		got := byPath(changes)
+		require.Len(t, got, 2, "the README deletion happened on the first parent, not in the merge")
+		assert.Equal(t, "module example.com/foo\n\nrequire golang.org/x/net v0.1.0\n", string(got["go.mod"].Before))
+		assert.Equal(t, "module example.com/foo\n\nrequire golang.org/x/net v0.2.0\n", string(got["go.mod"].After))
+		assert.Nil(t, got["go.sum"].Before)
+	})
+
+	t.Run("deleted file has no after side", func(t *testing.T) {
+		changes, err := FileChangesInCommit(repo, dropped, nil)
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+		assert.Equal(t, "README.md", changes[0].Path)
+		assert.Nil(t, changes[0].After)
+	})
+
+	t.Run("match filters paths", func(t *testing.T) {
+		changes, err := FileChangesInCommit(repo, merge, func(p string) bool { return p == "go.sum" })
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+		assert.Equal(t, "go.sum", changes[0].Path)
+	})
+
+	t.Run("unknown commit errors", func(t *testing.T) {
+		_, err := FileChangesInCommit(repo, strings.Repeat("0", 40), nil)
+		assert.Error(t, err)
+	})
+}
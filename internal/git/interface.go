@@ -13,10 +13,12 @@ type Interface interface {
 	HeadTag() (string, error)
 	RemoteURL() (string, error)
 	SearchForTag(tagRef string) (*Tag, error)
+	TagSignature(tagRef string) (*SignedTag, error)
 	TagsFromLocal() ([]Tag, error)
 	CommitsBetween(Range) ([]string, error)
 	CommitsBetweenWithMeta(Range) ([]Commit, error)
 	ListFilesAtRef(ref string, match func(path string) bool) ([]FileBlob, error)
+	FileChangesInCommit(hash string, match func(path string) bool) ([]FileChange, error)
 	WorktreeDirtyPaths() ([]string, error)
 }
 
@@ -61,6 +63,10 @@ func (g gitter) SearchForTag(tagRef string) (*Tag, error) {
 	return SearchForTag(g.repoPath, tagRef)
 }
 
+func (g gitter) TagSignature(tagRef string) (*SignedTag, error) {
+	return TagSignature(g.repoPath, tagRef)
+}
+
 func (g gitter) TagsFromLocal() ([]Tag, error) {
 	return TagsFromLocal(g.repoPath)
 }
@@ -73,6 +79,10 @@ func (g gitter) ListFilesAtRef(ref string, match func(path string) bool) ([]File
 	return ListFilesAtRef(g.repoPath, ref, match)
 }
 
+func (g gitter) FileChangesInCommit(hash string, match func(path string) bool) ([]FileChange, error) {
+	return FileChangesInCommit(g.repoPath, hash, match)
+}
+
 func (g gitter) WorktreeDirtyPaths() ([]string, error) {
 	return WorktreeDirtyPaths(g.repoPath)
 }
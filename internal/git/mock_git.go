@@ -1,5 +1,7 @@
 package git
 
+import "fmt"
+
 var _ Interface = (*MockInterface)(nil)
 
 type MockInterface struct {
@@ -11,8 +13,10 @@ type MockInterface struct {
 	MockCommitsBetween         []string
 	MockCommitsBetweenWithMeta []Commit
 	MockFirstCommit            string
-	MockFilesAtRef             map[string][]FileBlob // ref -> files present at that ref
-	MockDirtyPaths             []string              // working-tree paths with uncommitted changes
+	MockFilesAtRef             map[string][]FileBlob   // ref -> files present at that ref
+	MockDirtyPaths             []string                // working-tree paths with uncommitted changes
+	MockFileChanges            map[string][]FileChange // commit hash -> files that commit changed
+	MockTagSignatures          map[string]*SignedTag   // tag name -> its signature
 }
 
 func (m MockInterface) CommitsBetween(_ Range) ([]string, error) {
@@ -69,3 +73,20 @@ func (m MockInterface) ListFilesAtRef(ref string, match func(path string) bool)
 	}
 	return out, nil
 }
+
+func (m MockInterface) FileChangesInCommit(hash string, match func(path string) bool) ([]FileChange, error) {
+	var out []FileChange
+	for _, f := range m.MockFileChanges[hash] {
+		if match == nil || match(f.Path) {
+			out = append(out, f)
+		}
+	}
+	return out, nil
+}
+
+func (m MockInterface) TagSignature(tagRef string) (*SignedTag, error) {
+	if t, ok := m.MockTagSignatures[tagRef]; ok {
+		return t, nil
+	}
+	return nil, fmt.Errorf("unable to find git ref=%q", tagRef)
+}
@@ -0,0 +1,73 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// SignedTag is what a tag's signature covers and the signature itself, read
+// without verifying anything. A lightweight tag is just a ref, so it has no
+// payload and can't be signed.
+type SignedTag struct {
+	Name      string
+	Annotated bool
+	// Payload is the tag object as it was signed: the object with its
+	// signature block removed.
+	Payload []byte
+	// Signature is the armored signature block (OpenPGP or SSH), or "" when
+	// the tag is unsigned.
+	Signature string
+	// Tagged is when the tag was made, which is the time an SSH signing key's
+	// validity window is checked against (as git does).
+	Tagged time.Time
+}
+
+func TagSignature(repoPath, tagRef string) (*SignedTag, error) {
+	r, err := openRepo(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	ref, err := r.Reference(plumbing.NewTagReferenceName(tagRef), false)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find git ref=%q: %w", tagRef, err)
+	}
+
+	tagObj, err := object.GetTag(r.Storer, ref.Hash())
+	if errors.Is(err, plumbing.ErrObjectNotFound) {
+		// the ref points straight at a commit: a lightweight tag
+		return &SignedTag{Name: tagRef}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve tag for %q: %w", tagRef, err)
+	}
+
+	out := &SignedTag{
+		Name:      tagRef,
+		Annotated: true,
+		Signature: tagObj.PGPSignature,
+		Tagged:    tagObj.Tagger.When,
+	}
+	if out.Signature == "" {
+		return out, nil
+	}
+
+	encoded := &plumbing.MemoryObject{}
+	if err := tagObj.EncodeWithoutSignature(encoded); err != nil {
+		return nil, fmt.Errorf("unable to encode tag %q: %w", tagRef, err)
+	}
+	rd, err := encoded.Reader()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read tag %q: %w", tagRef, err)
+	}
+	defer rd.Close()
+	if out.Payload, err = io.ReadAll(rd); err != nil {
+		return nil, fmt.Errorf("unable to read tag %q: %w", tagRef, err)
+	}
+	return out, nil
+}
@@ -19,6 +19,7 @@ type Tag struct {
 	Timestamp time.Time
 	Commit    string
 	Annotated bool
+	Message   string // an annotated tag's message, without any signature; "" for a lightweight tag
 }
 
 type Range struct {
@@ -34,6 +35,7 @@ type Commit struct {
 	Subject   string // first line of the commit message
 	Author    string
 	Timestamp time.Time
+	Parents   []string // parent hashes, first parent first; empty for a root commit
 }
 
 func CommitsBetween(repoPath string, cfg Range) ([]string, error) {
@@ -130,6 +132,9 @@ func CommitsBetweenWithMeta(repoPath string, cfg Range) ([]Commit, error) {
 			Author:    c.Author.Name,
 			Timestamp: c.Author.When,
 		}
+		for _, p := range c.ParentHashes {
+			entry.Parents = append(entry.Parents, p.String())
+		}
 
 		switch {
 		// check the since boundary first: it is the stop condition, so when since
@@ -252,5 +257,6 @@ func newTag(r *git.Repository, t *plumbing.Reference) (*Tag, error) {
 		Timestamp: tagObj.Tagger.When.In(time.Local),
 		Commit:    tagObj.Target.String(),
 		Annotated: true,
+		Message:   tagObj.Message,
 	}, nil
 }
@@ -343,7 +343,7 @@ func gitCommitsWithMeta(t *testing.T, path string, cfg Range) []Commit {
 
 	// use the same inclusive range trick as gitLogRange: since~1..until
 	cmd := exec.Command("git", "--no-pager", "log",
-		`--pretty=format:%H|%s|%an|%aI`,
+		`--pretty=format:%H|%s|%an|%aI|%P`,
 		fmt.Sprintf("%s~1..%s", cfg.SinceRef, cfg.UntilRef),
 	)
 	cmd.Dir = path
@@ -357,8 +357,8 @@ func gitCommitsWithMeta(t *testing.T, path string, cfg Range) []Commit {
 		if row == "" {
 			continue
 		}
-		parts := strings.SplitN(row, "|", 4)
-		require.Len(t, parts, 4, "unexpected git log row: %q", row)
+		parts := strings.SplitN(row, "|", 5)
+		require.Len(t, parts, 5, "unexpected git log row: %q", row)
 
 		ts, err := time.Parse(time.RFC3339, parts[3])
 		require.NoError(t, err)
@@ -368,6 +368,7 @@ func gitCommitsWithMeta(t *testing.T, path string, cfg Range) []Commit {
 			Subject:   parts[1],
 			Author:    parts[2],
 			Timestamp: ts,
+			Parents:   strings.Fields(parts[4]),
 		})
 	}
 
@@ -460,10 +461,12 @@ func expectedTags(t *testing.T, path string) []Tag {
 		nameFields := strings.Split(name, "/")
 		date := dateForCommit(t, path, tagCommit)
 		var annotated bool
+		var message string
 		switch ty {
 		case "tag":
 			annotated = true
 			date = dateForAnnotatedTag(t, path, name)
+			message = messageForAnnotatedTag(t, path, name)
 		case "commit":
 			annotated = false
 			date = dateForCommit(t, path, tagCommit)
@@ -476,6 +479,7 @@ func expectedTags(t *testing.T, path string) []Tag {
 			Timestamp: date,
 			Commit:    tagHash(t, path, name),
 			Annotated: annotated,
+			Message:   message,
 		})
 	}
 
@@ -519,6 +523,15 @@ func dateForAnnotatedTag(t *testing.T, path string, tag string) time.Time {
 	return tt
 }
 
+func messageForAnnotatedTag(t *testing.T, path string, tag string) string {
+	cmd := exec.Command("git", "--no-pager", "for-each-ref", "--format=%(contents)", tag)
+	cmd.Dir = path
+	output, err := cmd.Output()
+	require.NoError(t, err)
+	// for-each-ref ends every ref's output with a newline of its own
+	return strings.TrimSuffix(string(output), "\n")
+}
+
 func tagHash(t *testing.T, repo string, tag string) string {
 	// note: this will work for both lightweight and annotated tags since we are dereferencing the tag to the closest
 	// commit object with the ^{commit} syntax
@@ -0,0 +1,295 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/anchore/chronicle/internal/log"
+)
+
+// ErrUnsignedTag is returned when a tag carries no signature to verify.
+var ErrUnsignedTag = errors.New("tag is not signed")
+
+const (
+	pgpSignatureHeader = "-----BEGIN PGP SIGNATURE-----"
+	sshSignatureHeader = "-----BEGIN SSH SIGNATURE-----"
+	sshSignatureFooter = "-----END SSH SIGNATURE-----"
+
+	// sshSigMagic opens both an SSH signature blob and the data it signs
+	// (see OpenSSH's PROTOCOL.sshsig).
+	sshSigMagic = "SSHSIG"
+	// sshSigNamespace is the namespace git signs with, which keeps a signature
+	// made for something else (a file, an email) from passing for a tag's.
+	sshSigNamespace = "git"
+)
+
+// TagVerifier checks tag signatures against the keys trusted to sign
+// releases: an OpenPGP keyring for GPG signatures and an allowed signers file
+// (the format git's gpg.ssh.allowedSignersFile uses) for SSH ones.
+type TagVerifier struct {
+	keyring openpgp.EntityList
+	signers []allowedSigner
+}
+
+// NewTagVerifier loads the trusted keys from an allowed signers file and/or an
+// OpenPGP keyring (armored or binary). Either path may be empty, in which case
+// signatures of that kind are never trusted.
+func NewTagVerifier(allowedSignersPath, keyringPath string) (*TagVerifier, error) {
+	v := &TagVerifier{}
+	if allowedSignersPath != "" {
+		content, err := os.ReadFile(allowedSignersPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read allowed signers %q: %w", allowedSignersPath, err)
+		}
+		if v.signers, err = parseAllowedSigners(content); err != nil {
+			return nil, fmt.Errorf("unable to parse allowed signers %q: %w", allowedSignersPath, err)
+		}
+	}
+	if keyringPath != "" {
+		content, err := os.ReadFile(keyringPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read keyring %q: %w", keyringPath, err)
+		}
+		if v.keyring, err = readKeyring(content); err != nil {
+			return nil, fmt.Errorf("unable to parse keyring %q: %w", keyringPath, err)
+		}
+	}
+	return v, nil
+}
+
+func readKeyring(content []byte) (openpgp.EntityList, error) {
+	if bytes.Contains(content, []byte("-----BEGIN PGP")) {
+		return openpgp.ReadArmoredKeyRing(bytes.NewReader(content))
+	}
+	return openpgp.ReadKeyRing(bytes.NewReader(content))
+}
+
+// Verify checks the tag's signature and returns who made it: the key's
+// primary user ID for a GPG signature, or the allowed signers entry's
+// principals for an SSH one. An unsigned tag fails with ErrUnsignedTag.
+func (v *TagVerifier) Verify(tag *SignedTag) (string, error) {
+	switch {
+	case !tag.Annotated:
+		return "", fmt.Errorf("%w: %q is a lightweight tag, which can't carry a signature", ErrUnsignedTag, tag.Name)
+	case tag.Signature == "":
+		return "", fmt.Errorf("%w: %q", ErrUnsignedTag, tag.Name)
+	case strings.HasPrefix(tag.Signature, sshSignatureHeader):
+		return v.verifySSH(tag)
+	case strings.HasPrefix(tag.Signature, pgpSignatureHeader):
+		return v.verifyPGP(tag)
+	}
+	return "", fmt.Errorf("tag %q has an unsupported signature type (only GPG and SSH signatures are verified)", tag.Name)
+}
+
+func (v *TagVerifier) verifyPGP(tag *SignedTag) (string, error) {
+	if len(v.keyring) == 0 {
+		return "", fmt.Errorf("tag %q has a GPG signature, but no keyring is configured", tag.Name)
+	}
+	entity, err := openpgp.CheckArmoredDetachedSignature(v.keyring, bytes.NewReader(tag.Payload), strings.NewReader(tag.Signature), nil)
+	if err != nil {
+		return "", fmt.Errorf("invalid GPG signature on tag %q: %w", tag.Name, err)
+	}
+	if id := entity.PrimaryIdentity(); id != nil {
+		return id.Name, nil
+	}
+	return strings.ToUpper(entity.PrimaryKey.KeyIdString()), nil
+}
+
+func (v *TagVerifier) verifySSH(tag *SignedTag) (string, error) {
+	if len(v.signers) == 0 {
+		return "", fmt.Errorf("tag %q has an SSH signature, but no allowed signers file is configured", tag.Name)
+	}
+	sig, err := parseSSHSignature(tag.Signature)
+	if err != nil {
+		return "", fmt.Errorf("unable to read the SSH signature on tag %q: %w", tag.Name, err)
+	}
+	if sig.namespace != sshSigNamespace {
+		return "", fmt.Errorf("SSH signature on tag %q was made for namespace %q, not %q", tag.Name, sig.namespace, sshSigNamespace)
+	}
+	if err := sig.verify(tag.Payload); err != nil {
+		return "", fmt.Errorf("invalid SSH signature on tag %q: %w", tag.Name, err)
+	}
+
+	// the signature is sound; what's left is whether its key may sign tags.
+	keyBytes := sig.key.Marshal()
+	for _, s := range v.signers {
+		if bytes.Equal(s.key.Marshal(), keyBytes) && s.allows(sshSigNamespace, tag.Tagged) {
+			return s.principals, nil
+		}
+	}
+	return "", fmt.Errorf("tag %q is signed by %s %s, which is not an allowed signer", tag.Name, sig.key.Type(), ssh.FingerprintSHA256(sig.key))
+}
+
+// sshSignature is a decoded SSH signature blob.
+type sshSignature struct {
+	key           ssh.PublicKey
+	namespace     string
+	reserved      string
+	hashAlgorithm string
+	signature     *ssh.Signature
+}
+
+func parseSSHSignature(armored string) (*sshSignature, error) {
+	body := strings.TrimSpace(armored)
+	body = strings.TrimPrefix(body, sshSignatureHeader)
+	body, _, ok := strings.Cut(body, sshSignatureFooter)
+	if !ok {
+		return nil, errors.New("missing signature footer")
+	}
+	raw, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(body), ""))
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode signature: %w", err)
+	}
+	if !bytes.HasPrefix(raw, []byte(sshSigMagic)) {
+		return nil, errors.New("not an SSH signature")
+	}
+
+	var blob struct {
+		Version       uint32
+		PublicKey     []byte
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     []byte
+	}
+	if err := ssh.Unmarshal(raw[len(sshSigMagic):], &blob); err != nil {
+		return nil, fmt.Errorf("unable to parse signature: %w", err)
+	}
+	if blob.Version != 1 {
+		return nil, fmt.Errorf("unsupported signature version %d", blob.Version)
+	}
+	key, err := ssh.ParsePublicKey(blob.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse signing key: %w", err)
+	}
+	sig := &ssh.Signature{}
+	if err := ssh.Unmarshal(blob.Signature, sig); err != nil {
+		return nil, fmt.Errorf("unable to parse signature: %w", err)
+	}
+	return &sshSignature{
+		key:           key,
+		namespace:     blob.Namespace,
+		reserved:      blob.Reserved,
+		hashAlgorithm: blob.HashAlgorithm,
+		signature:     sig,
+	}, nil
+}
+
+// verify checks the signature over message. What the key signs is not the
+// message itself but a blob binding its digest to the namespace.
+func (s *sshSignature) verify(message []byte) error {
+	var digest []byte
+	switch s.hashAlgorithm {
+	case "sha256":
+		h := sha256.Sum256(message)
+		digest = h[:]
+	case "sha512":
+		h := sha512.Sum512(message)
+		digest = h[:]
+	default:
+		return fmt.Errorf("unsupported hash algorithm %q", s.hashAlgorithm)
+	}
+	signed := append([]byte(sshSigMagic), ssh.Marshal(struct {
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Digest        []byte
+	}{s.namespace, s.reserved, s.hashAlgorithm, digest})...)
+	return s.key.Verify(signed, s.signature)
+}
+
+// allowedSigner is one line of an allowed signers file:
+// "principals [options] keytype base64-key [comment]".
+type allowedSigner struct {
+	principals  string
+	key         ssh.PublicKey
+	namespaces  []string
+	validAfter  time.Time
+	validBefore time.Time
+}
+
+// allows reports whether the entry's key may sign in namespace at time t.
+func (s allowedSigner) allows(namespace string, t time.Time) bool {
+	if len(s.namespaces) > 0 && !slices.Contains(s.namespaces, namespace) {
+		return false
+	}
+	if !s.validAfter.IsZero() && t.Before(s.validAfter) {
+		return false
+	}
+	if !s.validBefore.IsZero() && !t.Before(s.validBefore) {
+		return false
+	}
+	return true
+}
+
+func parseAllowedSigners(content []byte) ([]allowedSigner, error) {
+	var out []allowedSigner
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		principals, rest, _ := strings.Cut(line, " ")
+		// ParseAuthorizedKey reads the same "[options] keytype key [comment]"
+		// shape an authorized_keys line has, quoted options included.
+		key, _, options, _, err := ssh.ParseAuthorizedKey([]byte(strings.TrimSpace(rest)))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		s := allowedSigner{principals: principals, key: key}
+		skip := false
+		for _, opt := range options {
+			name, value, _ := strings.Cut(opt, "=")
+			value = strings.Trim(value, `"`)
+			switch strings.ToLower(name) {
+			case "namespaces":
+				s.namespaces = strings.Split(value, ",")
+			case "valid-after":
+				s.validAfter, err = parseSignerTime(value)
+			case "valid-before":
+				s.validBefore, err = parseSignerTime(value)
+			case "cert-authority":
+				// a CA key vouches for certificates, which tag signatures made
+				// with a plain key never carry.
+				log.WithFields("line", n).Debug("skipping cert-authority allowed signer; certificate signatures are not supported")
+				skip = true
+			}
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid %s: %w", n, name, err)
+			}
+		}
+		if !skip {
+			out = append(out, s)
+		}
+	}
+	return out, scanner.Err()
+}
+
+// parseSignerTime reads an allowed signers timestamp: YYYYMMDD[HHMM[SS]],
+// in local time unless suffixed with Z.
+func parseSignerTime(value string) (time.Time, error) {
+	loc := time.Local
+	if strings.HasSuffix(value, "Z") {
+		loc = time.UTC
+		value = strings.TrimSuffix(value, "Z")
+	}
+	for _, layout := range []string{"20060102", "200601021504", "20060102150405"} {
+		if len(value) == len(layout) {
+			return time.ParseInLocation(layout, value, loc)
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized time %q", value)
+}
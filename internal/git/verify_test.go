@@ -0,0 +1,203 @@
+package git
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+// sshSign produces the armored signature `ssh-keygen -Y sign -n <namespace>`
+// would for message.
+func sshSign(t *testing.T, signer ssh.Signer, namespace string, message []byte) string {
+	t.Helper()
+	digest := sha512.Sum512(message)
+	signed := append([]byte(sshSigMagic), ssh.Marshal(struct {
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Digest        []byte
+	}{namespace, "", "sha512", digest[:]})...)
+	sig, err := signer.Sign(rand.Reader, signed)
+	require.NoError(t, err)
+
+	blob := append([]byte(sshSigMagic), ssh.Marshal(struct {
+		Version       uint32
+		PublicKey     []byte
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     []byte
+	}{1, signer.PublicKey().Marshal(), namespace, "", "sha512", ssh.Marshal(sig)})...)
+	return sshSignatureHeader + "\n" + base64.StdEncoding.EncodeToString(blob) + "\n" + sshSignatureFooter + "\n"
+}
+
+func newSSHSigner(t *testing.T) ssh.Signer {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(key)
+	require.NoError(t, err)
+	return signer
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(p, []byte(content), 0o600))
+	return p
+}
+
+func TestTagVerifier_SSH(t *testing.T) {
+	alice := newSSHSigner(t)
+	mallory := newSSHSigner(t)
+	tagged := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	payload := []byte("object 0123\ntype commit\ntag v1.0.0\n\nrelease v1.0.0\n")
+	authorized := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(alice.PublicKey())))
+
+	tests := []struct {
+		name       string
+		allowed    string
+		signature  string
+		payload    []byte
+		wantSigner string
+		wantErr    string
+	}{
+		{
+			name:       "allowed signer",
+			allowed:    "# release managers\nalice@example.com " + authorized + " laptop\n",
+			signature:  sshSign(t, alice, "git", payload),
+			wantSigner: "alice@example.com",
+		},
+		{
+			name:       "options before the key",
+			allowed:    `alice@example.com,ops@example.com namespaces="git",valid-after="20250101" ` + authorized + "\n",
+			signature:  sshSign(t, alice, "git", payload),
+			wantSigner: "alice@example.com,ops@example.com",
+		},
+		{
+			name:      "key not allowed",
+			allowed:   "alice@example.com " + authorized + "\n",
+			signature: sshSign(t, mallory, "git", payload),
+			wantErr:   "not an allowed signer",
+		},
+		{
+			name:      "tampered payload",
+			allowed:   "alice@example.com " + authorized + "\n",
+			signature: sshSign(t, alice, "git", payload),
+			payload:   []byte("object 0123\ntype commit\ntag v1.0.0\n\nrelease v9.9.9\n"),
+			wantErr:   "invalid SSH signature",
+		},
+		{
+			name:      "signature made for another namespace",
+			allowed:   "alice@example.com " + authorized + "\n",
+			signature: sshSign(t, alice, "file", payload),
+			wantErr:   `namespace "file"`,
+		},
+		{
+			name:      "key restricted to other namespaces",
+			allowed:   `alice@example.com namespaces="file" ` + authorized + "\n",
+			signature: sshSign(t, alice, "git", payload),
+			wantErr:   "not an allowed signer",
+		},
+		{
+			name:      "tagged after the key expired",
+			allowed:   `alice@example.com valid-before="20250201Z" ` + authorized + "\n",
+			signature: sshSign(t, alice, "git", payload),
+			wantErr:   "not an allowed signer",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := NewTagVerifier(writeFile(t, "allowed_signers", tt.allowed), "")
+			require.NoError(t, err)
+
+			if tt.payload == nil {
+				tt.payload = payload
+			}
+			signer, err := v.Verify(&SignedTag{Name: "v1.0.0", Annotated: true, Payload: tt.payload, Signature: tt.signature, Tagged: tagged})
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSigner, signer)
+		})
+	}
+}
+
+func TestTagVerifier_Unsigned(t *testing.T) {
+	v, err := NewTagVerifier("", "")
+	require.NoError(t, err)
+
+	_, err = v.Verify(&SignedTag{Name: "v1.0.0"})
+	require.ErrorIs(t, err, ErrUnsignedTag)
+	_, err = v.Verify(&SignedTag{Name: "v1.0.0", Annotated: true})
+	require.ErrorIs(t, err, ErrUnsignedTag)
+
+	_, err = v.Verify(&SignedTag{Name: "v1.0.0", Annotated: true, Signature: sshSign(t, newSSHSigner(t), "git", nil)})
+	require.ErrorContains(t, err, "no allowed signers file is configured")
+}
+
+func TestTagSignature_GPG(t *testing.T) {
+	bob, err := openpgp.NewEntity("Bob", "", "bob@example.com", nil)
+	require.NoError(t, err)
+	eve, err := openpgp.NewEntity("Eve", "", "eve@example.com", nil)
+	require.NoError(t, err)
+
+	// a real repository, so the payload is exactly what go-git signed.
+	dir := t.TempDir()
+	r, err := gogit.PlainInit(dir, false)
+	require.NoError(t, err)
+	wt, err := r.Worktree()
+	require.NoError(t, err)
+	sig := &object.Signature{Name: "Bob", Email: "bob@example.com", When: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)}
+	head, err := wt.Commit("init", &gogit.CommitOptions{AllowEmptyCommits: true, Author: sig})
+	require.NoError(t, err)
+	_, err = r.CreateTag("v1.0.0", head, &gogit.CreateTagOptions{Tagger: sig, Message: "release v1.0.0", SignKey: bob})
+	require.NoError(t, err)
+	_, err = r.CreateTag("v1.0.1", head, &gogit.CreateTagOptions{Tagger: sig, Message: "forged", SignKey: eve})
+	require.NoError(t, err)
+	_, err = r.CreateTag("v1.0.2", head, nil)
+	require.NoError(t, err)
+
+	var keyring bytes.Buffer
+	w, err := armor.Encode(&keyring, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, bob.Serialize(w))
+	require.NoError(t, w.Close())
+	v, err := NewTagVerifier("", writeFile(t, "keyring.asc", keyring.String()))
+	require.NoError(t, err)
+
+	signed, err := TagSignature(dir, "v1.0.0")
+	require.NoError(t, err)
+	assert.True(t, signed.Annotated)
+	signer, err := v.Verify(signed)
+	require.NoError(t, err)
+	assert.Equal(t, "Bob <bob@example.com>", signer)
+
+	forged, err := TagSignature(dir, "v1.0.1")
+	require.NoError(t, err)
+	_, err = v.Verify(forged)
+	require.ErrorContains(t, err, "invalid GPG signature")
+
+	lightweight, err := TagSignature(dir, "v1.0.2")
+	require.NoError(t, err)
+	assert.False(t, lightweight.Annotated)
+	_, err = v.Verify(lightweight)
+	require.ErrorIs(t, err, ErrUnsignedTag)
+}
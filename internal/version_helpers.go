@@ -0,0 +1,22 @@
+package internal
+
+// CompareVersionSegments orders two versions parsed into numeric segments (e.g. 3.9.1 as
+// [3 9 1]), treating missing trailing segments as zero so 3.9 and 3.9.0 are equal.
+func CompareVersionSegments(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}